@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/bhati00/workova/backend/config"
+	"github.com/bhati00/workova/backend/internal/alert"
+	alertrepository "github.com/bhati00/workova/backend/internal/alert/repository"
 	"github.com/bhati00/workova/backend/internal/job"
 	"github.com/bhati00/workova/backend/internal/job/repository"
 	"github.com/bhati00/workova/backend/internal/worker"
@@ -34,13 +36,24 @@ func main() {
 	locationRepo := repository.NewLocationRepository(db)
 	categoryRepo := repository.NewCategoryRepository(db)
 	skillRepo := repository.NewSkillRepository(db)
+	companyRepo := repository.NewCompanyRepository(db)
 
-	jobService := job.NewJobService(jobRepo, skillRepo, categoryRepo, locationRepo)
+	jobService := job.NewJobService(jobRepo, skillRepo, categoryRepo, locationRepo, companyRepo, job.WithSummaryLength(cfg.SummaryLength))
 
 	// Initialize aggregators
 	aggregatorList := initializeAggregators(logger)
 	worker := worker.NewWorker(aggregatorList, jobService)
 
+	// Saved search alerts go out after each aggregation
+	notifier, err := alert.NewNotifier(cfg)
+	if err != nil {
+		logger.Error("Failed to configure alert notifier", "error", err)
+		os.Exit(1)
+	}
+	matcher := alert.NewMatcher(alertrepository.NewSavedSearchRepository(db), jobService, notifier)
+	// Searches expand synonyms from the dictionary the server maintains, alerts need it too
+	synonyms := job.NewSynonymService(cfg.SynonymsPath)
+
 	// Setup fetch options
 	fetchOptions := job_aggregator.FetchOptions{
 		Pages:      3,
@@ -50,7 +63,7 @@ func main() {
 
 	// Run initial aggregation
 	logger.Info("Running initial job aggregation")
-	go runAggregation(worker, fetchOptions, logger)
+	go runAggregation(worker, matcher, synonyms, fetchOptions, logger)
 
 	// Setup midnight scheduler
 	scheduler := setupMidnightScheduler()
@@ -67,7 +80,7 @@ func main() {
 		select {
 		case <-scheduler.C:
 			logger.Info("Daily aggregation triggered")
-			go runAggregation(worker, fetchOptions, logger)
+			go runAggregation(worker, matcher, synonyms, fetchOptions, logger)
 
 		case <-quit:
 			logger.Info("Shutting down aggregator service")
@@ -104,7 +117,7 @@ func setupMidnightScheduler() *time.Ticker {
 	return time.NewTicker(24 * time.Hour)
 }
 
-func runAggregation(worker *worker.Worker, options job_aggregator.FetchOptions, logger *slog.Logger) {
+func runAggregation(worker *worker.Worker, matcher *alert.Matcher, synonyms job.SynonymService, options job_aggregator.FetchOptions, logger *slog.Logger) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
 
@@ -140,11 +153,28 @@ func runAggregation(worker *worker.Worker, options job_aggregator.FetchOptions,
 				"jobs_processed", result.count,
 				"duration", duration)
 		}
+		runAlerts(matcher, synonyms, logger)
 	case <-ctx.Done():
 		logger.Error("Aggregation timed out after 30 minutes")
 	}
 }
 
+// runAlerts sends the digests of the saved searches with new matches. It also runs after
+// aggregations with errors, for the jobs the other aggregators saved. The synonyms are
+// reloaded first so alerts match like the API, whose admins may have edited them.
+func runAlerts(matcher *alert.Matcher, synonyms job.SynonymService, logger *slog.Logger) {
+	if err := synonyms.Load(); err != nil {
+		// Same as the server: searches run with the rules loaded last, if any
+		logger.Error("Failed to load synonyms", "error", err)
+	}
+	sent, err := matcher.Run()
+	if err != nil {
+		logger.Error("Saved search alerts failed", "error", err)
+		return
+	}
+	logger.Info("Saved search alerts sent", "digests", sent)
+}
+
 func getOneMonthAgo() *time.Time {
 	oneMonthAgo := time.Now().AddDate(0, -1, 0)
 	return &oneMonthAgo
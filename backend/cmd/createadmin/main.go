@@ -0,0 +1,60 @@
+// Command createadmin makes the first admin of a fresh install. Later admins are promoted
+// by an admin with PUT /api/admin/users/{id}/role.
+//
+// Run it from the backend directory, like the server:
+//
+//	go run ./cmd/createadmin -email root@example.com -name "Site Admin"
+//
+// The password is read from ADMIN_PASSWORD, or else from the first line of stdin. It is
+// only needed when the email has no account yet, an existing account is promoted as is.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/bhati00/workova/backend/config"
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user"
+	"github.com/bhati00/workova/backend/internal/user/repository"
+	"github.com/bhati00/workova/backend/pkg/database"
+)
+
+func main() {
+	email := flag.String("email", "", "Email of the admin account (required)")
+	name := flag.String("name", "", "Name of the admin, for new accounts")
+	flag.Parse()
+	if *email == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg := config.LoadConfig()
+	user.Migrate(cfg.DBpath)
+	db := database.ConnectDatabase(*cfg)
+	userService := user.NewUserService(repository.NewUserRepository(db), []byte(cfg.JWTSecret))
+
+	admin, err := userService.BootstrapAdmin(dtos.SignupRequest{
+		Email:    *email,
+		Password: readPassword(),
+		Name:     *name,
+	})
+	if err != nil {
+		log.Fatalf("Could not create admin: %v", err)
+	}
+	log.Printf("User %s (ID: %d) is now an admin", admin.Email, admin.ID)
+}
+
+// readPassword returns ADMIN_PASSWORD, or the first line of stdin
+func readPassword() string {
+	if password := os.Getenv("ADMIN_PASSWORD"); password != "" {
+		return password
+	}
+	fmt.Fprint(os.Stderr, "Password (ignored for existing accounts): ")
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimRight(line, "\r\n")
+}
@@ -1,11 +1,33 @@
 package config
 
+import "os"
+
 type Config struct {
-	DBpath string
+	DBpath        string
+	SummaryLength int    // Max characters of the summaries generated at ingestion
+	SynonymsPath  string // Synonym rules job search queries are expanded with
+	JWTSecret     string // Signs access tokens, a random one is used when empty
+
+	// Saved search alerts are mailed through SMTPAddr (host:port) when set. Otherwise they
+	// are appended to AlertsPath, or logged when that is empty too. Password reset tokens
+	// are only ever mailed, resets are disabled without SMTPAddr.
+	SMTPAddr     string
+	SMTPFrom     string
+	SMTPUsername string
+	SMTPPassword string
+	AlertsPath   string
 }
 
 func LoadConfig() *Config {
 	return &Config{
-		DBpath: "data/workova.db",
+		DBpath:        "data/workova.db",
+		SummaryLength: 300,
+		SynonymsPath:  "data/synonyms.txt",
+		JWTSecret:     os.Getenv("JWT_SECRET"),
+		SMTPAddr:      os.Getenv("SMTP_ADDR"),
+		SMTPFrom:      os.Getenv("SMTP_FROM"),
+		SMTPUsername:  os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:  os.Getenv("SMTP_PASSWORD"),
+		AlertsPath:    os.Getenv("ALERTS_PATH"),
 	}
 }
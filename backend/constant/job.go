@@ -1,5 +1,10 @@
 package constant
 
+import (
+	"strconv"
+	"strings"
+)
+
 type JobType int
 
 const (
@@ -36,3 +41,78 @@ const (
 	CurrencyGBP Currency = "GBP"
 	CurrencyINR Currency = "INR"
 )
+
+var jobTypeNames = map[JobType]string{
+	JobTypeFullTime:   "full_time",
+	JobTypePartTime:   "part_time",
+	JobTypeContract:   "contract",
+	JobTypeInternship: "internship",
+	JobTypeTemporary:  "temporary",
+}
+
+// String returns the API name of the job type, e.g. "full_time"
+func (t JobType) String() string {
+	return jobTypeNames[t]
+}
+
+var workModeNames = map[WorkMode]string{
+	WorkModeRemote: "remote",
+	WorkModeOnsite: "onsite",
+	WorkModeHybrid: "hybrid",
+}
+
+// String returns the API name of the work mode, e.g. "remote"
+func (m WorkMode) String() string {
+	return workModeNames[m]
+}
+
+var experienceLevelNames = map[ExperienceLevel]string{
+	ExperienceLevelEntry:     "entry",
+	ExperienceLevelMid:       "mid",
+	ExperienceLevelSenior:    "senior",
+	ExperienceLevelLead:      "lead",
+	ExperienceLevelExecutive: "executive",
+}
+
+// String returns the API name of the experience level, e.g. "senior"
+func (l ExperienceLevel) String() string {
+	return experienceLevelNames[l]
+}
+
+// normalizeEnumName lower-cases s and turns dashes and spaces into underscores, so
+// "Full-time" and "full time" both read as "full_time"
+func normalizeEnumName(s string) string {
+	return strings.NewReplacer("-", "_", " ", "_").Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// parseEnum reads an enum from its API name, an alias or its number
+func parseEnum[T ~int](s string, names map[T]string, aliases map[string]T) (T, bool) {
+	s = normalizeEnumName(s)
+	if number, err := strconv.Atoi(s); err == nil {
+		_, ok := names[T(number)]
+		return T(number), ok
+	}
+	for value, name := range names {
+		if name == s {
+			return value, true
+		}
+	}
+	value, ok := aliases[s]
+	return value, ok
+}
+
+// ParseJobType reads a job type from its name ("full_time", "Full-time") or number ("1")
+func ParseJobType(s string) (JobType, bool) {
+	return parseEnum(s, jobTypeNames, map[string]JobType{"fulltime": JobTypeFullTime, "parttime": JobTypePartTime, "intern": JobTypeInternship, "temp": JobTypeTemporary})
+}
+
+// ParseWorkMode reads a work mode from its name ("remote", "on-site") or number ("1")
+func ParseWorkMode(s string) (WorkMode, bool) {
+	return parseEnum(s, workModeNames, map[string]WorkMode{"on_site": WorkModeOnsite, "office": WorkModeOnsite})
+}
+
+// ParseExperienceLevel reads an experience level from its name ("senior", "entry-level") or number ("3")
+func ParseExperienceLevel(s string) (ExperienceLevel, bool) {
+	return parseEnum(s, experienceLevelNames, map[string]ExperienceLevel{"entry_level": ExperienceLevelEntry, "junior": ExperienceLevelEntry,
+		"mid_level": ExperienceLevelMid, "intermediate": ExperienceLevelMid, "staff": ExperienceLevelLead, "principal": ExperienceLevelLead})
+}
@@ -15,16 +15,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/jobs": {
+        "/admin/api-keys": {
             "get": {
-                "description": "Returns paginated list of jobs",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns paginated API keys with their usage today, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jobs"
+                    "Admin"
                 ],
-                "summary": "Get all jobs",
+                "summary": "List API keys",
                 "parameters": [
                     {
                         "type": "integer",
@@ -42,6 +47,30 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.PaginatedAPIKeysResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
@@ -55,7 +84,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new job entry",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a key for the X-API-Key header. The key is only shown in this response. Scopes are jobs:read, jobs:write and export.",
                 "consumes": [
                     "application/json"
                 ],
@@ -63,17 +97,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Jobs"
+                    "Admin"
                 ],
-                "summary": "Create a new job",
+                "summary": "Issue an API key",
                 "parameters": [
                     {
-                        "description": "Job object",
-                        "name": "job",
+                        "description": "Key",
+                        "name": "key",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.JobRequest"
+                            "$ref": "#/definitions/dtos.CreateAPIKeyRequest"
                         }
                     }
                 ],
@@ -81,7 +115,19 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.APIKeyResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -90,6 +136,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -99,35 +157,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/batch": {
-            "delete": {
-                "description": "Deletes multiple jobs by IDs or JobIDs",
-                "consumes": [
-                    "application/json"
+        "/admin/api-keys/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns an API key with its requests per day of the last 30 days",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jobs"
+                    "Admin"
                 ],
-                "summary": "Batch delete jobs",
+                "summary": "Inspect an API key",
                 "parameters": [
                     {
-                        "description": "Batch delete request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.BatchDeleteRequest"
-                        }
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.APIKeyResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -136,122 +206,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/jobs/search": {
-            "get": {
-                "description": "Searches jobs with filters like query, work mode, skills, salary, etc.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Jobs"
-                ],
-                "summary": "Search jobs",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Search keyword",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Currency code",
-                        "name": "currency",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated work modes",
-                        "name": "work_mode",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated work types",
-                        "name": "work_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated skills",
-                        "name": "skills",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated sources",
-                        "name": "source",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Minimum salary",
-                        "name": "min_salary",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Maximum salary",
-                        "name": "max_salary",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter active jobs",
-                        "name": "is_active",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
                     },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.APIResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/jobs/stats": {
-            "get": {
-                "description": "Returns statistics about jobs",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Jobs"
-                ],
-                "summary": "Get job statistics",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
@@ -263,22 +231,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/jobs/{id}": {
-            "get": {
-                "description": "Returns a job by its internal ID",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The key stops working right away. Revoked keys stay listed with their usage.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jobs"
+                    "Admin"
                 ],
-                "summary": "Get job by ID",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Job ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -297,27 +268,52 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a job by ID",
+            }
+        },
+        "/admin/api-keys/{id}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the secret of an API key, keeping its scopes, quota and usage. The old key stops working right away, the new one is only shown in this response.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jobs"
+                    "Admin"
                 ],
-                "summary": "Delete a job",
+                "summary": "Rotate an API key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Job ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -327,7 +323,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.APIKeyResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -336,6 +344,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -345,23 +377,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{id}/deactivate": {
-            "patch": {
-                "description": "Marks a job as inactive",
+        "/admin/companies/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves all jobs and aliases of the source company to the target, deletes the source and records the merge as performed by the current user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jobs"
+                    "Admin"
                 ],
-                "summary": "Deactivate a job",
+                "summary": "Merge two companies",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Job ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Merge request",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CompanyMergeRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -377,6 +419,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -385,14 +445,2568 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "constant.Currency": {
-            "type": "string",
-            "enum": [
-                "USD",
-                "EUR",
+        },
+        "/admin/companies/merges": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the audit log of company merges, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List company merges",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/companies/{slug}/aliases": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the other names ingestion matches to a company",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List company aliases",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Makes ingestion match jobs posted under another name to the company",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Add a company alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CompanyAliasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/synonyms": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the rules job search queries are expanded with, in file order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List search synonyms",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.SynonymRule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a rule to the synonyms file. Without expansions the terms are equivalent, e.g. {\"terms\": [\"frontend\", \"front-end\"]}. With expansions searching a term also finds its expansions but not the reverse, e.g. {\"terms\": [\"swe\"], \"expansions\": [\"software engineer\"]}.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Add a search synonym rule",
+                "parameters": [
+                    {
+                        "description": "Rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SynonymRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.SynonymRule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/synonyms/expand": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the groups of alternative phrases a job search for query matches. A job matches when it contains one phrase of every group.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Preview a query expansion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/synonyms/reload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reads the synonyms file again after it was edited by hand",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reload search synonyms",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.SynonymRule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/synonyms/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Replace a search synonym rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SynonymRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.SynonymRule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the rule, the IDs of the rules after it shift down by one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete a search synonym rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns paginated users, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email or name contains",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "reader",
+                            "employer",
+                            "moderator",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Only users with the role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.PaginatedUsersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Takes effect on the user's next request. The last admin can't be demoted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Change the role of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Starts a session and returns an access token for the Authorization header and a refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "Credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends the session of the access token. Its access and refresh tokens stop working.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Log out",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get the current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Sends a password reset token to the account with the email. Succeeds for unknown emails too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Sets a new password with a password reset token and ends every session of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Reset a password",
+                "parameters": [
+                    {
+                        "description": "Token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Trades a refresh token for a new access and refresh token. The old refresh token stops working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh the access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/signup": {
+            "post": {
+                "description": "Registers a user and logs them in. Passwords are 8 to 72 bytes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Create an account",
+                "parameters": [
+                    {
+                        "description": "Account",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Returns the job categories as a tree, e.g. Engineering \u003e Backend Engineering. Slugs can be used in the category search filter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get the category taxonomy",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies": {
+            "get": {
+                "description": "Returns paginated companies with their open job counts and sources, most open jobs first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "List companies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company name contains",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{slug}": {
+            "get": {
+                "description": "Returns the company profile with its open job count and sources",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Get a company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{slug}/jobs": {
+            "get": {
+                "description": "Returns the paginated jobs of a company, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Get a company's jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Company slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the description: html (default), markdown or text",
+                        "name": "description_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "description": "Returns paginated list of jobs, newest first. Follow next_cursor/prev_cursor with the cursor parameter for stable paging while jobs are added.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get all jobs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous page, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the description: html (default), markdown or text",
+                        "name": "description_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new job entry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Create a new job",
+                "parameters": [
+                    {
+                        "description": "Job object",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.JobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/batch": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes multiple jobs by IDs or JobIDs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Batch delete jobs",
+                "parameters": [
+                    {
+                        "description": "Batch delete request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BatchDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Streams every job matching the filters of GET /jobs/search as newline-delimited JSON, one job per line, newest first. Paging, sorting, facets, highlights and auto-correction don't apply. Takes the export scope with an API key.",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Export jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the description: html (default), markdown or text",
+                        "name": "description_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "One job per line",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/search": {
+            "get": {
+                "description": "Searches jobs with filters like query, work mode, skills, salary, etc. List filters take comma-separated or repeated values, enum filters take names or numbers. Invalid parameters are all reported in errors.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Search jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search keywords or the compact filter syntax, e.g. skills:go AND (remote:true OR country:DE) -company:acme",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated skill names, e.g. go,postgresql",
+                        "name": "skills",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated company slugs",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated category slugs or names, e.g. engineering or backend. Includes subcategories",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sources",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated cities, regions, countries or country ISO codes",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated work modes: remote, onsite, hybrid or 1-3",
+                        "name": "work_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated job types: full_time, part_time, contract, internship, temporary or 1-5",
+                        "name": "job_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated alias of job_type",
+                        "name": "work_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated experience levels: entry, mid, senior, lead, executive or 1-5",
+                        "name": "experience_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum salary",
+                        "name": "min_salary",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum salary",
+                        "name": "max_salary",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Three-letter currency code, e.g. USD",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated salary periods",
+                        "name": "salary_period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by remote flag",
+                        "name": "is_remote",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only jobs that do (true) or don't (false) sponsor visas",
+                        "name": "visa_sponsorship",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by health insurance",
+                        "name": "health_insurance",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by paid time off",
+                        "name": "paid_time_off",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by flexible schedule",
+                        "name": "flexible_schedule",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by equity offered",
+                        "name": "equity_offered",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only jobs that do (true) or don't (false) require a security clearance",
+                        "name": "security_clearance",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by urgent hiring",
+                        "name": "is_urgent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated company sizes",
+                        "name": "company_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated industries",
+                        "name": "industry",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated departments",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated education levels",
+                        "name": "education_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated travel requirements",
+                        "name": "travel_required",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Contract duration in months",
+                        "name": "contract_duration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posted on or after: 2024-01-31, an RFC 3339 time or an age such as 7d or 12h",
+                        "name": "posted_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posted on or before: 2024-01-31, an RFC 3339 time or an age such as 7d or 12h",
+                        "name": "posted_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the search centre",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the search centre",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius in km around lat/lng or near (default 50)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City to search around, e.g. Berlin or Berlin, DE",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone of the searcher, e.g. Europe/Berlin",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum shared working hours with the job's timezone or remote working hours (default 4)",
+                        "name": "tz_overlap_hours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only remote jobs open to people in this country, ISO code or name, e.g. DE",
+                        "name": "remote_eligible_in",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated ISO 639-1 codes of the posting language, e.g. de,en. Also picks the stemmer for query",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous page, replaces page. Not available with sort_by=distance",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at, posted_date, updated_at, salary_max, salary_min, company_name, title or distance",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc or desc (default)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated facets to count: work_mode, job_type, experience_level, source, country, skills, category, salary, posted_date",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the description: html (default), markdown or text",
+                        "name": "description_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add highlights to each job: title and summary with the words matching query marked, and excerpts of the description around them",
+                        "name": "highlight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inserted before every matching word (default \u003cmark\u003e). With an HTML tag the text around it is HTML-escaped",
+                        "name": "highlight_pre_tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inserted after every matching word (default \u003c/mark\u003e)",
+                        "name": "highlight_post_tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Approximate length of the description excerpts, 40-1000 (default 150)",
+                        "name": "highlight_fragment_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "When query finds nothing, return the results of its spelling correction instead, with auto_corrected set. Queries with few or no results get did_you_mean either way",
+                        "name": "auto_correct",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Searches jobs with a JSON filter tree of nested and/or/not groups. Conditions take a field, an operator (eq, in, gt, gte, lt, lte, between) and a value, e.g. {\"filter\": {\"and\": [{\"field\": \"skills\", \"value\": [\"go\", \"rust\"]}, {\"field\": \"salary\", \"op\": \"gte\", \"value\": 100000}, {\"not\": {\"field\": \"company\", \"value\": \"acme\"}}]}}. query takes free text or the compact syntax skills:go AND (remote:true OR country:DE) -company:acme. Query string filters of GET /jobs/search apply as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Search jobs with a filter document",
+                "parameters": [
+                    {
+                        "description": "Search request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.JobSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/stats": {
+            "get": {
+                "description": "Returns statistics about jobs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "description": "Returns a job by its internal ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the description: html (default), markdown or text",
+                        "name": "description_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a job by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Delete a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/deactivate": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks a job as inactive",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Deactivate a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/similar": {
+            "get": {
+                "description": "Returns open jobs related to a job, most similar first. Similarity combines the TF-IDF vectors of title and description with shared skills, categories, location and seniority. Reposts of the job by its company are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get similar jobs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum jobs, 1-50 (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the description: html (default), markdown or text",
+                        "name": "description_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the saved searches of the current user, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Searches"
+                ],
+                "summary": "List saved searches",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.SavedSearchResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saves the filters of a GET /jobs/search query string and alerts of the jobs added from now on, in digests sent instantly after each aggregation, daily or weekly. Paging, sorting, facets and highlights are dropped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Searches"
+                ],
+                "summary": "Save a job search",
+                "parameters": [
+                    {
+                        "description": "Search",
+                        "name": "search",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateSavedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.SavedSearchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Searches"
+                ],
+                "summary": "Delete a saved search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches/{id}/pause": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops the alerts of a saved search until it is resumed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Searches"
+                ],
+                "summary": "Pause a saved search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.SavedSearchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches/{id}/resume": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restarts the alerts of a paused saved search. Jobs added during the pause aren't alerted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Searches"
+                ],
+                "summary": "Resume a saved search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.SavedSearchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggest": {
+            "get": {
+                "description": "Completes a partial query with skills, companies, popular titles, locations and categories of open jobs, most jobs first. A suggestion matches when its label, or a later word of it, starts with q. slug is the value for the matching search filter (skills, company, query, location or category).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggest"
+                ],
+                "summary": "Typeahead suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partial query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated suggestion types: skill, company, title, location, category (default all)",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum suggestions, 1-50 (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.Suggestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "constant.Currency": {
+            "type": "string",
+            "enum": [
+                "USD",
+                "EUR",
                 "GBP",
                 "INR"
             ],
@@ -450,164 +3064,1043 @@ const docTemplate = `{
                 "WorkModeHybrid"
             ]
         },
-        "dtos.APIResponse": {
+        "dtos.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by_id": {
+                    "type": "integer"
+                },
+                "daily_quota": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "3f9a1c7e02b4"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Scope"
+                    }
+                },
+                "usage": {
+                    "description": "Requests per day of the last 30 days, when inspecting a key",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.APIKeyUsage"
+                    }
+                },
+                "usage_today": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string",
+                    "example": "Something went wrong"
+                },
+                "errors": {
+                    "description": "Per-parameter validation errors",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Operation successful"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dtos.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "Seconds the access token is valid",
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                }
+            }
+        },
+        "dtos.BatchDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dtos.CompanyAliasRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Splash Robotics"
+                }
+            }
+        },
+        "dtos.CompanyMergeRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Same employer, different legal suffix"
+                },
+                "source": {
+                    "description": "Slug of the company that goes away",
+                    "type": "string",
+                    "example": "splash-inc"
+                },
+                "target": {
+                    "description": "Slug of the company that stays",
+                    "type": "string",
+                    "example": "splash"
+                }
+            }
+        },
+        "dtos.CreateAPIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "daily_quota": {
+                    "description": "Requests per UTC day, 10000 when omitted",
+                    "type": "integer",
+                    "example": 10000
+                },
+                "expires_at": {
+                    "description": "Never when omitted",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acme job board"
+                },
+                "scopes": {
+                    "description": "jobs:read, jobs:write or export",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Scope"
+                    },
+                    "example": [
+                        "jobs:read",
+                        "export"
+                    ]
+                }
+            }
+        },
+        "dtos.CreateSavedSearchRequest": {
+            "type": "object",
+            "properties": {
+                "frequency": {
+                    "description": "instant, daily or weekly, daily when omitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Frequency"
+                        }
+                    ],
+                    "example": "daily"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Remote Go jobs"
+                },
+                "search": {
+                    "description": "Query string of GET /jobs/search, without paging, sorting, facets or highlights",
+                    "type": "string",
+                    "example": "query=golang\u0026is_remote=true"
+                }
+            }
+        },
+        "dtos.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "work_mode"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be remote, onsite or hybrid, or 1-3"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "moon"
+                }
+            }
+        },
+        "dtos.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "ada@example.com"
+                }
+            }
+        },
+        "dtos.HighlightOptions": {
+            "type": "object",
+            "properties": {
+                "fragment_size": {
+                    "description": "Approximate length of the description excerpts",
+                    "type": "integer"
+                },
+                "post_tag": {
+                    "description": "Inserted after every matching word, \"\u003c/mark\u003e\" by default",
+                    "type": "string"
+                },
+                "pre_tag": {
+                    "description": "Inserted before every matching word, \"\u003cmark\u003e\" by default",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.JobRequest": {
+            "type": "object",
+            "properties": {
+                "apply_url": {
+                    "type": "string",
+                    "example": "https://techcorp.com/careers/12345"
+                },
+                "categories": {
+                    "type": "string",
+                    "example": "Engineering, IT"
+                },
+                "company": {
+                    "type": "string",
+                    "example": "Tech Corp"
+                },
+                "company_logo_url": {
+                    "type": "string",
+                    "example": "https://techcorp.com/logo.png"
+                },
+                "company_size": {
+                    "type": "string",
+                    "example": "51-200"
+                },
+                "company_website": {
+                    "description": "Matches the job to a company by domain",
+                    "type": "string",
+                    "example": "https://techcorp.com"
+                },
+                "department": {
+                    "type": "string",
+                    "example": "Engineering"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Job description here"
+                },
+                "education_level": {
+                    "type": "string",
+                    "example": "Bachelor's"
+                },
+                "experience_level": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.ExperienceLevel"
+                        }
+                    ],
+                    "example": 1
+                },
+                "external_job_id": {
+                    "type": "string",
+                    "example": "ext-12345"
+                },
+                "industry": {
+                    "type": "string",
+                    "example": "Information Technology"
+                },
+                "is_remote": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "job_type": {
+                    "description": "\"full-time\", \"part-time\", \"contract\", \"remote\"",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.JobType"
+                        }
+                    ],
+                    "example": 1
+                },
+                "language": {
+                    "description": "ISO 639-1 code, detected from the text when omitted",
+                    "type": "string",
+                    "example": "en"
+                },
+                "location_text": {
+                    "description": "Locations as written by the source, parsed for remote restrictions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Remote - EMEA"
+                    ]
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.LocationRequest"
+                    }
+                },
+                "posted_at": {
+                    "type": "string",
+                    "example": "2023-10-01T12:00:00Z"
+                },
+                "salary_currency": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.Currency"
+                        }
+                    ],
+                    "example": "USD"
+                },
+                "salary_max": {
+                    "type": "integer",
+                    "example": 120000
+                },
+                "salary_min": {
+                    "type": "integer",
+                    "example": 60000
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Go",
+                        " Docker",
+                        " Kubernetes"
+                    ]
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "software-engineer-12345"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "LinkedIn"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "urgent",
+                        "remote"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Software Engineer"
+                },
+                "visa_sponsorship": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "work_mode": {
+                    "description": "\"onsite\", \"remote\", \"hybrid\"",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.WorkMode"
+                        }
+                    ],
+                    "example": 1
+                }
+            }
+        },
+        "dtos.JobSearchParams": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {
-                    "type": "string",
-                    "example": "Something went wrong"
+                "auto_correct": {
+                    "description": "Search for the spelling correction instead when Query finds nothing",
+                    "type": "boolean"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Operation successful"
+                "category": {
+                    "description": "Category slugs or names, descendants included",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "company": {
+                    "description": "Company slugs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "company_size": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "contract_duration": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "cursor": {
+                    "description": "Opaque next_cursor/prev_cursor of a previous page, replaces Offset",
+                    "type": "string"
+                },
+                "department": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "education_level": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "equity_offered": {
+                    "type": "boolean"
+                },
+                "experience_level": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constant.ExperienceLevel"
+                    }
+                },
+                "facets": {
+                    "description": "Facet counts to compute, see the Facet constants",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "filter": {
+                    "description": "Boolean filter tree, ANDed with the other filters",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.SearchFilter"
+                        }
+                    ]
+                },
+                "flexible_schedule": {
+                    "type": "boolean"
+                },
+                "health_insurance": {
+                    "type": "boolean"
+                },
+                "highlight": {
+                    "description": "Mark the words matching the text search, nil for no highlights",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.HighlightOptions"
+                        }
+                    ]
+                },
+                "industry": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_remote": {
+                    "type": "boolean"
+                },
+                "is_urgent": {
+                    "type": "boolean"
+                },
+                "job_type": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constant.JobType"
+                    }
+                },
+                "language": {
+                    "description": "ISO 639-1 codes, also used to stem Query",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "lng": {
+                    "type": "number"
+                },
+                "location": {
+                    "description": "For filtering by job locations",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_salary": {
+                    "type": "integer"
+                },
+                "min_salary": {
+                    "type": "integer"
+                },
+                "near": {
+                    "description": "City name, resolved to lat/lng via the gazetteer",
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "paid_time_off": {
+                    "type": "boolean"
+                },
+                "posted_after": {
+                    "type": "string"
+                },
+                "posted_before": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "radius_km": {
+                    "type": "number"
+                },
+                "remote_eligible_in": {
+                    "description": "Country ISO code or name the searcher works from",
+                    "type": "string"
+                },
+                "salary_period": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "security_clearance": {
+                    "description": "Whether a security clearance is required",
+                    "type": "boolean"
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sort_by": {
+                    "description": "\"created_at\", \"posted_date\", \"salary_max\", \"distance\", etc.",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "\"asc\", \"desc\"",
+                    "type": "string"
+                },
+                "source": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "travel_required": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tz": {
+                    "description": "IANA timezone of the searcher",
+                    "type": "string"
+                },
+                "tz_overlap_hours": {
+                    "description": "Minimum shared working hours with the job's timezone",
+                    "type": "integer"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "visa_sponsorship": {
+                    "type": "boolean"
+                },
+                "work_mode": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constant.WorkMode"
+                    }
                 }
             }
         },
-        "dtos.BatchDeleteRequest": {
+        "dtos.JobSearchRequest": {
             "type": "object",
             "properties": {
-                "ids": {
+                "auto_correct": {
+                    "type": "boolean"
+                },
+                "cursor": {
+                    "type": "string"
+                },
+                "description_format": {
+                    "type": "string"
+                },
+                "facets": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
+                },
+                "filter": {
+                    "$ref": "#/definitions/dtos.SearchFilter"
+                },
+                "highlight": {
+                    "type": "boolean"
+                },
+                "highlight_fragment_size": {
+                    "type": "integer"
+                },
+                "highlight_post_tag": {
+                    "type": "string"
+                },
+                "highlight_pre_tag": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "sort_by": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.JobRequest": {
+        "dtos.LocationRequest": {
             "type": "object",
             "properties": {
-                "apply_url": {
-                    "type": "string",
-                    "example": "https://techcorp.com/careers/12345"
-                },
-                "categories": {
-                    "type": "string",
-                    "example": "Engineering, IT"
-                },
                 "city": {
                     "type": "string",
                     "example": "San Francisco"
                 },
-                "company": {
-                    "type": "string",
-                    "example": "Tech Corp"
-                },
                 "country": {
+                    "description": "ISO code or country name",
                     "type": "string",
-                    "example": "USA"
+                    "example": "US"
                 },
-                "department": {
-                    "type": "string",
-                    "example": "Engineering"
+                "latitude": {
+                    "type": "number",
+                    "example": 37.77493
                 },
-                "description": {
+                "longitude": {
+                    "type": "number",
+                    "example": -122.41942
+                },
+                "region": {
                     "type": "string",
-                    "example": "Job description here"
+                    "example": "California"
                 },
-                "education_level": {
+                "timezone": {
                     "type": "string",
-                    "example": "Bachelor's"
+                    "example": "America/Los_Angeles"
                 },
-                "experience_level": {
+                "work_mode": {
+                    "description": "Omit to inherit the job's work mode",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/constant.ExperienceLevel"
+                            "$ref": "#/definitions/constant.WorkMode"
                         }
                     ],
-                    "example": 1
+                    "example": 3
+                }
+            }
+        },
+        "dtos.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "ada@example.com"
                 },
-                "external_job_id": {
+                "password": {
                     "type": "string",
-                    "example": "ext-12345"
+                    "example": "correct horse battery"
+                }
+            }
+        },
+        "dtos.PaginatedAPIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.APIKeyResponse"
+                    }
                 },
-                "industry": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PaginatedUsersResponse": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.User"
+                    }
+                }
+            }
+        },
+        "dtos.RefreshRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
                     "type": "string",
-                    "example": "Information Technology"
+                    "example": "correct horse battery staple"
                 },
-                "is_remote": {
-                    "type": "boolean",
-                    "example": false
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.SavedSearchResponse": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "description": "Jobs added since are alerted next",
+                    "type": "string"
                 },
-                "job_type": {
-                    "description": "\"full-time\", \"part-time\", \"contract\", \"remote\"",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/constant.JobType"
-                        }
-                    ],
-                    "example": 1
+                "created_at": {
+                    "type": "string"
                 },
-                "posted_at": {
+                "frequency": {
+                    "$ref": "#/definitions/model.Frequency"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_alert_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "params": {
+                    "$ref": "#/definitions/dtos.JobSearchParams"
+                },
+                "paused": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dtos.SearchFilter": {
+            "type": "object",
+            "properties": {
+                "and": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SearchFilter"
+                    }
+                },
+                "field": {
+                    "type": "string"
+                },
+                "not": {
+                    "$ref": "#/definitions/dtos.SearchFilter"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "or": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SearchFilter"
+                    }
+                },
+                "value": {
+                    "type": "object"
+                }
+            }
+        },
+        "dtos.SignupRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
                     "type": "string",
-                    "example": "2023-10-01T12:00:00Z"
+                    "example": "ada@example.com"
                 },
-                "salary_currency": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/constant.Currency"
-                        }
-                    ],
-                    "example": "USD"
+                "name": {
+                    "type": "string",
+                    "example": "Ada Lovelace"
                 },
-                "salary_max": {
+                "password": {
+                    "description": "8 to 72 bytes",
+                    "type": "string",
+                    "example": "correct horse battery"
+                }
+            }
+        },
+        "dtos.Suggestion": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Row ID; titles and cities have none",
                     "type": "integer",
-                    "example": 120000
+                    "example": 12
                 },
-                "salary_min": {
+                "job_count": {
+                    "description": "Open jobs with this value",
                     "type": "integer",
-                    "example": 60000
+                    "example": 312
                 },
-                "skills": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "Go",
-                        " Docker",
-                        " Kubernetes"
-                    ]
+                "label": {
+                    "description": "Text to display",
+                    "type": "string",
+                    "example": "Go"
+                },
+                "match": {
+                    "description": "\"prefix\" when the label starts with the query, \"word\" when a later word does",
+                    "type": "string",
+                    "example": "prefix"
                 },
                 "slug": {
+                    "description": "Value for the matching search filter",
                     "type": "string",
-                    "example": "software-engineer-12345"
+                    "example": "go"
                 },
-                "source": {
+                "type": {
+                    "description": "skill, company, title, location or category",
                     "type": "string",
-                    "example": "LinkedIn"
+                    "example": "skill"
+                }
+            }
+        },
+        "dtos.SynonymRule": {
+            "type": "object",
+            "properties": {
+                "bidirectional": {
+                    "description": "Every term matches all the others, set when there are no expansions",
+                    "type": "boolean"
                 },
-                "tags": {
+                "expansions": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "urgent",
-                        "remote"
+                        "software engineer"
                     ]
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Software Engineer"
+                "id": {
+                    "description": "Line of the rule in the synonyms file, ignoring comments",
+                    "type": "integer",
+                    "example": 1
                 },
-                "visa_sponsorship": {
-                    "type": "boolean",
-                    "example": false
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "swe",
+                        "sde"
+                    ]
+                }
+            }
+        },
+        "dtos.SynonymRuleRequest": {
+            "type": "object",
+            "properties": {
+                "expansions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "software engineer"
+                    ]
                 },
-                "work_mode": {
-                    "description": "\"onsite\", \"remote\", \"hybrid\"",
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "swe",
+                        "sde"
+                    ]
+                }
+            }
+        },
+        "dtos.UpdateUserRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "description": "reader, employer, moderator or admin",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/constant.WorkMode"
+                            "$ref": "#/definitions/model.Role"
                         }
                     ],
-                    "example": 1
+                    "example": "employer"
+                }
+            }
+        },
+        "model.APIKeyUsage": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "day": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Frequency": {
+            "type": "string",
+            "enum": [
+                "instant",
+                "daily",
+                "weekly"
+            ],
+            "x-enum-varnames": [
+                "FrequencyInstant",
+                "FrequencyDaily",
+                "FrequencyWeekly"
+            ]
+        },
+        "model.Role": {
+            "type": "string",
+            "enum": [
+                "reader",
+                "employer",
+                "moderator",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "RoleReader",
+                "RoleEmployer",
+                "RoleModerator",
+                "RoleAdmin"
+            ]
+        },
+        "model.Scope": {
+            "type": "string",
+            "enum": [
+                "jobs:read",
+                "jobs:write",
+                "export"
+            ],
+            "x-enum-varnames": [
+                "ScopeJobsRead",
+                "ScopeJobsWrite",
+                "ScopeExport"
+            ]
+        },
+        "model.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_login_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/model.Role"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         }
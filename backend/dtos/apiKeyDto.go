@@ -0,0 +1,49 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/user/model"
+)
+
+// CreateAPIKeyRequest issues an API key
+type CreateAPIKeyRequest struct {
+	Name       string        `json:"name" example:"Acme job board"`
+	Scopes     []model.Scope `json:"scopes" example:"jobs:read,export"` // jobs:read, jobs:write or export
+	DailyQuota int           `json:"daily_quota" example:"10000"`       // Requests per UTC day, 10000 when omitted
+	ExpiresAt  *time.Time    `json:"expires_at"`                        // Never when omitted
+}
+
+// APIKeyResponse describes an API key. Key is only set when the key is created or rotated,
+// it can't be retrieved later.
+type APIKeyResponse struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Prefix      string              `json:"prefix" example:"3f9a1c7e02b4"`
+	Key         string              `json:"key,omitempty"`
+	Scopes      []model.Scope       `json:"scopes"`
+	DailyQuota  int                 `json:"daily_quota"`
+	UsageToday  int64               `json:"usage_today"`
+	Usage       []model.APIKeyUsage `json:"usage,omitempty"` // Requests per day of the last 30 days, when inspecting a key
+	ExpiresAt   *time.Time          `json:"expires_at"`
+	RevokedAt   *time.Time          `json:"revoked_at"`
+	LastUsedAt  *time.Time          `json:"last_used_at"`
+	CreatedByID *uint               `json:"created_by_id"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// PaginatedAPIKeysResponse is a page of API keys
+type PaginatedAPIKeysResponse struct {
+	APIKeys     []APIKeyResponse `json:"api_keys"`
+	TotalCount  int64            `json:"total_count"`
+	CurrentPage int              `json:"current_page"`
+	PageSize    int              `json:"page_size"`
+	TotalPages  int              `json:"total_pages"`
+}
+
+// RateLimit is the daily quota of an API key as of a request
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time // Start of the next UTC day, when the quota refills
+}
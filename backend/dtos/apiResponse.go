@@ -1,10 +1,18 @@
 package dtos
 
 type APIResponse struct {
-	Success bool        `json:"success" example:"true"`
-	Message string      `json:"message,omitempty" example:"Operation successful"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty" example:"Something went wrong"`
+	Success bool         `json:"success" example:"true"`
+	Message string       `json:"message,omitempty" example:"Operation successful"`
+	Data    interface{}  `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty" example:"Something went wrong"`
+	Errors  []FieldError `json:"errors,omitempty"` // Per-parameter validation errors
+}
+
+// FieldError describes one invalid request parameter
+type FieldError struct {
+	Field   string `json:"field" example:"work_mode"`
+	Value   string `json:"value,omitempty" example:"moon"`
+	Message string `json:"message" example:"must be remote, onsite or hybrid, or 1-3"`
 }
 
 // BatchResult represents the result of batch operations
@@ -0,0 +1,40 @@
+package dtos
+
+import "github.com/bhati00/workova/backend/internal/job/model"
+
+// CompanyResponse is a company profile with its open job count and the sources that posted its jobs
+type CompanyResponse struct {
+	model.Company
+	OpenJobs int64    `json:"open_jobs"`
+	Sources  []string `json:"sources"`
+}
+
+// PaginatedCompaniesResponse represents paginated companies response
+type PaginatedCompaniesResponse struct {
+	Companies   []CompanyResponse `json:"companies"`
+	TotalCount  int64             `json:"total_count"`
+	CurrentPage int               `json:"current_page"`
+	PageSize    int               `json:"page_size"`
+	TotalPages  int               `json:"total_pages"`
+}
+
+// CompanyAliasRequest adds another name a company is known by
+type CompanyAliasRequest struct {
+	Name string `json:"name" example:"Splash Robotics"`
+}
+
+// CompanyMergeRequest merges the source company into the target company
+type CompanyMergeRequest struct {
+	Source string  `json:"source" example:"splash-inc"` // Slug of the company that goes away
+	Target string  `json:"target" example:"splash"`     // Slug of the company that stays
+	Reason *string `json:"reason,omitempty" example:"Same employer, different legal suffix"`
+}
+
+// PaginatedCompanyMergesResponse represents the paginated merge audit log
+type PaginatedCompanyMergesResponse struct {
+	Merges      []model.CompanyMerge `json:"merges"`
+	TotalCount  int64                `json:"total_count"`
+	CurrentPage int                  `json:"current_page"`
+	PageSize    int                  `json:"page_size"`
+	TotalPages  int                  `json:"total_pages"`
+}
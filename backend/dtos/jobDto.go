@@ -12,10 +12,14 @@ type JobRequest struct {
 	Slug            *string                   `json:"slug,omitempty" example:"software-engineer-12345"`
 	Title           string                    `json:"title" example:"Software Engineer"`
 	Description     *string                   `json:"description" example:"Job description here"`
+	Language        *string                   `json:"language,omitempty" example:"en"` // ISO 639-1 code, detected from the text when omitted
 	CompanyName     string                    `json:"company" example:"Tech Corp"`
-	CountryIso      string                    `json:"country" example:"USA"`
-	City            *string                   `json:"city,omitempty" example:"San Francisco"`
-	JobType         constant.JobType          `json:"job_type" example:"1"` // "full-time", "part-time", "contract", "remote"
+	CompanyWebsite  *string                   `json:"company_website,omitempty" example:"https://techcorp.com"` // Matches the job to a company by domain
+	CompanyLogoURL  *string                   `json:"company_logo_url,omitempty" example:"https://techcorp.com/logo.png"`
+	CompanySize     *string                   `json:"company_size,omitempty" example:"51-200"`
+	Locations       []LocationRequest         `json:"locations,omitempty"`
+	LocationText    []string                  `json:"location_text,omitempty" example:"Remote - EMEA"` // Locations as written by the source, parsed for remote restrictions
+	JobType         constant.JobType          `json:"job_type" example:"1"`                            // "full-time", "part-time", "contract", "remote"
 	SalaryMin       *int                      `json:"salary_min,omitempty" example:"60000"`
 	SalaryMax       *int                      `json:"salary_max,omitempty" example:"120000"`
 	SalaryCurrency  constant.Currency         `json:"salary_currency,omitempty" example:"USD"`
@@ -34,6 +38,17 @@ type JobRequest struct {
 	WorkMode        constant.WorkMode         `json:"work_mode,omitempty" example:"1"` // "onsite", "remote", "hybrid"
 }
 
+// LocationRequest is one place a job is offered in
+type LocationRequest struct {
+	CountryIso string            `json:"country" example:"US"` // ISO code or country name
+	City       *string           `json:"city,omitempty" example:"San Francisco"`
+	Region     *string           `json:"region,omitempty" example:"California"`
+	Latitude   *float64          `json:"latitude,omitempty" example:"37.77493"`
+	Longitude  *float64          `json:"longitude,omitempty" example:"-122.41942"`
+	Timezone   *string           `json:"timezone,omitempty" example:"America/Los_Angeles"`
+	WorkMode   constant.WorkMode `json:"work_mode,omitempty" example:"3"` // Omit to inherit the job's work mode
+}
+
 type JobResponse struct {
 	ID            uint      `json:"id"`
 	ExternalJobID string    `json:"external_job_id"`
@@ -46,11 +61,38 @@ type JobResponse struct {
 
 // PaginatedJobsResponse represents paginated jobs response
 type PaginatedJobsResponse struct {
-	Jobs        []model.Job `json:"jobs"`
-	TotalCount  int64       `json:"total_count"`
-	CurrentPage int         `json:"current_page"`
-	PageSize    int         `json:"page_size"`
-	TotalPages  int         `json:"total_pages"`
+	Jobs          []model.Job             `json:"jobs"`
+	TotalCount    int64                   `json:"total_count"`
+	CurrentPage   int                     `json:"current_page"`
+	PageSize      int                     `json:"page_size"`
+	TotalPages    int                     `json:"total_pages"`
+	Facets        map[string][]FacetValue `json:"facets,omitempty"`         // Requested facets by name
+	NextCursor    string                  `json:"next_cursor,omitempty"`    // Cursor of the following page, empty on the last page
+	PrevCursor    string                  `json:"prev_cursor,omitempty"`    // Cursor of the preceding page, empty on the first page
+	DidYouMean    string                  `json:"did_you_mean,omitempty"`   // Spelling correction of the free text of a search with few or no results
+	AutoCorrected bool                    `json:"auto_corrected,omitempty"` // The results are for DidYouMean, the query found nothing
+}
+
+// Facet names accepted by facets=
+const (
+	FacetWorkMode        = "work_mode"
+	FacetJobType         = "job_type"
+	FacetExperienceLevel = "experience_level"
+	FacetSource          = "source"
+	FacetCountry         = "country"
+	FacetSkills          = "skills"
+	FacetCategory        = "category"
+	FacetSalary          = "salary"
+	FacetPostedDate      = "posted_date"
+)
+
+// FacetValue is one filter option and the number of matching jobs. Counts ignore the
+// facet's own filter so other options of the same facet stay visible. Salary values are
+// "min-max" ranges for min_salary/max_salary, and count exactly the jobs those return.
+type FacetValue struct {
+	Value string `json:"value" example:"remote"` // Value to send back in the facet's filter
+	Label string `json:"label" example:"Remote"`
+	Count int64  `json:"count" example:"312"`
 }
 
 // JobStatsResponse represents job statistics
@@ -65,30 +107,131 @@ type JobStatsResponse struct {
 }
 
 type JobSearchParams struct {
-	Query            string                     `json:"query"`
-	Skills           []string                   `json:"skills"`
-	WorkMode         []constant.WorkMode        `json:"work_mode"`
-	JobType          []constant.JobType         `json:"job_type"`
-	ExperienceLevel  []constant.ExperienceLevel `json:"experience_level"`
-	MinSalary        *int                       `json:"min_salary"`
-	MaxSalary        *int                       `json:"max_salary"`
-	Currency         string                     `json:"currency"`
-	IsRemote         *bool                      `json:"is_remote"`
-	VisaSponsorship  *bool                      `json:"visa_sponsorship"`
-	IsUrgent         *bool                      `json:"is_urgent"`
-	CompanySize      []string                   `json:"company_size"`
-	Industry         []string                   `json:"industry"`
-	Department       []string                   `json:"department"`
-	EducationLevel   []string                   `json:"education_level"`
-	TravelRequired   []string                   `json:"travel_required"`
-	Location         []string                   `json:"location"` // For filtering by job locations
-	Source           []string                   `json:"source"`
-	PostedAfter      *time.Time                 `json:"posted_after"`
-	PostedBefore     *time.Time                 `json:"posted_before"`
-	SalaryPeriod     []string                   `json:"salary_period"`
-	ContractDuration *int                       `json:"contract_duration"`
-	Offset           int                        `json:"offset"`
-	Limit            int                        `json:"limit"`
-	SortBy           string                     `json:"sort_by"`    // "created_at", "posted_date", "salary_max", etc.
-	SortOrder        string                     `json:"sort_order"` // "asc", "desc"
+	Query                string                     `json:"query"`
+	Language             []string                   `json:"language"` // ISO 639-1 codes, also used to stem Query
+	Skills               []string                   `json:"skills"`
+	Company              []string                   `json:"company"`  // Company slugs
+	Category             []string                   `json:"category"` // Category slugs or names, descendants included
+	WorkMode             []constant.WorkMode        `json:"work_mode"`
+	JobType              []constant.JobType         `json:"job_type"`
+	ExperienceLevel      []constant.ExperienceLevel `json:"experience_level"`
+	MinSalary            *int                       `json:"min_salary"`
+	MaxSalary            *int                       `json:"max_salary"`
+	Currency             string                     `json:"currency"`
+	IsRemote             *bool                      `json:"is_remote"`
+	VisaSponsorship      *bool                      `json:"visa_sponsorship"`
+	HealthInsurance      *bool                      `json:"health_insurance"`
+	PaidTimeOff          *bool                      `json:"paid_time_off"`
+	FlexibleSchedule     *bool                      `json:"flexible_schedule"`
+	EquityOffered        *bool                      `json:"equity_offered"`
+	Clearance            *bool                      `json:"security_clearance"` // Whether a security clearance is required
+	IsUrgent             *bool                      `json:"is_urgent"`
+	CompanySize          []string                   `json:"company_size"`
+	Industry             []string                   `json:"industry"`
+	Department           []string                   `json:"department"`
+	EducationLevel       []string                   `json:"education_level"`
+	TravelRequired       []string                   `json:"travel_required"`
+	Location             []string                   `json:"location"` // For filtering by job locations
+	Latitude             *float64                   `json:"lat"`
+	Longitude            *float64                   `json:"lng"`
+	RadiusKm             *float64                   `json:"radius_km"`
+	Near                 string                     `json:"near"`               // City name, resolved to lat/lng via the gazetteer
+	Timezone             string                     `json:"tz"`                 // IANA timezone of the searcher
+	TimezoneOverlap      *int                       `json:"tz_overlap_hours"`   // Minimum shared working hours with the job's timezone
+	Timezones            []string                   `json:"-"`                  // Job timezones matching Timezone/TimezoneOverlap, resolved by the service
+	TimezoneOffset       *float64                   `json:"-"`                  // UTC offset of Timezone, resolved by the service
+	RemoteEligibleIn     string                     `json:"remote_eligible_in"` // Country ISO code or name the searcher works from
+	RemoteEligibleOffset *float64                   `json:"-"`                  // UTC offset of RemoteEligibleIn, resolved by the service
+	Source               []string                   `json:"source"`
+	PostedAfter          *time.Time                 `json:"posted_after"`
+	PostedBefore         *time.Time                 `json:"posted_before"`
+	CreatedAfter         *time.Time                 `json:"-"` // Jobs added after this instant only, set by saved search alerts
+	CreatedBefore        *time.Time                 `json:"-"` // Jobs added up to this instant only
+	SalaryPeriod         []string                   `json:"salary_period"`
+	ContractDuration     *int                       `json:"contract_duration"`
+	Offset               int                        `json:"offset"`
+	Limit                int                        `json:"limit"`
+	Cursor               string                     `json:"cursor"`       // Opaque next_cursor/prev_cursor of a previous page, replaces Offset
+	Keyset               *JobKeyset                 `json:"-"`            // Position decoded from Cursor by the service
+	SortBy               string                     `json:"sort_by"`      // "created_at", "posted_date", "salary_max", "distance", etc.
+	SortOrder            string                     `json:"sort_order"`   // "asc", "desc"
+	Facets               []string                   `json:"facets"`       // Facet counts to compute, see the Facet constants
+	Filter               *SearchFilter              `json:"filter"`       // Boolean filter tree, ANDed with the other filters
+	Highlight            *HighlightOptions          `json:"highlight"`    // Mark the words matching the text search, nil for no highlights
+	AutoCorrect          bool                       `json:"auto_correct"` // Search for the spelling correction instead when Query finds nothing
+}
+
+// HighlightOptions controls the highlights of a text search
+type HighlightOptions struct {
+	PreTag       string `json:"pre_tag"`       // Inserted before every matching word, "<mark>" by default
+	PostTag      string `json:"post_tag"`      // Inserted after every matching word, "</mark>" by default
+	FragmentSize int    `json:"fragment_size"` // Approximate length of the description excerpts
+}
+
+// JobKeyset is a position in a sorted job listing: the sort value and ID of the job a
+// page starts after, or before when paging backwards
+type JobKeyset struct {
+	Value    interface{} // nil when the job has no value for a nullable sort column
+	ID       uint
+	Backward bool
+}
+
+// Operators of a SearchFilter condition
+const (
+	FilterOpEq      = "eq" // Default; a list value means any of
+	FilterOpIn      = "in"
+	FilterOpGt      = "gt"
+	FilterOpGte     = "gte"
+	FilterOpLt      = "lt"
+	FilterOpLte     = "lte"
+	FilterOpBetween = "between" // Value is [low, high], both inclusive
+)
+
+// SearchFilter is one node of a boolean search filter: either a group (And, Or or Not)
+// or a condition on Field, e.g.
+//
+//	{"and": [{"field": "skills", "value": "go"}, {"not": {"field": "company", "value": "acme"}}]}
+type SearchFilter struct {
+	And   []SearchFilter `json:"and,omitempty"`
+	Or    []SearchFilter `json:"or,omitempty"`
+	Not   *SearchFilter  `json:"not,omitempty"`
+	Field string         `json:"field,omitempty"`
+	Op    string         `json:"op,omitempty"`
+	Value interface{}    `json:"value,omitempty" swaggertype:"object"`
+}
+
+// JobSearchRequest is the body of POST /jobs/search. Query takes free text or the
+// compact filter syntax, e.g. skills:go AND (remote:true OR country:DE) -company:acme
+type JobSearchRequest struct {
+	Query             string        `json:"query"`
+	Filter            *SearchFilter `json:"filter"`
+	Page              int           `json:"page"`
+	PageSize          int           `json:"page_size"`
+	SortBy            string        `json:"sort_by"`
+	SortOrder         string        `json:"sort_order"`
+	Facets            []string      `json:"facets"`
+	Cursor            string        `json:"cursor"`
+	DescriptionFormat string        `json:"description_format"`
+	Highlight         *bool         `json:"highlight"`
+	HighlightPreTag   string        `json:"highlight_pre_tag"`
+	HighlightPostTag  string        `json:"highlight_post_tag"`
+	FragmentSize      int           `json:"highlight_fragment_size"`
+	AutoCorrect       *bool         `json:"auto_correct"`
+}
+
+// Suggestion is a typeahead completion, see GET /suggest
+type Suggestion struct {
+	Type     string `json:"type" example:"skill"`      // skill, company, title, location or category
+	Label    string `json:"label" example:"Go"`        // Text to display
+	ID       *uint  `json:"id,omitempty" example:"12"` // Row ID; titles and cities have none
+	Slug     string `json:"slug" example:"go"`         // Value for the matching search filter
+	JobCount int64  `json:"job_count" example:"312"`   // Open jobs with this value
+	Match    string `json:"match" example:"prefix"`    // "prefix" when the label starts with the query, "word" when a later word does
+}
+
+// SimilarJob is a job related to another one, see GET /jobs/{id}/similar
+type SimilarJob struct {
+	model.Job
+	Similarity   float64  `json:"similarity" example:"0.62"`                  // 0 to 1, how related the job is
+	SharedSkills []string `json:"shared_skills,omitempty" example:"Go,Kafka"` // Skills both jobs list
 }
@@ -0,0 +1,26 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/alert/model"
+)
+
+// CreateSavedSearchRequest saves a job search to be alerted of its new matches
+type CreateSavedSearchRequest struct {
+	Name      string          `json:"name" example:"Remote Go jobs"`
+	Search    string          `json:"search" example:"query=golang&is_remote=true"` // Query string of GET /jobs/search, without paging, sorting, facets or highlights
+	Frequency model.Frequency `json:"frequency" example:"daily"`                    // instant, daily or weekly, daily when omitted
+}
+
+// SavedSearchResponse describes a saved search
+type SavedSearchResponse struct {
+	ID          uint             `json:"id"`
+	Name        string           `json:"name"`
+	Params      *JobSearchParams `json:"params"`
+	Frequency   model.Frequency  `json:"frequency"`
+	Paused      bool             `json:"paused"`
+	CheckedAt   time.Time        `json:"checked_at"` // Jobs added since are alerted next
+	LastAlertAt *time.Time       `json:"last_alert_at"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
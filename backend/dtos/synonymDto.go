@@ -0,0 +1,16 @@
+package dtos
+
+// SynonymRule is a rule of the search synonym dictionary
+type SynonymRule struct {
+	ID            int      `json:"id" example:"1"` // Line of the rule in the synonyms file, ignoring comments
+	Terms         []string `json:"terms" example:"swe,sde"`
+	Expansions    []string `json:"expansions,omitempty" example:"software engineer"`
+	Bidirectional bool     `json:"bidirectional"` // Every term matches all the others, set when there are no expansions
+}
+
+// SynonymRuleRequest creates or replaces a synonym rule. Without expansions the terms are
+// equivalent, with expansions searching a term also finds its expansions but not the reverse.
+type SynonymRuleRequest struct {
+	Terms      []string `json:"terms" example:"swe,sde"`
+	Expansions []string `json:"expansions,omitempty" example:"software engineer"`
+}
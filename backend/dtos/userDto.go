@@ -0,0 +1,61 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/user/model"
+)
+
+// SignupRequest creates an account
+type SignupRequest struct {
+	Email    string `json:"email" example:"ada@example.com"`
+	Password string `json:"password" example:"correct horse battery"` // 8 to 72 bytes
+	Name     string `json:"name" example:"Ada Lovelace"`
+}
+
+// LoginRequest starts a session
+type LoginRequest struct {
+	Email    string `json:"email" example:"ada@example.com"`
+	Password string `json:"password" example:"correct horse battery"`
+}
+
+// RefreshRequest trades a refresh token for a new token pair
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ForgotPasswordRequest asks for a password reset token
+type ForgotPasswordRequest struct {
+	Email string `json:"email" example:"ada@example.com"`
+}
+
+// ResetPasswordRequest sets a new password with a password reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password" example:"correct horse battery staple"`
+}
+
+// AuthResponse is returned by signup, login and refresh. The access token goes in the
+// Authorization header as "Bearer <access_token>", the refresh token to POST /auth/refresh.
+type AuthResponse struct {
+	User             *model.User `json:"user"`
+	AccessToken      string      `json:"access_token"`
+	TokenType        string      `json:"token_type" example:"Bearer"`
+	ExpiresIn        int         `json:"expires_in" example:"900"` // Seconds the access token is valid
+	RefreshToken     string      `json:"refresh_token"`
+	RefreshExpiresAt time.Time   `json:"refresh_expires_at"`
+}
+
+// UpdateUserRoleRequest changes the role of a user
+type UpdateUserRoleRequest struct {
+	Role model.Role `json:"role" example:"employer"` // reader, employer, moderator or admin
+}
+
+// PaginatedUsersResponse is a page of users for admins
+type PaginatedUsersResponse struct {
+	Users       []model.User `json:"users"`
+	TotalCount  int64        `json:"total_count"`
+	CurrentPage int          `json:"current_page"`
+	PageSize    int          `json:"page_size"`
+	TotalPages  int          `json:"total_pages"`
+}
@@ -0,0 +1,89 @@
+package alert
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bhati00/workova/backend/config"
+	"github.com/bhati00/workova/backend/internal/alert/repository"
+	"github.com/bhati00/workova/backend/internal/job"
+	"github.com/bhati00/workova/backend/pkg/notify"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"gorm.io/gorm"
+)
+
+// AlertModule represents the complete saved search module with all dependencies
+type AlertModule struct {
+	Service SavedSearchService
+	Handler *SavedSearchHandler
+}
+
+// InitializeAlertModule initializes the saved search module, whose searches run through
+// jobService
+func InitializeAlertModule(db *gorm.DB, config *config.Config, jobService job.JobService) *AlertModule {
+	// Run migrations
+	Migrate(config.DBpath)
+
+	savedSearchService := NewSavedSearchService(repository.NewSavedSearchRepository(db), jobService)
+	return &AlertModule{
+		Service: savedSearchService,
+		Handler: NewSavedSearchHandler(savedSearchService),
+	}
+}
+
+// NewNotifier returns the SMTP notifier of config, or the file or log sink without one
+func NewNotifier(config *config.Config) (notify.Notifier, error) {
+	switch {
+	case config.SMTPAddr != "":
+		notifier, err := notify.NewSMTPNotifier(notify.SMTPConfig{
+			Addr:     config.SMTPAddr,
+			From:     config.SMTPFrom,
+			Username: config.SMTPUsername,
+			Password: config.SMTPPassword,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure smtp: %w", err)
+		}
+		return notifier, nil
+	case config.AlertsPath != "":
+		// The file stays open for the life of the process
+		notifier, _, err := notify.NewFileNotifier(config.AlertsPath)
+		return notifier, err
+	default:
+		return notify.NewWriterNotifier(log.Writer()), nil
+	}
+}
+
+// RegisterRoutes registers all saved search routes to the router
+func (am *AlertModule) RegisterRoutes(router *gin.Engine) {
+	v1 := router.Group("/api/")
+
+	am.Handler.RegisterSavedSearchRoutes(v1)
+}
+
+// Migrate applies the alert migrations, tracked in their own table like the user ones.
+// The user migrations must have run first.
+func Migrate(dbPath string) {
+	dsn := "sqlite://" + dbPath + "?x-migrations-table=alert_schema_migrations"
+
+	m, err := migrate.New(
+		"file://internal/alert/migrations",
+		dsn,
+	)
+	if err != nil {
+		log.Fatalf("Could not initialize alert migration: %v", err)
+	}
+
+	if err := m.Up(); err != nil {
+		if err == migrate.ErrNoChange {
+			log.Println("No new alert migrations to apply")
+		} else {
+			log.Fatalf("Alert migration failed: %v", err)
+		}
+	} else {
+		log.Println("Alert migrations applied successfully")
+	}
+}
@@ -0,0 +1,133 @@
+package alert
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/alert/model"
+	"github.com/bhati00/workova/backend/internal/alert/repository"
+	"github.com/bhati00/workova/backend/internal/job"
+	"github.com/bhati00/workova/backend/pkg/notify"
+)
+
+const (
+	// digestSize is how many of the new matches a digest lists, newest first
+	digestSize = 20
+	// dueSlack lets daily and weekly searches be checked a bit early, so a run that
+	// starts a few minutes sooner than the last one doesn't skip them for a whole period
+	dueSlack = time.Hour
+)
+
+// Matcher alerts users of the jobs added since their saved searches were last checked.
+// It is meant to run after each aggregation.
+type Matcher struct {
+	searchRepo repository.SavedSearchRepository
+	jobService job.JobService
+	notifier   notify.Notifier
+	now        func() time.Time
+}
+
+// NewMatcher creates a matcher sending digests through notifier
+func NewMatcher(searchRepo repository.SavedSearchRepository, jobService job.JobService, notifier notify.Notifier) *Matcher {
+	return &Matcher{searchRepo: searchRepo, jobService: jobService, notifier: notifier, now: time.Now}
+}
+
+// Run checks the saved searches that are due and sends a digest for each that has new
+// matches, returning how many went out. A search that fails is logged and left unchecked,
+// so its matches are alerted on a later run.
+func (m *Matcher) Run() (int, error) {
+	searches, err := m.searchRepo.ListActive()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+
+	sent := 0
+	for i := range searches {
+		search := &searches[i]
+		if !search.Due(m.now(), dueSlack) {
+			continue
+		}
+		alerted, err := m.check(search)
+		if err != nil {
+			log.Printf("Failed to check saved search (ID: %d): %v", search.ID, err)
+			continue
+		}
+		if alerted {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// check matches the jobs added since the search was last checked and sends their digest
+func (m *Matcher) check(search *model.SavedSearch) (bool, error) {
+	if search.User == nil {
+		return false, fmt.Errorf("user %d not found", search.UserID)
+	}
+	params, err := decodeParams(search)
+	if err != nil {
+		return false, err
+	}
+	now := m.now()
+	params.CreatedAfter, params.CreatedBefore = &search.CheckedAt, &now
+	params.SortBy, params.SortOrder, params.Limit = "created_at", "desc", digestSize
+
+	result, err := m.jobService.SearchJobs(params)
+	if err != nil {
+		return false, fmt.Errorf("search failed: %w", err)
+	}
+
+	var alertedAt *time.Time
+	if len(result.Jobs) > 0 {
+		if err := m.notifier.Notify(digest(search, result)); err != nil {
+			return false, fmt.Errorf("failed to send digest: %w", err)
+		}
+		alertedAt = &now
+	}
+	if err := m.searchRepo.MarkChecked(search.ID, now, alertedAt); err != nil {
+		return false, fmt.Errorf("failed to mark saved search checked: %w", err)
+	}
+	return alertedAt != nil, nil
+}
+
+// digest builds the message listing the new matches of a search
+func digest(search *model.SavedSearch, result *dtos.PaginatedJobsResponse) notify.Message {
+	var b strings.Builder
+	if search.User.Name != "" {
+		fmt.Fprintf(&b, "Hi %s,\n\n", search.User.Name)
+	} else {
+		b.WriteString("Hi,\n\n")
+	}
+	fmt.Fprintf(&b, "%s for your saved search %q:\n\n", newJobs(result.TotalCount), search.Name)
+	for _, match := range result.Jobs {
+		fmt.Fprintf(&b, "- %s at %s\n", match.Title, match.CompanyName)
+	}
+	if more := result.TotalCount - int64(len(result.Jobs)); more > 0 {
+		fmt.Fprintf(&b, "\nand %d more.\n", more)
+	}
+	fmt.Fprintf(&b, "\nYou get this digest %s. Pause or delete the search in your saved searches.\n", frequencyPhrase(search.Frequency))
+
+	return notify.Message{
+		To:      search.User.Email,
+		Subject: fmt.Sprintf("%s for %q", newJobs(result.TotalCount), search.Name),
+		Body:    b.String(),
+	}
+}
+
+// newJobs phrases a count of new jobs, e.g. "1 new job" or "3 new jobs"
+func newJobs(count int64) string {
+	if count == 1 {
+		return "1 new job"
+	}
+	return fmt.Sprintf("%d new jobs", count)
+}
+
+func frequencyPhrase(frequency model.Frequency) string {
+	if frequency == model.FrequencyInstant {
+		return "whenever new jobs come in"
+	}
+	return string(frequency)
+}
@@ -0,0 +1,204 @@
+package alert
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/constant"
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/alert/mocks"
+	"github.com/bhati00/workova/backend/internal/alert/model"
+	alertrepository "github.com/bhati00/workova/backend/internal/alert/repository"
+	"github.com/bhati00/workova/backend/internal/job"
+	jobmocks "github.com/bhati00/workova/backend/internal/job/mocks"
+	jobmodel "github.com/bhati00/workova/backend/internal/job/model"
+	jobrepository "github.com/bhati00/workova/backend/internal/job/repository"
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/bhati00/workova/backend/pkg/notify"
+	"github.com/bhati00/workova/backend/pkg/synonym"
+	"github.com/bhati00/workova/backend/pkg/utils"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingNotifier keeps the messages it is given, failing for the recipients in fail
+type recordingNotifier struct {
+	messages []notify.Message
+	fail     map[string]bool
+}
+
+func (n *recordingNotifier) Notify(message notify.Message) error {
+	if n.fail[message.To] {
+		return errors.New("mailbox unavailable")
+	}
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func newTestMatcher(searchRepo *mocks.MockSavedSearchRepository, jobService *jobmocks.MockJobService, notifier notify.Notifier) *Matcher {
+	m := NewMatcher(searchRepo, jobService, notifier)
+	m.now = func() time.Time { return testNow }
+	return m
+}
+
+func TestSavedSearch_Due(t *testing.T) {
+	tests := []struct {
+		frequency model.Frequency
+		checked   time.Duration // Before testNow
+		paused    bool
+		due       bool
+	}{
+		{model.FrequencyInstant, time.Minute, false, true},
+		{model.FrequencyInstant, time.Minute, true, false},
+		{model.FrequencyDaily, 23*time.Hour + 50*time.Minute, false, true},
+		{model.FrequencyDaily, 12 * time.Hour, false, false},
+		{model.FrequencyWeekly, 6 * 24 * time.Hour, false, false},
+		{model.FrequencyWeekly, 7 * 24 * time.Hour, false, true},
+	}
+
+	for _, tt := range tests {
+		search := model.SavedSearch{Frequency: tt.frequency, CheckedAt: testNow.Add(-tt.checked), Paused: tt.paused}
+		assert.Equal(t, tt.due, search.Due(testNow, dueSlack), "%s checked %s ago, paused %t", tt.frequency, tt.checked, tt.paused)
+	}
+}
+
+func TestMatcher_Run(t *testing.T) {
+	ada := &usermodel.User{ID: 7, Email: "ada@example.com", Name: "Ada"}
+	bob := &usermodel.User{ID: 8, Email: "bob@example.com"}
+	withUser := func(search *model.SavedSearch, user *usermodel.User, frequency model.Frequency, checked time.Duration) model.SavedSearch {
+		search.UserID, search.User, search.Frequency, search.CheckedAt = user.ID, user, frequency, testNow.Add(-checked)
+		return *search
+	}
+	searches := []model.SavedSearch{
+		withUser(savedSearch(t, 1, dtos.JobSearchParams{Query: "golang"}), ada, model.FrequencyInstant, time.Hour),
+		withUser(savedSearch(t, 2, dtos.JobSearchParams{Query: "rust"}), ada, model.FrequencyDaily, 2*time.Hour), // Not due
+		withUser(savedSearch(t, 3, dtos.JobSearchParams{Query: "python"}), bob, model.FrequencyWeekly, 8*24*time.Hour),
+		withUser(savedSearch(t, 4, dtos.JobSearchParams{Query: "java"}), bob, model.FrequencyDaily, 25*time.Hour),
+		withUser(savedSearch(t, 5, dtos.JobSearchParams{Query: "scala"}), &usermodel.User{ID: 9, Email: "eve@example.com"}, model.FrequencyInstant, time.Hour),
+	}
+	searches[0].Name = "Go"
+
+	mockSearchRepo := &mocks.MockSavedSearchRepository{}
+	mockSearchRepo.On("ListActive").Return(searches, nil)
+	mockJobService := &jobmocks.MockJobService{}
+	matches := func(query string, since time.Duration) interface{} {
+		return mock.MatchedBy(func(params *dtos.JobSearchParams) bool {
+			return params.Query == query && params.CreatedAfter.Equal(testNow.Add(-since)) && params.CreatedBefore.Equal(testNow) &&
+				params.SortBy == "created_at" && params.SortOrder == "desc" && params.Limit == digestSize
+		})
+	}
+	mockJobService.On("SearchJobs", matches("golang", time.Hour)).Return(&dtos.PaginatedJobsResponse{
+		Jobs:       []jobmodel.Job{{ID: 3, Title: "Go Developer", CompanyName: "Acme"}, {ID: 2, Title: "Go Engineer", CompanyName: "Globex"}},
+		TotalCount: 23,
+	}, nil)
+	mockJobService.On("SearchJobs", matches("python", 8*24*time.Hour)).Return(&dtos.PaginatedJobsResponse{}, nil)
+	mockJobService.On("SearchJobs", matches("java", 25*time.Hour)).Return(nil, errors.New("database error"))
+	mockJobService.On("SearchJobs", matches("scala", time.Hour)).Return(&dtos.PaginatedJobsResponse{
+		Jobs:       []jobmodel.Job{{ID: 4, Title: "Scala Developer", CompanyName: "Initech"}},
+		TotalCount: 1,
+	}, nil)
+	// Alerted, checked without matches, and nothing for the failed search and digest
+	now := testNow
+	mockSearchRepo.On("MarkChecked", uint(1), testNow, &now).Return(nil)
+	mockSearchRepo.On("MarkChecked", uint(3), testNow, (*time.Time)(nil)).Return(nil)
+	notifier := &recordingNotifier{fail: map[string]bool{"eve@example.com": true}}
+
+	sent, err := newTestMatcher(mockSearchRepo, mockJobService, notifier).Run()
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, notifier.messages, 1)
+	assert.Equal(t, notify.Message{
+		To:      "ada@example.com",
+		Subject: `23 new jobs for "Go"`,
+		Body: "Hi Ada,\n\n23 new jobs for your saved search \"Go\":\n\n" +
+			"- Go Developer at Acme\n- Go Engineer at Globex\n\nand 21 more.\n\n" +
+			"You get this digest whenever new jobs come in. Pause or delete the search in your saved searches.\n",
+	}, notifier.messages[0])
+	mockSearchRepo.AssertExpectations(t)
+	mockJobService.AssertExpectations(t)
+}
+
+// newTestDB returns a database with the job, user and saved search migrations applied
+func newTestDB(t *testing.T) *gorm.DB {
+	path := filepath.Join(t.TempDir(), "workova.db")
+	for _, migrations := range []struct{ source, table string }{
+		{"../job/migrations", "schema_migrations"},
+		{"../user/migrations", "user_schema_migrations"},
+		{"migrations", "alert_schema_migrations"},
+	} {
+		m, err := migrate.New("file://"+migrations.source, "sqlite://"+path+"?x-migrations-table="+migrations.table)
+		require.NoError(t, err)
+		require.NoError(t, m.Up())
+		m.Close()
+	}
+
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	return db
+}
+
+func TestMatcher_RunExpandsSynonyms(t *testing.T) {
+	previous := synonym.Default()
+	t.Cleanup(func() { synonym.SetDefault(previous) })
+	synonym.SetDefault(synonym.NewDictionary(nil))
+
+	db := newTestDB(t)
+	jobService := job.NewJobService(jobrepository.NewJobRepository(db), jobrepository.NewSkillRepository(db),
+		jobrepository.NewCategoryRepository(db), jobrepository.NewLocationRepository(db), jobrepository.NewCompanyRepository(db))
+	ada := &usermodel.User{Email: "ada@example.com", Name: "Ada", PasswordHash: "x", Role: usermodel.RoleReader}
+	require.NoError(t, db.Create(ada).Error)
+	search := &model.SavedSearch{UserID: ada.ID, Name: "K8s", Params: `{"query":"k8s"}`, Frequency: model.FrequencyInstant,
+		CheckedAt: time.Now().Add(-time.Hour)}
+	require.NoError(t, db.Create(search).Error)
+	_, err := jobService.CreateJob(dtos.JobRequest{ExternalJobID: utils.String("k8s-1"), Title: "Platform Engineer",
+		Description: utils.String("Run Kubernetes clusters"), CompanyName: "Acme", Source: "test",
+		JobType: constant.JobTypeFullTime, WorkMode: constant.WorkModeRemote, Locations: []dtos.LocationRequest{{CountryIso: "US"}}})
+	require.NoError(t, err)
+
+	// k8s only matches the job through the dictionary
+	notifier := &recordingNotifier{}
+	matcher := NewMatcher(alertrepository.NewSavedSearchRepository(db), jobService, notifier)
+	matcher.now = func() time.Time { return time.Now().Add(time.Second) }
+	result, err := jobService.SearchJobs(&dtos.JobSearchParams{Query: "k8s"})
+	require.NoError(t, err)
+	assert.Zero(t, result.TotalCount)
+
+	synonym.SetDefault(synonym.NewDictionary([]synonym.Rule{{Terms: []string{"k8s", "kubernetes"}}}))
+	sent, err := matcher.Run()
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, notifier.messages, 1)
+	assert.Contains(t, notifier.messages[0].Body, "- Platform Engineer at Acme")
+}
+
+func TestMatcher_RunListError(t *testing.T) {
+	mockSearchRepo := &mocks.MockSavedSearchRepository{}
+	mockSearchRepo.On("ListActive").Return([]model.SavedSearch{}, errors.New("database error"))
+
+	sent, err := newTestMatcher(mockSearchRepo, &jobmocks.MockJobService{}, &recordingNotifier{}).Run()
+
+	assert.EqualError(t, err, "failed to list saved searches: database error")
+	assert.Zero(t, sent)
+}
+
+func TestDigest(t *testing.T) {
+	search := &model.SavedSearch{Name: "Remote Rust", Frequency: model.FrequencyWeekly, User: &usermodel.User{Email: "bob@example.com"}}
+
+	message := digest(search, &dtos.PaginatedJobsResponse{
+		Jobs:       []jobmodel.Job{{Title: "Rust Engineer", CompanyName: "Acme"}},
+		TotalCount: 1,
+	})
+
+	assert.Equal(t, `1 new job for "Remote Rust"`, message.Subject)
+	assert.Equal(t, "Hi,\n\n1 new job for your saved search \"Remote Rust\":\n\n- Rust Engineer at Acme\n\n"+
+		"You get this digest weekly. Pause or delete the search in your saved searches.\n", message.Body)
+}
@@ -0,0 +1,19 @@
+CREATE TABLE saved_searches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    params TEXT NOT NULL,
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('instant', 'daily', 'weekly')),
+    paused BOOLEAN NOT NULL DEFAULT 0,
+    checked_at DATETIME NOT NULL,
+    last_alert_at DATETIME,
+
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_user
+        FOREIGN KEY (user_id) REFERENCES users(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_saved_searches_user_id ON saved_searches(user_id);
@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/alert/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSavedSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedSearchRepository) Create(search *model.SavedSearch) (*model.SavedSearch, error) {
+	args := m.Called(search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavedSearch), args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) GetByID(userID, id uint) (*model.SavedSearch, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavedSearch), args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) ListByUser(userID uint) ([]model.SavedSearch, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]model.SavedSearch), args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) CountByUser(userID uint) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) SetPaused(id uint, paused bool) error {
+	args := m.Called(id, paused)
+	return args.Error(0)
+}
+
+func (m *MockSavedSearchRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSavedSearchRepository) ListActive() ([]model.SavedSearch, error) {
+	args := m.Called()
+	return args.Get(0).([]model.SavedSearch), args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) MarkChecked(id uint, checkedAt time.Time, alertedAt *time.Time) error {
+	args := m.Called(id, checkedAt, alertedAt)
+	return args.Error(0)
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
+)
+
+// Frequency is how often a saved search sends digests of its new matches
+type Frequency string
+
+const (
+	// FrequencyInstant sends a digest after every aggregation that found new matches
+	FrequencyInstant Frequency = "instant"
+	// FrequencyDaily sends at most one digest a day
+	FrequencyDaily Frequency = "daily"
+	// FrequencyWeekly sends at most one digest a week
+	FrequencyWeekly Frequency = "weekly"
+)
+
+// Frequencies lists every frequency
+var Frequencies = []Frequency{FrequencyInstant, FrequencyDaily, FrequencyWeekly}
+
+// Valid reports whether f is one of Frequencies
+func (f Frequency) Valid() bool {
+	for _, frequency := range Frequencies {
+		if f == frequency {
+			return true
+		}
+	}
+	return false
+}
+
+// Interval is the time between two checks of a saved search, zero for instant ones
+func (f Frequency) Interval() time.Duration {
+	switch f {
+	case FrequencyDaily:
+		return 24 * time.Hour
+	case FrequencyWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// SavedSearch is a job search a user is alerted of new matches for. Params holds the
+// dtos.JobSearchParams of the search as JSON.
+type SavedSearch struct {
+	ID          uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uint            `gorm:"not null;index:idx_saved_searches_user_id" json:"user_id"`
+	User        *usermodel.User `gorm:"foreignKey:UserID" json:"-"`
+	Name        string          `gorm:"size:100;not null" json:"name"`
+	Params      string          `gorm:"type:text;not null" json:"-"`
+	Frequency   Frequency       `gorm:"size:10;not null" json:"frequency"`
+	Paused      bool            `gorm:"not null;default:false" json:"paused"`
+	CheckedAt   time.Time       `gorm:"not null" json:"checked_at"` // Jobs added up to then were matched already
+	LastAlertAt *time.Time      `json:"last_alert_at"`              // When the last digest went out
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the SavedSearch model
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// Due reports whether the search should be checked for new matches at now
+func (s *SavedSearch) Due(now time.Time, slack time.Duration) bool {
+	return !s.Paused && !now.Before(s.CheckedAt.Add(s.Frequency.Interval()-slack))
+}
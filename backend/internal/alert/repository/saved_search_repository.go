@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/alert/model"
+	"gorm.io/gorm"
+)
+
+type SavedSearchRepository interface {
+	Create(search *model.SavedSearch) (*model.SavedSearch, error)
+	// GetByID returns the search when it belongs to the user, gorm.ErrRecordNotFound otherwise
+	GetByID(userID, id uint) (*model.SavedSearch, error)
+	// ListByUser returns the searches of a user, oldest first
+	ListByUser(userID uint) ([]model.SavedSearch, error)
+	CountByUser(userID uint) (int64, error)
+	SetPaused(id uint, paused bool) error
+	Delete(id uint) error
+
+	// Alerts
+	// ListActive returns the searches that aren't paused with their user, oldest first
+	ListActive() ([]model.SavedSearch, error)
+	// MarkChecked records that the jobs added up to checkedAt were matched, alertedAt is
+	// nil when no digest went out
+	MarkChecked(id uint, checkedAt time.Time, alertedAt *time.Time) error
+}
+
+type savedSearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedSearchRepository(db *gorm.DB) savedSearchRepository {
+	return savedSearchRepository{db: db}
+}
+
+func (r savedSearchRepository) Create(search *model.SavedSearch) (*model.SavedSearch, error) {
+	if err := r.db.Create(search).Error; err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+func (r savedSearchRepository) GetByID(userID, id uint) (*model.SavedSearch, error) {
+	var search model.SavedSearch
+	if err := r.db.Where("user_id = ?", userID).First(&search, id).Error; err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (r savedSearchRepository) ListByUser(userID uint) ([]model.SavedSearch, error) {
+	var searches []model.SavedSearch
+	err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&searches).Error
+	return searches, err
+}
+
+func (r savedSearchRepository) CountByUser(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.SavedSearch{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r savedSearchRepository) SetPaused(id uint, paused bool) error {
+	return r.db.Model(&model.SavedSearch{}).Where("id = ?", id).Update("paused", paused).Error
+}
+
+func (r savedSearchRepository) Delete(id uint) error {
+	return r.db.Delete(&model.SavedSearch{}, id).Error
+}
+
+func (r savedSearchRepository) ListActive() ([]model.SavedSearch, error) {
+	var searches []model.SavedSearch
+	err := r.db.Preload("User").Where("paused = ?", false).Order("id ASC").Find(&searches).Error
+	return searches, err
+}
+
+func (r savedSearchRepository) MarkChecked(id uint, checkedAt time.Time, alertedAt *time.Time) error {
+	updates := map[string]interface{}{"checked_at": checkedAt}
+	if alertedAt != nil {
+		updates["last_alert_at"] = *alertedAt
+	}
+	return r.db.Model(&model.SavedSearch{}).Where("id = ?", id).Updates(updates).Error
+}
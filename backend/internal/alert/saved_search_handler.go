@@ -0,0 +1,237 @@
+package alert
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job"
+	"github.com/bhati00/workova/backend/internal/user"
+	"github.com/gin-gonic/gin"
+)
+
+// SavedSearchHandler handles HTTP requests for the saved searches of the current user
+type SavedSearchHandler struct {
+	savedSearchService SavedSearchService
+}
+
+// NewSavedSearchHandler creates a new saved search handler instance
+func NewSavedSearchHandler(savedSearchService SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{savedSearchService: savedSearchService}
+}
+
+// CreateSavedSearch godoc
+// @Summary Save a job search
+// @Description Saves the filters of a GET /jobs/search query string and alerts of the jobs added from now on, in digests sent instantly after each aggregation, daily or weekly. Paging, sorting, facets and highlights are dropped.
+// @Tags Saved Searches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param search body dtos.CreateSavedSearchRequest true "Search"
+// @Success 201 {object} dtos.APIResponse{data=dtos.SavedSearchResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 409 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /saved-searches [post]
+func (h *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	var request dtos.CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+	values, err := url.ParseQuery(request.Search)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid search: " + err.Error(),
+		})
+		return
+	}
+	params, fieldErrors := job.ParseSearchParams(values)
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid search parameters",
+			Errors:  fieldErrors,
+		})
+		return
+	}
+
+	search, err := h.savedSearchService.CreateSavedSearch(user.CurrentUser(c).ID, request.Name, params, request.Frequency)
+	if err != nil {
+		c.JSON(savedSearchErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to save search: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dtos.APIResponse{
+		Success: true,
+		Message: "Search saved successfully",
+		Data:    search,
+	})
+}
+
+// ListSavedSearches godoc
+// @Summary List saved searches
+// @Description Returns the saved searches of the current user, oldest first
+// @Tags Saved Searches
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.APIResponse{data=[]dtos.SavedSearchResponse}
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /saved-searches [get]
+func (h *SavedSearchHandler) ListSavedSearches(c *gin.Context) {
+	searches, err := h.savedSearchService.ListSavedSearches(user.CurrentUser(c).ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get saved searches: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    searches,
+	})
+}
+
+// PauseSavedSearch godoc
+// @Summary Pause a saved search
+// @Description Stops the alerts of a saved search until it is resumed
+// @Tags Saved Searches
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Saved search ID"
+// @Success 200 {object} dtos.APIResponse{data=dtos.SavedSearchResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /saved-searches/{id}/pause [patch]
+func (h *SavedSearchHandler) PauseSavedSearch(c *gin.Context) {
+	h.setPaused(c, true)
+}
+
+// ResumeSavedSearch godoc
+// @Summary Resume a saved search
+// @Description Restarts the alerts of a paused saved search. Jobs added during the pause aren't alerted.
+// @Tags Saved Searches
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Saved search ID"
+// @Success 200 {object} dtos.APIResponse{data=dtos.SavedSearchResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /saved-searches/{id}/resume [patch]
+func (h *SavedSearchHandler) ResumeSavedSearch(c *gin.Context) {
+	h.setPaused(c, false)
+}
+
+func (h *SavedSearchHandler) setPaused(c *gin.Context, paused bool) {
+	id, ok := savedSearchID(c)
+	if !ok {
+		return
+	}
+
+	search, err := h.savedSearchService.SetPaused(user.CurrentUser(c).ID, id, paused)
+	if err != nil {
+		c.JSON(savedSearchErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to update saved search: " + err.Error(),
+		})
+		return
+	}
+
+	message := "Saved search resumed successfully"
+	if paused {
+		message = "Saved search paused successfully"
+	}
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    search,
+	})
+}
+
+// DeleteSavedSearch godoc
+// @Summary Delete a saved search
+// @Tags Saved Searches
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Saved search ID"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /saved-searches/{id} [delete]
+func (h *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	id, ok := savedSearchID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.savedSearchService.DeleteSavedSearch(user.CurrentUser(c).ID, id); err != nil {
+		c.JSON(savedSearchErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to delete saved search: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "Saved search deleted successfully",
+	})
+}
+
+// savedSearchID parses the id path parameter, responding with 400 when it is malformed
+func savedSearchID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid saved search ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// savedSearchErrorStatus maps saved search service errors to HTTP status codes
+func savedSearchErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidSavedSearch):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrSavedSearchNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTooManySavedSearches):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// RegisterSavedSearchRoutes registers the saved search routes, for signed in users only
+func (h *SavedSearchHandler) RegisterSavedSearchRoutes(router *gin.RouterGroup) {
+	searches := router.Group("/saved-searches", user.RequireUser())
+	{
+		searches.GET("", h.ListSavedSearches)
+		searches.POST("", h.CreateSavedSearch)
+		searches.PATCH("/:id/pause", h.PauseSavedSearch)
+		searches.PATCH("/:id/resume", h.ResumeSavedSearch)
+		searches.DELETE("/:id", h.DeleteSavedSearch)
+	}
+}
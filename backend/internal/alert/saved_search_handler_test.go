@@ -0,0 +1,182 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/alert/mocks"
+	"github.com/bhati00/workova/backend/internal/alert/model"
+	jobmocks "github.com/bhati00/workova/backend/internal/job/mocks"
+	"github.com/bhati00/workova/backend/internal/user"
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// readerUserService authenticates the access token "ada" as the reader with ID 7
+type readerUserService struct {
+	user.UserService
+}
+
+func (readerUserService) Authenticate(accessToken string) (*usermodel.User, *usermodel.Session, error) {
+	if accessToken != "ada" {
+		return nil, nil, user.ErrInvalidToken
+	}
+	return &usermodel.User{ID: 7, Email: "ada@example.com", Role: usermodel.RoleReader}, &usermodel.Session{ID: 1, UserID: 7}, nil
+}
+
+func newSavedSearchRouter(searchRepo *mocks.MockSavedSearchRepository, jobService *jobmocks.MockJobService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(user.Authenticate(readerUserService{}))
+	NewSavedSearchHandler(newTestSavedSearchService(searchRepo, jobService)).RegisterSavedSearchRoutes(router.Group("/api"))
+	return router
+}
+
+func TestSavedSearchHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		anonymous      bool
+		setupMocks     func(*mocks.MockSavedSearchRepository, *jobmocks.MockJobService)
+		expectedStatus int
+	}{
+		{
+			name:           "anonymous",
+			method:         http.MethodGet,
+			path:           "/api/saved-searches",
+			anonymous:      true,
+			setupMocks:     func(*mocks.MockSavedSearchRepository, *jobmocks.MockJobService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "lists_own_searches",
+			method: http.MethodGet,
+			path:   "/api/saved-searches",
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, _ *jobmocks.MockJobService) {
+				searchRepo.On("ListByUser", uint(7)).Return([]model.SavedSearch{*savedSearch(t, 4, dtos.JobSearchParams{Query: "golang"})}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "creates_search",
+			method: http.MethodPost,
+			path:   "/api/saved-searches",
+			body:   `{"name": "Remote Go", "search": "query=golang&is_remote=true&page=2", "frequency": "instant"}`,
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, jobService *jobmocks.MockJobService) {
+				searchRepo.On("CountByUser", uint(7)).Return(int64(0), nil)
+				jobService.On("SearchJobs", mock.Anything).Return(&dtos.PaginatedJobsResponse{}, nil)
+				searchRepo.On("Create", mock.MatchedBy(func(search *model.SavedSearch) bool {
+					params := decodeSearch(search)
+					return search.UserID == 7 && search.Frequency == model.FrequencyInstant &&
+						params.Query == "golang" && *params.IsRemote && params.Offset == 0
+				})).Return(savedSearch(t, 4, dtos.JobSearchParams{Query: "golang"}), nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "creates_search_with_invalid_params",
+			method:         http.MethodPost,
+			path:           "/api/saved-searches",
+			body:           `{"name": "Go", "search": "min_salary=lots"}`,
+			setupMocks:     func(*mocks.MockSavedSearchRepository, *jobmocks.MockJobService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "creates_search_with_unknown_frequency",
+			method:         http.MethodPost,
+			path:           "/api/saved-searches",
+			body:           `{"name": "Go", "search": "query=golang", "frequency": "hourly"}`,
+			setupMocks:     func(*mocks.MockSavedSearchRepository, *jobmocks.MockJobService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "creates_too_many_searches",
+			method: http.MethodPost,
+			path:   "/api/saved-searches",
+			body:   `{"name": "Go", "search": "query=golang"}`,
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, _ *jobmocks.MockJobService) {
+				searchRepo.On("CountByUser", uint(7)).Return(int64(MaxSavedSearches), nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "pauses_search",
+			method: http.MethodPatch,
+			path:   "/api/saved-searches/4/pause",
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, _ *jobmocks.MockJobService) {
+				searchRepo.On("GetByID", uint(7), uint(4)).Return(savedSearch(t, 4, dtos.JobSearchParams{}), nil)
+				searchRepo.On("SetPaused", uint(4), true).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "resumes_search_of_another_user",
+			method: http.MethodPatch,
+			path:   "/api/saved-searches/5/resume",
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, _ *jobmocks.MockJobService) {
+				searchRepo.On("GetByID", uint(7), uint(5)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "deletes_malformed_id",
+			method:         http.MethodDelete,
+			path:           "/api/saved-searches/abc",
+			setupMocks:     func(*mocks.MockSavedSearchRepository, *jobmocks.MockJobService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "deletes_search",
+			method: http.MethodDelete,
+			path:   "/api/saved-searches/4",
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, _ *jobmocks.MockJobService) {
+				searchRepo.On("GetByID", uint(7), uint(4)).Return(savedSearch(t, 4, dtos.JobSearchParams{}), nil)
+				searchRepo.On("Delete", uint(4)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSearchRepo := &mocks.MockSavedSearchRepository{}
+			mockJobService := &jobmocks.MockJobService{}
+			tt.setupMocks(mockSearchRepo, mockJobService)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if !tt.anonymous {
+				req.Header.Set("Authorization", "Bearer ada")
+			}
+			newSavedSearchRouter(mockSearchRepo, mockJobService).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			mockSearchRepo.AssertExpectations(t)
+			mockJobService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavedSearchHandler_InvalidParamsReportFields(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/saved-searches", strings.NewReader(`{"name": "Go", "search": "min_salary=lots"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer ada")
+	newSavedSearchRouter(&mocks.MockSavedSearchRepository{}, &jobmocks.MockJobService{}).ServeHTTP(w, req)
+
+	var response dtos.APIResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "min_salary", response.Errors[0].Field)
+}
@@ -0,0 +1,202 @@
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/alert/model"
+	"github.com/bhati00/workova/backend/internal/alert/repository"
+	"github.com/bhati00/workova/backend/internal/job"
+	"gorm.io/gorm"
+)
+
+// SavedSearchService defines business logic operations for saved searches. Users only
+// ever see and change their own searches.
+type SavedSearchService interface {
+	// CreateSavedSearch saves params, alerting of the jobs added from now on
+	CreateSavedSearch(userID uint, name string, params *dtos.JobSearchParams, frequency model.Frequency) (*dtos.SavedSearchResponse, error)
+	ListSavedSearches(userID uint) ([]dtos.SavedSearchResponse, error)
+	// SetPaused pauses or resumes the alerts of a search. A resumed search doesn't alert
+	// of the jobs added while it was paused.
+	SetPaused(userID, id uint, paused bool) (*dtos.SavedSearchResponse, error)
+	DeleteSavedSearch(userID, id uint) error
+}
+
+var (
+	// ErrInvalidSavedSearch is returned for unusable names, frequencies or searches
+	ErrInvalidSavedSearch = errors.New("invalid saved search")
+	// ErrSavedSearchNotFound is returned when the user has no search with the requested ID
+	ErrSavedSearchNotFound = errors.New("saved search not found")
+	// ErrTooManySavedSearches is returned when the user has MaxSavedSearches already
+	ErrTooManySavedSearches = errors.New("too many saved searches")
+)
+
+const (
+	// MaxSavedSearches is how many searches a user can save
+	MaxSavedSearches    = 20
+	maxSearchNameLength = 100
+)
+
+// savedSearchService implements SavedSearchService interface
+type savedSearchService struct {
+	searchRepo repository.SavedSearchRepository
+	jobService job.JobService
+	now        func() time.Time
+}
+
+// NewSavedSearchService creates a new saved search service. Searches are run once through
+// jobService when saved, so the ones that can't run are rejected up front.
+func NewSavedSearchService(searchRepo repository.SavedSearchRepository, jobService job.JobService) SavedSearchService {
+	return &savedSearchService{searchRepo: searchRepo, jobService: jobService, now: time.Now}
+}
+
+func (s *savedSearchService) CreateSavedSearch(userID uint, name string, params *dtos.JobSearchParams, frequency model.Frequency) (*dtos.SavedSearchResponse, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxSearchNameLength {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidSavedSearch, maxSearchNameLength)
+	}
+	if frequency == "" {
+		frequency = model.FrequencyDaily
+	}
+	if !frequency.Valid() {
+		return nil, fmt.Errorf("%w: frequency must be instant, daily or weekly", ErrInvalidSavedSearch)
+	}
+
+	count, err := s.searchRepo.CountByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count saved searches: %w", err)
+	}
+	if count >= MaxSavedSearches {
+		return nil, fmt.Errorf("%w: at most %d are allowed", ErrTooManySavedSearches, MaxSavedSearches)
+	}
+
+	saved := savedParams(params)
+	encoded, err := json.Marshal(saved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode search: %w", err)
+	}
+	// The service resolves params in place, the run works on a copy
+	trial := *saved
+	trial.Limit = 1
+	if _, err := s.jobService.SearchJobs(&trial); errors.Is(err, job.ErrInvalidSearch) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSavedSearch, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to run search: %w", err)
+	}
+
+	search, err := s.searchRepo.Create(&model.SavedSearch{
+		UserID:    userID,
+		Name:      name,
+		Params:    string(encoded),
+		Frequency: frequency,
+		CheckedAt: s.now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saved search: %w", err)
+	}
+	return toSavedSearchResponse(search)
+}
+
+func (s *savedSearchService) ListSavedSearches(userID uint) ([]dtos.SavedSearchResponse, error) {
+	searches, err := s.searchRepo.ListByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	responses := make([]dtos.SavedSearchResponse, len(searches))
+	for i := range searches {
+		response, err := toSavedSearchResponse(&searches[i])
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = *response
+	}
+	return responses, nil
+}
+
+func (s *savedSearchService) SetPaused(userID, id uint, paused bool) (*dtos.SavedSearchResponse, error) {
+	search, err := s.getSavedSearch(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if search.Paused == paused {
+		return toSavedSearchResponse(search)
+	}
+
+	if paused {
+		err = s.searchRepo.SetPaused(id, true)
+	} else {
+		// Skip what was added during the pause, the digest would be stale or huge
+		now := s.now()
+		if err = s.searchRepo.MarkChecked(id, now, nil); err == nil {
+			err = s.searchRepo.SetPaused(id, false)
+		}
+		search.CheckedAt = now
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update saved search: %w", err)
+	}
+	search.Paused = paused
+	return toSavedSearchResponse(search)
+}
+
+func (s *savedSearchService) DeleteSavedSearch(userID, id uint) error {
+	if _, err := s.getSavedSearch(userID, id); err != nil {
+		return err
+	}
+	if err := s.searchRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	return nil
+}
+
+func (s *savedSearchService) getSavedSearch(userID, id uint) (*model.SavedSearch, error) {
+	search, err := s.searchRepo.GetByID(userID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSavedSearchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+	return search, nil
+}
+
+// savedParams returns the filters of params, without the options that only apply to a
+// page of results
+func savedParams(params *dtos.JobSearchParams) *dtos.JobSearchParams {
+	saved := *params
+	saved.Offset, saved.Limit, saved.Cursor, saved.Keyset = 0, 0, "", nil
+	saved.SortBy, saved.SortOrder, saved.Facets, saved.Highlight = "", "", nil, nil
+	saved.AutoCorrect = false
+	saved.CreatedAfter, saved.CreatedBefore = nil, nil
+	return &saved
+}
+
+// decodeParams reads the params a search was saved with
+func decodeParams(search *model.SavedSearch) (*dtos.JobSearchParams, error) {
+	var params dtos.JobSearchParams
+	if err := json.Unmarshal([]byte(search.Params), &params); err != nil {
+		return nil, fmt.Errorf("failed to decode saved search %d: %w", search.ID, err)
+	}
+	return &params, nil
+}
+
+func toSavedSearchResponse(search *model.SavedSearch) (*dtos.SavedSearchResponse, error) {
+	params, err := decodeParams(search)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.SavedSearchResponse{
+		ID:          search.ID,
+		Name:        search.Name,
+		Params:      params,
+		Frequency:   search.Frequency,
+		Paused:      search.Paused,
+		CheckedAt:   search.CheckedAt,
+		LastAlertAt: search.LastAlertAt,
+		CreatedAt:   search.CreatedAt,
+	}, nil
+}
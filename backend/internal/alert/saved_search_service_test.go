@@ -0,0 +1,218 @@
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/alert/mocks"
+	"github.com/bhati00/workova/backend/internal/alert/model"
+	"github.com/bhati00/workova/backend/internal/job"
+	jobmocks "github.com/bhati00/workova/backend/internal/job/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestSavedSearchService returns a saved search service with a fixed clock
+func newTestSavedSearchService(searchRepo *mocks.MockSavedSearchRepository, jobService *jobmocks.MockJobService) *savedSearchService {
+	s := NewSavedSearchService(searchRepo, jobService).(*savedSearchService)
+	s.now = func() time.Time { return testNow }
+	return s
+}
+
+// savedSearch returns a search of user 7 saved with params
+func savedSearch(t *testing.T, id uint, params dtos.JobSearchParams) *model.SavedSearch {
+	encoded, err := json.Marshal(params)
+	require.NoError(t, err)
+	return &model.SavedSearch{ID: id, UserID: 7, Name: "Go", Params: string(encoded), Frequency: model.FrequencyDaily, CheckedAt: testNow.Add(-48 * time.Hour)}
+}
+
+// decodeSearch returns the params a search was saved with, nil when they don't decode
+func decodeSearch(search *model.SavedSearch) *dtos.JobSearchParams {
+	params, err := decodeParams(search)
+	if err != nil {
+		return nil
+	}
+	return params
+}
+
+func TestSavedSearchService_CreateSavedSearch(t *testing.T) {
+	remote := true
+	tests := []struct {
+		name          string
+		searchName    string
+		params        *dtos.JobSearchParams
+		frequency     model.Frequency
+		setupMocks    func(*mocks.MockSavedSearchRepository, *jobmocks.MockJobService)
+		expectedError error
+	}{
+		{
+			name:       "saves_filters_only",
+			searchName: " Remote Go ",
+			params: &dtos.JobSearchParams{Query: "golang", IsRemote: &remote, Offset: 40, Limit: 20, SortBy: "salary_max",
+				Facets: []string{"skills"}, Highlight: &dtos.HighlightOptions{}, AutoCorrect: true},
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, jobService *jobmocks.MockJobService) {
+				searchRepo.On("CountByUser", uint(7)).Return(int64(3), nil)
+				jobService.On("SearchJobs", mock.MatchedBy(func(params *dtos.JobSearchParams) bool {
+					return params.Query == "golang" && params.Limit == 1 && params.Offset == 0 && params.Facets == nil
+				})).Return(&dtos.PaginatedJobsResponse{}, nil)
+				searchRepo.On("Create", mock.MatchedBy(func(search *model.SavedSearch) bool {
+					return search.UserID == 7 && search.Name == "Remote Go" && search.Frequency == model.FrequencyDaily &&
+						search.CheckedAt.Equal(testNow) && !search.Paused &&
+						assert.ObjectsAreEqual(&dtos.JobSearchParams{Query: "golang", IsRemote: &remote}, decodeSearch(search))
+				})).Return(savedSearch(t, 4, dtos.JobSearchParams{Query: "golang", IsRemote: &remote}), nil)
+			},
+		},
+		{
+			name:          "missing_name",
+			searchName:    " ",
+			params:        &dtos.JobSearchParams{},
+			setupMocks:    func(*mocks.MockSavedSearchRepository, *jobmocks.MockJobService) {},
+			expectedError: ErrInvalidSavedSearch,
+		},
+		{
+			name:          "unknown_frequency",
+			searchName:    "Go",
+			params:        &dtos.JobSearchParams{},
+			frequency:     "hourly",
+			setupMocks:    func(*mocks.MockSavedSearchRepository, *jobmocks.MockJobService) {},
+			expectedError: ErrInvalidSavedSearch,
+		},
+		{
+			name:       "too_many",
+			searchName: "Go",
+			params:     &dtos.JobSearchParams{},
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, _ *jobmocks.MockJobService) {
+				searchRepo.On("CountByUser", uint(7)).Return(int64(MaxSavedSearches), nil)
+			},
+			expectedError: ErrTooManySavedSearches,
+		},
+		{
+			name:       "search_that_cannot_run",
+			searchName: "Go",
+			params:     &dtos.JobSearchParams{Near: "Atlantis"},
+			frequency:  model.FrequencyWeekly,
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, jobService *jobmocks.MockJobService) {
+				searchRepo.On("CountByUser", uint(7)).Return(int64(0), nil)
+				jobService.On("SearchJobs", mock.Anything).Return(nil, fmt.Errorf("%w: unknown city", job.ErrInvalidSearch))
+			},
+			expectedError: ErrInvalidSavedSearch,
+		},
+		{
+			name:       "search_error",
+			searchName: "Go",
+			params:     &dtos.JobSearchParams{},
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository, jobService *jobmocks.MockJobService) {
+				searchRepo.On("CountByUser", uint(7)).Return(int64(0), nil)
+				jobService.On("SearchJobs", mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("failed to run search: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSearchRepo := &mocks.MockSavedSearchRepository{}
+			mockJobService := &jobmocks.MockJobService{}
+			tt.setupMocks(mockSearchRepo, mockJobService)
+
+			search, err := newTestSavedSearchService(mockSearchRepo, mockJobService).CreateSavedSearch(7, tt.searchName, tt.params, tt.frequency)
+
+			switch {
+			case tt.expectedError == nil:
+				require.NoError(t, err)
+				assert.Equal(t, uint(4), search.ID)
+				assert.Equal(t, &dtos.JobSearchParams{Query: "golang", IsRemote: &remote}, search.Params)
+			case errors.Is(tt.expectedError, ErrInvalidSavedSearch) || errors.Is(tt.expectedError, ErrTooManySavedSearches):
+				assert.ErrorIs(t, err, tt.expectedError)
+			default:
+				assert.EqualError(t, err, tt.expectedError.Error())
+			}
+			mockSearchRepo.AssertExpectations(t)
+			mockJobService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavedSearchService_SetPaused(t *testing.T) {
+	tests := []struct {
+		name          string
+		paused        bool
+		setupMocks    func(*mocks.MockSavedSearchRepository)
+		expectedError error
+	}{
+		{
+			name:   "pauses",
+			paused: true,
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository) {
+				searchRepo.On("GetByID", uint(7), uint(4)).Return(savedSearch(t, 4, dtos.JobSearchParams{}), nil)
+				searchRepo.On("SetPaused", uint(4), true).Return(nil)
+			},
+		},
+		{
+			name:   "resumes_from_now",
+			paused: false,
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository) {
+				search := savedSearch(t, 4, dtos.JobSearchParams{})
+				search.Paused = true
+				searchRepo.On("GetByID", uint(7), uint(4)).Return(search, nil)
+				searchRepo.On("MarkChecked", uint(4), testNow, (*time.Time)(nil)).Return(nil)
+				searchRepo.On("SetPaused", uint(4), false).Return(nil)
+			},
+		},
+		{
+			name:   "already_paused",
+			paused: true,
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository) {
+				search := savedSearch(t, 4, dtos.JobSearchParams{})
+				search.Paused = true
+				searchRepo.On("GetByID", uint(7), uint(4)).Return(search, nil)
+			},
+		},
+		{
+			name:   "search_of_another_user",
+			paused: true,
+			setupMocks: func(searchRepo *mocks.MockSavedSearchRepository) {
+				searchRepo.On("GetByID", uint(7), uint(4)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrSavedSearchNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSearchRepo := &mocks.MockSavedSearchRepository{}
+			tt.setupMocks(mockSearchRepo)
+
+			search, err := newTestSavedSearchService(mockSearchRepo, &jobmocks.MockJobService{}).SetPaused(7, 4, tt.paused)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, search)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.paused, search.Paused)
+			}
+			mockSearchRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavedSearchService_DeleteSavedSearch(t *testing.T) {
+	mockSearchRepo := &mocks.MockSavedSearchRepository{}
+	mockSearchRepo.On("GetByID", uint(7), uint(4)).Return(savedSearch(t, 4, dtos.JobSearchParams{}), nil)
+	mockSearchRepo.On("Delete", uint(4)).Return(nil)
+	mockSearchRepo.On("GetByID", uint(7), uint(5)).Return(nil, gorm.ErrRecordNotFound)
+	service := newTestSavedSearchService(mockSearchRepo, &jobmocks.MockJobService{})
+
+	assert.NoError(t, service.DeleteSavedSearch(7, 4))
+	assert.ErrorIs(t, service.DeleteSavedSearch(7, 5), ErrSavedSearchNotFound)
+	mockSearchRepo.AssertExpectations(t)
+}
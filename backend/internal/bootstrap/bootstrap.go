@@ -5,7 +5,9 @@ import (
 
 	"github.com/bhati00/workova/backend/config"
 	"github.com/bhati00/workova/backend/docs"
+	"github.com/bhati00/workova/backend/internal/alert"
 	"github.com/bhati00/workova/backend/internal/job"
+	"github.com/bhati00/workova/backend/internal/user"
 	. "github.com/bhati00/workova/backend/pkg/database"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -26,22 +28,40 @@ import (
 
 // @host localhost:8080
 // @BasePath /api/v1
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token from /auth/login, as "Bearer <token>"
+
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description Partner API key issued under /admin/api-keys
 func InitializeApp() *gin.Engine {
 	cfg := config.LoadConfig()
 	db := ConnectDatabase(*cfg)
 	r := gin.Default()
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"}, // Your Next.js frontend URL
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Requested-With", "X-API-Key"},
+		ExposeHeaders:    []string{"Content-Length", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 	docs.SwaggerInfo.BasePath = "/api"
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Resolve the current user and API key before any route so every handler can see them
+	userModule := user.InitializeUserModule(db, cfg)
+	r.Use(userModule.Authenticate(), userModule.AuthenticateAPIKey())
+	userModule.RegisterRoutes(r)
+
 	jobModule := job.InitializeJobModule(db, cfg)
 	jobModule.RegisterRoutes(r)
+
+	alertModule := alert.InitializeAlertModule(db, cfg, jobModule.Service)
+	alertModule.RegisterRoutes(r)
 	return r
 }
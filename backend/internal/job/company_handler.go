@@ -0,0 +1,342 @@
+package job
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user"
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/gin-gonic/gin"
+)
+
+// CompanyHandler handles HTTP requests for company operations
+type CompanyHandler struct {
+	companyService CompanyService
+	jobService     JobService
+}
+
+// NewCompanyHandler creates a new company handler instance
+func NewCompanyHandler(companyService CompanyService, jobService JobService) *CompanyHandler {
+	return &CompanyHandler{
+		companyService: companyService,
+		jobService:     jobService,
+	}
+}
+
+// ListCompanies godoc
+// @Summary List companies
+// @Description Returns paginated companies with their open job counts and sources, most open jobs first
+// @Tags Companies
+// @Produce json
+// @Param query query string false "Company name contains"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /companies [get]
+func (h *CompanyHandler) ListCompanies(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.companyService.ListCompanies(c.Query("query"), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get companies: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// GetCompany godoc
+// @Summary Get a company
+// @Description Returns the company profile with its open job count and sources
+// @Tags Companies
+// @Produce json
+// @Param slug path string true "Company slug"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /companies/{slug} [get]
+func (h *CompanyHandler) GetCompany(c *gin.Context) {
+	company, err := h.companyService.GetCompany(c.Param("slug"))
+	if errors.Is(err, ErrCompanyNotFound) {
+		c.JSON(http.StatusNotFound, dtos.APIResponse{
+			Success: false,
+			Error:   "Company not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get company: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    company,
+	})
+}
+
+// GetCompanyJobs godoc
+// @Summary Get a company's jobs
+// @Description Returns the paginated jobs of a company, newest first
+// @Tags Companies
+// @Produce json
+// @Param slug path string true "Company slug"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Param description_format query string false "Format of the description: html (default), markdown or text"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /companies/{slug}/jobs [get]
+func (h *CompanyHandler) GetCompanyJobs(c *gin.Context) {
+	slug := c.Param("slug")
+	format, err := parseDescriptionFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if _, err := h.companyService.GetCompany(slug); err != nil {
+		status, message := http.StatusInternalServerError, "Failed to get company: "+err.Error()
+		if errors.Is(err, ErrCompanyNotFound) {
+			status, message = http.StatusNotFound, "Company not found"
+		}
+		c.JSON(status, dtos.APIResponse{
+			Success: false,
+			Error:   message,
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	params := &dtos.JobSearchParams{
+		Company: []string{slug},
+		Offset:  (page - 1) * pageSize,
+		Limit:   pageSize,
+	}
+
+	result, err := h.jobService.SearchJobs(params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get jobs: " + err.Error(),
+		})
+		return
+	}
+	for i := range result.Jobs {
+		applyDescriptionFormat(&result.Jobs[i], format)
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// GetCompanyAliases godoc
+// @Summary List company aliases
+// @Description Returns the other names ingestion matches to a company
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param slug path string true "Company slug"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/companies/{slug}/aliases [get]
+func (h *CompanyHandler) GetCompanyAliases(c *gin.Context) {
+	aliases, err := h.companyService.GetAliases(c.Param("slug"))
+	if err != nil {
+		c.JSON(companyErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get aliases: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    aliases,
+	})
+}
+
+// AddCompanyAlias godoc
+// @Summary Add a company alias
+// @Description Makes ingestion match jobs posted under another name to the company
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param slug path string true "Company slug"
+// @Param alias body dtos.CompanyAliasRequest true "Alias"
+// @Success 201 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 409 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/companies/{slug}/aliases [post]
+func (h *CompanyHandler) AddCompanyAlias(c *gin.Context) {
+	var request dtos.CompanyAliasRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	alias, err := h.companyService.AddAlias(c.Param("slug"), request)
+	if err != nil {
+		c.JSON(companyErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to add alias: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dtos.APIResponse{
+		Success: true,
+		Message: "Alias added successfully",
+		Data:    alias,
+	})
+}
+
+// MergeCompanies godoc
+// @Summary Merge two companies
+// @Description Moves all jobs and aliases of the source company to the target, deletes the source and records the merge as performed by the current user
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param merge body dtos.CompanyMergeRequest true "Merge request"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/companies/merge [post]
+func (h *CompanyHandler) MergeCompanies(c *gin.Context) {
+	var request dtos.CompanyMergeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	var performedBy *string
+	if admin := user.CurrentUser(c); admin != nil {
+		performedBy = &admin.Email
+	}
+	merge, err := h.companyService.MergeCompanies(request, performedBy)
+	if err != nil {
+		c.JSON(companyErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to merge companies: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "Companies merged successfully",
+		Data:    merge,
+	})
+}
+
+// GetCompanyMerges godoc
+// @Summary List company merges
+// @Description Returns the audit log of company merges, newest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/companies/merges [get]
+func (h *CompanyHandler) GetCompanyMerges(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.companyService.GetMerges(page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get merges: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// companyErrorStatus maps company service errors to HTTP status codes
+func companyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrCompanyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidCompanyRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrAliasTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// RegisterCompanyRoutes registers all company-related routes
+func (h *CompanyHandler) RegisterCompanyRoutes(router *gin.RouterGroup) {
+	companies := router.Group("/companies", user.RequireScope(usermodel.ScopeJobsRead))
+	{
+		companies.GET("", h.ListCompanies)
+		companies.GET("/:slug", h.GetCompany)
+		companies.GET("/:slug/jobs", h.GetCompanyJobs)
+	}
+}
+
+// RegisterCompanyAdminRoutes registers the company curation routes
+func (h *CompanyHandler) RegisterCompanyAdminRoutes(router *gin.RouterGroup) {
+	companies := router.Group("/admin/companies", user.RequirePermission(user.PermissionCurateCompanies))
+	{
+		companies.GET("/merges", h.GetCompanyMerges)
+		companies.POST("/merge", h.MergeCompanies)
+		companies.GET("/:slug/aliases", h.GetCompanyAliases)
+		companies.POST("/:slug/aliases", h.AddCompanyAlias)
+	}
+}
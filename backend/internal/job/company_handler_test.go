@@ -0,0 +1,43 @@
+package job
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bhati00/workova/backend/internal/job/mocks"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/internal/user"
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/bhati00/workova/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompanyHandler_MergeCompaniesRecordsCurrentUser(t *testing.T) {
+	source := &model.Company{ID: 2, Slug: "splash-2"}
+	target := &model.Company{ID: 1, Slug: "splash"}
+	mockCompanyRepo := &mocks.MockCompanyRepository{}
+	mockCompanyRepo.On("GetBySlug", "splash-2").Return(source, nil)
+	mockCompanyRepo.On("GetBySlug", "splash").Return(target, nil)
+	mockCompanyRepo.On("Merge", source, target, (*string)(nil), utils.String("admin@workova.dev")).
+		Return(&model.CompanyMerge{ID: 1, SourceCompanyID: 2, TargetCompanyID: 1, PerformedBy: utils.String("admin@workova.dev")}, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(user.Authenticate(roleUserService{}))
+	NewCompanyHandler(NewCompanyService(mockCompanyRepo), &mocks.MockJobService{}).RegisterCompanyAdminRoutes(router.Group("/api"))
+
+	// A performed_by in the body is ignored
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/companies/merge",
+		strings.NewReader(`{"source": "splash-2", "target": "splash", "performed_by": "someone@workova.dev"}`))
+	req.Header.Set("Content-Type", "application/json")
+	authenticateAs(req, usermodel.RoleAdmin)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"performed_by":"admin@workova.dev"`)
+	mockCompanyRepo.AssertExpectations(t)
+}
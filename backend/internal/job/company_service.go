@@ -0,0 +1,223 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/internal/job/repository"
+	companyname "github.com/bhati00/workova/backend/pkg/company_name"
+	"gorm.io/gorm"
+)
+
+// CompanyService defines business logic operations for companies
+type CompanyService interface {
+	ListCompanies(query string, page, pageSize int) (*dtos.PaginatedCompaniesResponse, error)
+	GetCompany(slug string) (*dtos.CompanyResponse, error)
+
+	// Admin operations
+	GetAliases(slug string) ([]model.CompanyAlias, error)
+	AddAlias(slug string, request dtos.CompanyAliasRequest) (*model.CompanyAlias, error)
+	MergeCompanies(request dtos.CompanyMergeRequest, performedBy *string) (*model.CompanyMerge, error)
+	GetMerges(page, pageSize int) (*dtos.PaginatedCompanyMergesResponse, error)
+}
+
+var (
+	// ErrCompanyNotFound is returned when no company has the requested slug
+	ErrCompanyNotFound = errors.New("company not found")
+	// ErrInvalidCompanyRequest is returned for unusable alias or merge requests
+	ErrInvalidCompanyRequest = errors.New("invalid company request")
+	// ErrAliasTaken is returned when an alias already matches a company
+	ErrAliasTaken = errors.New("alias already matches a company")
+)
+
+// companyService implements CompanyService interface
+type companyService struct {
+	companyRepo repository.CompanyRepository
+}
+
+// NewCompanyService creates a new company service instance
+func NewCompanyService(companyRepo repository.CompanyRepository) CompanyService {
+	return &companyService{companyRepo: companyRepo}
+}
+
+// ListCompanies returns companies whose name contains query, most open jobs first
+func (s *companyService) ListCompanies(query string, page, pageSize int) (*dtos.PaginatedCompaniesResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	companies, totalCount, err := s.companyRepo.List(query, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list companies: %w", err)
+	}
+	responses, err := s.withStats(companies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.PaginatedCompaniesResponse{
+		Companies:   responses,
+		TotalCount:  totalCount,
+		CurrentPage: page,
+		PageSize:    pageSize,
+		TotalPages:  int((totalCount + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// GetCompany returns the profile of the company with the given slug
+func (s *companyService) GetCompany(slug string) (*dtos.CompanyResponse, error) {
+	company, err := s.getBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.withStats([]model.Company{*company})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+// GetAliases returns the other names of the company with the given slug
+func (s *companyService) GetAliases(slug string) ([]model.CompanyAlias, error) {
+	company, err := s.getBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := s.companyRepo.GetAliases(company.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// AddAlias makes ingestion match name to the company with the given slug
+func (s *companyService) AddAlias(slug string, request dtos.CompanyAliasRequest) (*model.CompanyAlias, error) {
+	normalized := companyname.Normalize(request.Name)
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: alias name is required", ErrInvalidCompanyRequest)
+	}
+	company, err := s.getBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.companyRepo.GetByNormalizedName(normalized)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check alias: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %q matches %s", ErrAliasTaken, request.Name, existing.Slug)
+	}
+
+	alias, err := s.companyRepo.CreateAlias(&model.CompanyAlias{
+		CompanyID:      company.ID,
+		Name:           strings.TrimSpace(request.Name),
+		NormalizedName: normalized,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alias: %w", err)
+	}
+	log.Printf("Added alias %q to company %s", alias.Name, company.Slug)
+	return alias, nil
+}
+
+// MergeCompanies moves every job and alias of the source company to the target and
+// deletes the source, leaving an audit record naming performedBy
+func (s *companyService) MergeCompanies(request dtos.CompanyMergeRequest, performedBy *string) (*model.CompanyMerge, error) {
+	if request.Source == "" || request.Target == "" {
+		return nil, fmt.Errorf("%w: source and target are required", ErrInvalidCompanyRequest)
+	}
+	if request.Source == request.Target {
+		return nil, fmt.Errorf("%w: can't merge a company into itself", ErrInvalidCompanyRequest)
+	}
+	source, err := s.getBySlug(request.Source)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getBySlug(request.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	merge, err := s.companyRepo.Merge(source, target, request.Reason, performedBy)
+	if err != nil {
+		log.Printf("Failed to merge company %s into %s: %v", source.Slug, target.Slug, err)
+		return nil, fmt.Errorf("failed to merge companies: %w", err)
+	}
+	log.Printf("Merged company %s into %s (%d jobs moved)", source.Slug, target.Slug, merge.JobsMoved)
+	return merge, nil
+}
+
+// GetMerges returns the merge audit log, newest first
+func (s *companyService) GetMerges(page, pageSize int) (*dtos.PaginatedCompanyMergesResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	merges, totalCount, err := s.companyRepo.GetMerges((page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merges: %w", err)
+	}
+	return &dtos.PaginatedCompanyMergesResponse{
+		Merges:      merges,
+		TotalCount:  totalCount,
+		CurrentPage: page,
+		PageSize:    pageSize,
+		TotalPages:  int((totalCount + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+func (s *companyService) getBySlug(slug string) (*model.Company, error) {
+	company, err := s.companyRepo.GetBySlug(slug)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrCompanyNotFound, slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	return company, nil
+}
+
+// withStats adds the open job counts and sources to companies
+func (s *companyService) withStats(companies []model.Company) ([]dtos.CompanyResponse, error) {
+	responses := make([]dtos.CompanyResponse, len(companies))
+	if len(companies) == 0 {
+		return responses, nil
+	}
+
+	ids := make([]uint, len(companies))
+	for i, company := range companies {
+		ids[i] = company.ID
+	}
+	counts, err := s.companyRepo.CountOpenJobs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count company jobs: %w", err)
+	}
+	sources, err := s.companyRepo.GetSources(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company sources: %w", err)
+	}
+
+	for i, company := range companies {
+		responses[i] = dtos.CompanyResponse{
+			Company:  company,
+			OpenJobs: counts[company.ID],
+			Sources:  sources[company.ID],
+		}
+		if responses[i].Sources == nil {
+			responses[i].Sources = []string{}
+		}
+	}
+	return responses, nil
+}
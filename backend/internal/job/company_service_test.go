@@ -0,0 +1,265 @@
+package job
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/mocks"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCompanyService_GetCompany(t *testing.T) {
+	tests := []struct {
+		name          string
+		slug          string
+		setupMocks    func(*mocks.MockCompanyRepository)
+		expectedJobs  int64
+		expectedError error
+	}{
+		{
+			name: "company_found_with_stats",
+			slug: "acme",
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetBySlug", "acme").Return(&model.Company{ID: 1, Name: "Acme", Slug: "acme"}, nil)
+				companyRepo.On("CountOpenJobs", []uint{1}).Return(map[uint]int64{1: 3}, nil)
+				companyRepo.On("GetSources", []uint{1}).Return(map[uint][]string{1: {"Y Combinator"}}, nil)
+			},
+			expectedJobs: 3,
+		},
+		{
+			name: "company_not_found",
+			slug: "missing",
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetBySlug", "missing").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrCompanyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCompanyRepo := &mocks.MockCompanyRepository{}
+			tt.setupMocks(mockCompanyRepo)
+
+			company, err := NewCompanyService(mockCompanyRepo).GetCompany(tt.slug)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, company)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedJobs, company.OpenJobs)
+				assert.Equal(t, []string{"Y Combinator"}, company.Sources)
+			}
+			mockCompanyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCompanyService_ListCompanies(t *testing.T) {
+	mockCompanyRepo := &mocks.MockCompanyRepository{}
+	companies := []model.Company{{ID: 1, Name: "Acme"}, {ID: 2, Name: "Globex"}}
+	mockCompanyRepo.On("List", "ac", 20, 20).Return(companies, int64(25), nil)
+	mockCompanyRepo.On("CountOpenJobs", []uint{1, 2}).Return(map[uint]int64{1: 4}, nil)
+	mockCompanyRepo.On("GetSources", []uint{1, 2}).Return(map[uint][]string{}, nil)
+
+	result, err := NewCompanyService(mockCompanyRepo).ListCompanies("ac", 2, 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.TotalPages)
+	assert.Equal(t, int64(4), result.Companies[0].OpenJobs)
+	assert.Equal(t, int64(0), result.Companies[1].OpenJobs)
+	assert.Equal(t, []string{}, result.Companies[1].Sources)
+	mockCompanyRepo.AssertExpectations(t)
+}
+
+func TestJobService_ResolveCompany(t *testing.T) {
+	tests := []struct {
+		name       string
+		job        model.Job
+		setupMocks func(*mocks.MockCompanyRepository)
+		expectedID uint
+	}{
+		{
+			name: "matches_on_domain",
+			job:  model.Job{CompanyName: "Acme Robotics", CompanyWebsite: utils.String("https://www.acme.io/jobs")},
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetByDomain", "acme.io").Return(&model.Company{ID: 7, NormalizedName: "acme", Domain: utils.String("acme.io"), Website: utils.String("https://acme.io")}, nil)
+				companyRepo.On("GetByNormalizedName", "acme robotics").Return(nil, gorm.ErrRecordNotFound)
+				companyRepo.On("CreateAlias", mock.MatchedBy(func(alias *model.CompanyAlias) bool {
+					return alias.CompanyID == 7 && alias.Name == "Acme Robotics"
+				})).Return(&model.CompanyAlias{ID: 1}, nil)
+			},
+			expectedID: 7,
+		},
+		{
+			name: "domain_match_under_known_name_adds_no_alias",
+			job:  model.Job{CompanyName: "Acme Inc", CompanyWebsite: utils.String("acme.io")},
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetByDomain", "acme.io").Return(&model.Company{ID: 7, NormalizedName: "acme", Domain: utils.String("acme.io"), Website: utils.String("https://acme.io")}, nil)
+			},
+			expectedID: 7,
+		},
+		{
+			name: "matches_on_normalized_name_and_fills_profile",
+			job:  model.Job{CompanyName: "ACME, Inc.", CompanyLogoUrl: utils.String("https://acme.io/logo.png")},
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetByNormalizedName", "acme").Return(&model.Company{ID: 3}, nil)
+				companyRepo.On("Update", mock.MatchedBy(func(company *model.Company) bool {
+					return utils.StringValue(company.LogoURL) == "https://acme.io/logo.png"
+				})).Return(nil)
+			},
+			expectedID: 3,
+		},
+		{
+			name: "same_name_with_other_domain_is_a_new_company",
+			job:  model.Job{CompanyName: "Acme", CompanyWebsite: utils.String("acme.de")},
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetByDomain", "acme.de").Return(nil, gorm.ErrRecordNotFound)
+				companyRepo.On("GetByNormalizedName", "acme").Return(&model.Company{ID: 3, Domain: utils.String("acme.io")}, nil)
+				companyRepo.On("Create", mock.MatchedBy(func(company *model.Company) bool {
+					return company.Slug == "acme" && utils.StringValue(company.Domain) == "acme.de"
+				})).Return(&model.Company{ID: 9}, nil)
+			},
+			expectedID: 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCompanyRepo := &mocks.MockCompanyRepository{}
+			tt.setupMocks(mockCompanyRepo)
+			service := &jobService{companyRepo: mockCompanyRepo}
+
+			company, err := service.resolveCompany(&tt.job)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, company.ID)
+			mockCompanyRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("lookup_errors_are_returned", func(t *testing.T) {
+		mockCompanyRepo := &mocks.MockCompanyRepository{}
+		mockCompanyRepo.On("GetByNormalizedName", "acme").Return(nil, errors.New("db down"))
+		service := &jobService{companyRepo: mockCompanyRepo}
+
+		_, err := service.resolveCompany(&model.Job{CompanyName: "Acme"})
+		assert.Error(t, err)
+	})
+}
+
+func TestCompanyService_AddAlias(t *testing.T) {
+	tests := []struct {
+		name          string
+		alias         string
+		setupMocks    func(*mocks.MockCompanyRepository)
+		expectedError error
+	}{
+		{
+			name:  "alias_added",
+			alias: "Splash Robotics, Inc.",
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetBySlug", "splash").Return(&model.Company{ID: 1, Slug: "splash"}, nil)
+				companyRepo.On("GetByNormalizedName", "splash robotics").Return(nil, gorm.ErrRecordNotFound)
+				companyRepo.On("CreateAlias", mock.MatchedBy(func(alias *model.CompanyAlias) bool {
+					return alias.CompanyID == 1 && alias.NormalizedName == "splash robotics"
+				})).Return(&model.CompanyAlias{ID: 4, CompanyID: 1, Name: "Splash Robotics, Inc."}, nil)
+			},
+		},
+		{
+			name:  "alias_taken_by_a_company",
+			alias: "Acme",
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetBySlug", "splash").Return(&model.Company{ID: 1, Slug: "splash"}, nil)
+				companyRepo.On("GetByNormalizedName", "acme").Return(&model.Company{ID: 2, Slug: "acme"}, nil)
+			},
+			expectedError: ErrAliasTaken,
+		},
+		{
+			name:          "empty_alias",
+			alias:         " ... ",
+			setupMocks:    func(companyRepo *mocks.MockCompanyRepository) {},
+			expectedError: ErrInvalidCompanyRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCompanyRepo := &mocks.MockCompanyRepository{}
+			tt.setupMocks(mockCompanyRepo)
+
+			alias, err := NewCompanyService(mockCompanyRepo).AddAlias("splash", dtos.CompanyAliasRequest{Name: tt.alias})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, alias)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uint(4), alias.ID)
+			}
+			mockCompanyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCompanyService_MergeCompanies(t *testing.T) {
+	source := &model.Company{ID: 2, Slug: "splash-2"}
+	target := &model.Company{ID: 1, Slug: "splash"}
+
+	tests := []struct {
+		name          string
+		request       dtos.CompanyMergeRequest
+		setupMocks    func(*mocks.MockCompanyRepository)
+		expectedError error
+	}{
+		{
+			name:    "merged",
+			request: dtos.CompanyMergeRequest{Source: "splash-2", Target: "splash", Reason: utils.String("duplicate")},
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetBySlug", "splash-2").Return(source, nil)
+				companyRepo.On("GetBySlug", "splash").Return(target, nil)
+				companyRepo.On("Merge", source, target, utils.String("duplicate"), utils.String("admin@workova.dev")).
+					Return(&model.CompanyMerge{ID: 1, SourceCompanyID: 2, TargetCompanyID: 1, JobsMoved: 5}, nil)
+			},
+		},
+		{
+			name:          "merge_into_itself",
+			request:       dtos.CompanyMergeRequest{Source: "splash", Target: "splash"},
+			setupMocks:    func(companyRepo *mocks.MockCompanyRepository) {},
+			expectedError: ErrInvalidCompanyRequest,
+		},
+		{
+			name:    "unknown_target",
+			request: dtos.CompanyMergeRequest{Source: "splash-2", Target: "missing"},
+			setupMocks: func(companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetBySlug", "splash-2").Return(source, nil)
+				companyRepo.On("GetBySlug", "missing").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrCompanyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCompanyRepo := &mocks.MockCompanyRepository{}
+			tt.setupMocks(mockCompanyRepo)
+
+			merge, err := NewCompanyService(mockCompanyRepo).MergeCompanies(tt.request, utils.String("admin@workova.dev"))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, merge)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(5), merge.JobsMoved)
+			}
+			mockCompanyRepo.AssertExpectations(t)
+		})
+	}
+}
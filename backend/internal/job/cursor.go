@@ -0,0 +1,113 @@
+package job
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+)
+
+// searchCursor is the content of an opaque pagination cursor. The sort is kept so a cursor
+// can't be replayed against a different order.
+type searchCursor struct {
+	SortBy    string  `json:"s"`
+	SortOrder string  `json:"o"`
+	Value     *string `json:"v,omitempty"`
+	ID        uint    `json:"id"`
+	Backward  bool    `json:"b,omitempty"`
+}
+
+// effectiveSort is the sort the repository applies for params, "created_at desc" by default
+func effectiveSort(params *dtos.JobSearchParams) (string, string) {
+	sortBy, sortOrder := params.SortBy, "desc"
+	if params.SortOrder == "asc" {
+		sortOrder = "asc"
+	}
+	if sortBy == "" {
+		return "created_at", "desc"
+	}
+	return sortBy, sortOrder
+}
+
+// encodeCursor builds the cursor of the page after job, or before it when backward
+func encodeCursor(params *dtos.JobSearchParams, job *model.Job, backward bool) string {
+	sortBy, sortOrder := effectiveSort(params)
+	cursor := searchCursor{SortBy: sortBy, SortOrder: sortOrder, ID: job.ID, Backward: backward}
+
+	switch sortBy {
+	case "created_at":
+		cursor.Value = formatCursorTime(&job.CreatedAt)
+	case "updated_at":
+		cursor.Value = formatCursorTime(&job.UpdatedAt)
+	case "posted_date":
+		cursor.Value = formatCursorTime(job.PostedDate)
+	case "salary_max":
+		cursor.Value = formatCursorInt(job.SalaryMax)
+	case "salary_min":
+		cursor.Value = formatCursorInt(job.SalaryMin)
+	case "company_name":
+		cursor.Value = &job.CompanyName
+	case "title":
+		cursor.Value = &job.Title
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor reads params.Cursor into params.Keyset. Errors wrap ErrInvalidSearch.
+func decodeCursor(params *dtos.JobSearchParams) error {
+	data, err := base64.RawURLEncoding.DecodeString(params.Cursor)
+	var cursor searchCursor
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	if err != nil || cursor.ID == 0 {
+		return fmt.Errorf("%w: malformed cursor", ErrInvalidSearch)
+	}
+
+	sortBy, sortOrder := effectiveSort(params)
+	if sortBy == "distance" {
+		return fmt.Errorf("%w: cursors can't be used with sort_by=distance", ErrInvalidSearch)
+	}
+	if cursor.SortBy != sortBy || cursor.SortOrder != sortOrder {
+		return fmt.Errorf("%w: cursor was issued for sort_by=%s&sort_order=%s", ErrInvalidSearch, cursor.SortBy, cursor.SortOrder)
+	}
+
+	keyset := &dtos.JobKeyset{ID: cursor.ID, Backward: cursor.Backward}
+	if cursor.Value != nil {
+		switch sortBy {
+		case "created_at", "updated_at", "posted_date":
+			keyset.Value, err = time.Parse(time.RFC3339Nano, *cursor.Value)
+		case "salary_max", "salary_min":
+			keyset.Value, err = strconv.Atoi(*cursor.Value)
+		default:
+			keyset.Value = *cursor.Value
+		}
+		if err != nil {
+			return fmt.Errorf("%w: malformed cursor", ErrInvalidSearch)
+		}
+	}
+	params.Keyset = keyset
+	return nil
+}
+
+func formatCursorTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	value := t.Format(time.RFC3339Nano)
+	return &value
+}
+
+func formatCursorInt(i *int) *string {
+	if i == nil {
+		return nil
+	}
+	value := strconv.Itoa(*i)
+	return &value
+}
@@ -0,0 +1,82 @@
+package job
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	created := time.Date(2025, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	job := &model.Job{ID: 7, Title: "Go Engineer", CompanyName: "Acme", CreatedAt: created, UpdatedAt: created.Add(time.Hour),
+		PostedDate: &created, SalaryMax: utils.Int(120000)}
+
+	tests := []struct {
+		sortBy, sortOrder string
+		want              interface{}
+	}{
+		{"", "", created},
+		{"updated_at", "asc", created.Add(time.Hour)},
+		{"posted_date", "desc", created},
+		{"salary_max", "desc", 120000},
+		{"salary_min", "asc", nil},
+		{"company_name", "asc", "Acme"},
+		{"title", "desc", "Go Engineer"},
+	}
+
+	for _, tt := range tests {
+		for _, backward := range []bool{false, true} {
+			params := &dtos.JobSearchParams{SortBy: tt.sortBy, SortOrder: tt.sortOrder}
+			params.Cursor = encodeCursor(params, job, backward)
+
+			require.NoError(t, decodeCursor(params), tt.sortBy)
+			assert.Equal(t, &dtos.JobKeyset{ID: 7, Value: tt.want, Backward: backward}, params.Keyset, tt.sortBy)
+		}
+	}
+}
+
+func TestCursor_Rejected(t *testing.T) {
+	encode := func(cursor searchCursor) string {
+		data, err := json.Marshal(cursor)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	value := func(s string) *string { return &s }
+	byCreated := &dtos.JobSearchParams{}
+	bySalary := &dtos.JobSearchParams{SortBy: "salary_max", SortOrder: "asc"}
+	job := &model.Job{ID: 3, CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), SalaryMax: utils.Int(90000)}
+
+	tests := []struct {
+		name   string
+		cursor string
+		params *dtos.JobSearchParams
+	}{
+		{"not base64", "not a cursor!", byCreated},
+		{"not JSON", base64.RawURLEncoding.EncodeToString([]byte("created_at:3")), byCreated},
+		{"missing id", encode(searchCursor{SortBy: "created_at", SortOrder: "desc", Value: value("2025-03-01T00:00:00Z")}), byCreated},
+		{"tampered time", encode(searchCursor{SortBy: "created_at", SortOrder: "desc", Value: value("yesterday"), ID: 3}), byCreated},
+		{"tampered number", encode(searchCursor{SortBy: "salary_max", SortOrder: "asc", Value: value("1 OR 1=1"), ID: 3}), bySalary},
+		{"tampered sort", encode(searchCursor{SortBy: "salary_max; DROP TABLE jobs", SortOrder: "asc", ID: 3}), bySalary},
+		{"other sort field", encodeCursor(bySalary, job, false), byCreated},
+		{"other sort order", encodeCursor(bySalary, job, false), &dtos.JobSearchParams{SortBy: "salary_max", SortOrder: "desc"}},
+		{"created cursor on a salary sort", encodeCursor(byCreated, job, false), bySalary},
+		{"distance sort", encodeCursor(byCreated, job, false), &dtos.JobSearchParams{SortBy: "distance", SortOrder: "asc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := *tt.params
+			params.Cursor = tt.cursor
+
+			assert.ErrorIs(t, decodeCursor(&params), ErrInvalidSearch)
+			assert.Nil(t, params.Keyset)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package job
+
+import (
+	"strings"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/pkg/highlight"
+	"github.com/bhati00/workova/backend/pkg/utils"
+)
+
+// Keys of model.Job.Highlights
+const (
+	HighlightTitle       = "title"
+	HighlightSummary     = "summary"
+	HighlightDescription = "description"
+)
+
+// highlightJobs sets the highlights of jobs found by a search: the title and summary with
+// the words matching the text search marked, and excerpts of the description around them.
+// Jobs matched by other filters only get no highlights.
+func highlightJobs(jobs []model.Job, params *dtos.JobSearchParams) {
+	matcher := highlight.NewMatcher(searchText(params), params.Language)
+	if matcher.Empty() {
+		return
+	}
+
+	opts := highlight.DefaultOptions()
+	if params.Highlight.PreTag != "" {
+		opts.PreTag, opts.PostTag = params.Highlight.PreTag, params.Highlight.PostTag
+		opts.EscapeHTML = strings.HasPrefix(opts.PreTag, "<")
+	}
+	if params.Highlight.FragmentSize > 0 {
+		opts.FragmentSize = params.Highlight.FragmentSize
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		lang := utils.StringValue(job.Language)
+		highlights := make(map[string][]string)
+		if title := matcher.Highlight(job.Title, lang, opts); title != "" {
+			highlights[HighlightTitle] = []string{title}
+		}
+		if summary := matcher.Highlight(utils.StringValue(job.Summary), lang, opts); summary != "" {
+			highlights[HighlightSummary] = []string{summary}
+		}
+		if fragments := matcher.Fragments(utils.StringValue(job.DescriptionText), lang, opts); len(fragments) > 0 {
+			highlights[HighlightDescription] = fragments
+		}
+		if len(highlights) > 0 {
+			job.Highlights = highlights
+		}
+	}
+}
+
+// searchText is the free text of a search: query and the text conditions of the filter
+// tree, except negated ones
+func searchText(params *dtos.JobSearchParams) string {
+	texts := []string{params.Query}
+	var collect func(filter *dtos.SearchFilter)
+	collect = func(filter *dtos.SearchFilter) {
+		if filter == nil {
+			return
+		}
+		for i := range filter.And {
+			collect(&filter.And[i])
+		}
+		for i := range filter.Or {
+			collect(&filter.Or[i])
+		}
+		if filter.Field == "text" {
+			switch value := filter.Value.(type) {
+			case string:
+				texts = append(texts, value)
+			case []interface{}:
+				for _, v := range value {
+					if text, ok := v.(string); ok {
+						texts = append(texts, text)
+					}
+				}
+			}
+		}
+	}
+	collect(params.Filter)
+	return strings.Join(texts, " ")
+}
@@ -0,0 +1,91 @@
+package job
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bhati00/workova/backend/constant"
+	"github.com/bhati00/workova/backend/dtos"
+)
+
+// facetLimits caps the values returned by open-ended facets
+var facetLimits = map[string]int{
+	dtos.FacetSkills:  20,
+	dtos.FacetCountry: 50,
+}
+
+// facetFilters clear the filter a facet counts for, so the other values of a facet
+// keep their counts while one is selected
+var facetFilters = map[string]func(*dtos.JobSearchParams){
+	dtos.FacetWorkMode:        func(p *dtos.JobSearchParams) { p.WorkMode = nil },
+	dtos.FacetJobType:         func(p *dtos.JobSearchParams) { p.JobType = nil },
+	dtos.FacetExperienceLevel: func(p *dtos.JobSearchParams) { p.ExperienceLevel = nil },
+	dtos.FacetSource:          func(p *dtos.JobSearchParams) { p.Source = nil },
+	dtos.FacetCountry:         func(p *dtos.JobSearchParams) { p.Location = nil },
+	dtos.FacetSkills:          func(p *dtos.JobSearchParams) { p.Skills = nil },
+	dtos.FacetCategory:        func(p *dtos.JobSearchParams) { p.Category = nil },
+	dtos.FacetSalary:          func(p *dtos.JobSearchParams) { p.MinSalary, p.MaxSalary = nil, nil },
+	dtos.FacetPostedDate:      func(p *dtos.JobSearchParams) { p.PostedAfter, p.PostedBefore = nil, nil },
+}
+
+// enumLabels are the display names of enum facet values
+var enumLabels = map[string]string{
+	"remote": "Remote", "onsite": "On-site", "hybrid": "Hybrid",
+	"full_time": "Full-time", "part_time": "Part-time", "contract": "Contract", "internship": "Internship", "temporary": "Temporary",
+	"entry": "Entry level", "mid": "Mid level", "senior": "Senior", "lead": "Lead", "executive": "Executive",
+}
+
+// validateFacets checks that every requested facet exists
+func validateFacets(facets []string) error {
+	for _, facet := range facets {
+		if _, ok := facetFilters[facet]; !ok {
+			return fmt.Errorf("%w: unknown facet %q", ErrInvalidSearch, facet)
+		}
+	}
+	return nil
+}
+
+// searchFacets counts the jobs matching params for each requested facet
+func (s *jobService) searchFacets(params *dtos.JobSearchParams) (map[string][]dtos.FacetValue, error) {
+	facets := make(map[string][]dtos.FacetValue, len(params.Facets))
+	for _, facet := range params.Facets {
+		if _, done := facets[facet]; done {
+			continue
+		}
+		facetParams := *params
+		facetFilters[facet](&facetParams)
+
+		values, err := s.jobRepo.CountFacet(&facetParams, facet, facetLimits[facet])
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s facet: %w", facet, err)
+		}
+		for i := range values {
+			labelFacetValue(facet, &values[i])
+		}
+		if values == nil {
+			values = []dtos.FacetValue{}
+		}
+		facets[facet] = values
+	}
+	return facets, nil
+}
+
+// labelFacetValue turns enum numbers into their API names and fills missing labels
+func labelFacetValue(facet string, value *dtos.FacetValue) {
+	if number, err := strconv.Atoi(value.Value); err == nil {
+		switch facet {
+		case dtos.FacetWorkMode:
+			value.Value = constant.WorkMode(number).String()
+		case dtos.FacetJobType:
+			value.Value = constant.JobType(number).String()
+		case dtos.FacetExperienceLevel:
+			value.Value = constant.ExperienceLevel(number).String()
+		}
+	}
+	if value.Label == "" {
+		value.Label = enumLabels[value.Value]
+	}
+	if value.Label == "" {
+		value.Label = value.Value
+	}
+}
@@ -2,13 +2,28 @@
 package job
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/internal/user"
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
 	"github.com/gin-gonic/gin"
 )
 
+// Description formats accepted by ?description_format=
+const (
+	DescriptionFormatHTML     = "html"
+	DescriptionFormatMarkdown = "markdown"
+	DescriptionFormatText     = "text"
+)
+
 // JobHandler handles HTTP requests for job operations
 type JobHandler struct {
 	jobService JobService
@@ -27,9 +42,13 @@ func NewJobHandler(jobService JobService) *JobHandler {
 // @Tags Jobs
 // @Accept json
 // @Produce json
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Param job body dtos.JobRequest true "Job object"
 // @Success 201 {object} dtos.APIResponse
 // @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
 // @Failure 500 {object} dtos.APIResponse
 // @Router /jobs [post]
 func (h *JobHandler) CreateJob(c *gin.Context) {
@@ -64,6 +83,7 @@ func (h *JobHandler) CreateJob(c *gin.Context) {
 // @Tags Jobs
 // @Produce json
 // @Param id path int true "Job ID"
+// @Param description_format query string false "Format of the description: html (default), markdown or text"
 // @Success 200 {object} dtos.APIResponse
 // @Failure 400 {object} dtos.APIResponse
 // @Failure 404 {object} dtos.APIResponse
@@ -79,6 +99,15 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 		return
 	}
 
+	format, err := parseDescriptionFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
 	job, err := h.jobService.GetJobByID(uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, dtos.APIResponse{
@@ -87,6 +116,7 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 		})
 		return
 	}
+	applyDescriptionFormat(job, format)
 
 	c.JSON(http.StatusOK, dtos.APIResponse{
 		Success: true,
@@ -94,14 +124,84 @@ func (h *JobHandler) GetJob(c *gin.Context) {
 	})
 }
 
+// GetSimilarJobs godoc
+// @Summary Get similar jobs
+// @Description Returns open jobs related to a job, most similar first. Similarity combines the TF-IDF vectors of title and description with shared skills, categories, location and seniority. Reposts of the job by its company are left out.
+// @Tags Jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Param limit query int false "Maximum jobs, 1-50 (default 10)"
+// @Param description_format query string false "Format of the description: html (default), markdown or text"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /jobs/{id}/similar [get]
+func (h *JobHandler) GetSimilarJobs(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid job ID",
+		})
+		return
+	}
+
+	p := &searchParamParser{query: c.Request.URL.Query()}
+	limit := 10
+	if value := p.integer("limit", 1, 50); value != nil {
+		limit = *value
+	}
+	rawFormat := c.Query("description_format")
+	format, err := validateDescriptionFormat(rawFormat)
+	if err != nil {
+		p.fail("description_format", rawFormat, "%s", err.Error())
+	}
+	if len(p.errors) > 0 {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid parameters",
+			Errors:  p.errors,
+		})
+		return
+	}
+
+	jobs, err := h.jobService.GetSimilarJobs(uint(id), limit)
+	if errors.Is(err, ErrJobNotFound) {
+		c.JSON(http.StatusNotFound, dtos.APIResponse{
+			Success: false,
+			Error:   "Job not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get similar jobs: " + err.Error(),
+		})
+		return
+	}
+	for i := range jobs {
+		applyDescriptionFormat(&jobs[i].Job, format)
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    jobs,
+	})
+}
+
 // DeleteJob godoc
 // @Summary Delete a job
 // @Description Deletes a job by ID
 // @Tags Jobs
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Job ID"
 // @Success 200 {object} dtos.APIResponse
 // @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
 // @Failure 500 {object} dtos.APIResponse
 // @Router /jobs/{id} [delete]
 func (h *JobHandler) DeleteJob(c *gin.Context) {
@@ -134,9 +234,12 @@ func (h *JobHandler) DeleteJob(c *gin.Context) {
 // @Description Marks a job as inactive
 // @Tags Jobs
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Job ID"
 // @Success 200 {object} dtos.APIResponse
 // @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
 // @Failure 500 {object} dtos.APIResponse
 // @Router /jobs/{id}/deactivate [patch]
 func (h *JobHandler) DeactivateJob(c *gin.Context) {
@@ -170,9 +273,12 @@ func (h *JobHandler) DeactivateJob(c *gin.Context) {
 // @Tags Jobs
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param request body dtos.BatchDeleteRequest true "Batch delete request"
 // @Success 200 {object} dtos.APIResponse
 // @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
 // @Failure 500 {object} dtos.APIResponse
 // @Router /jobs/batch [delete]
 func (h *JobHandler) BatchDeleteJobs(c *gin.Context) {
@@ -228,19 +334,37 @@ func (h *JobHandler) BatchDeleteJobs(c *gin.Context) {
 
 // GetAllJobs godoc
 // @Summary Get all jobs
-// @Description Returns paginated list of jobs
+// @Description Returns paginated list of jobs, newest first. Follow next_cursor/prev_cursor with the cursor parameter for stable paging while jobs are added.
 // @Tags Jobs
 // @Produce json
 // @Param page query int false "Page number"
 // @Param page_size query int false "Page size"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, replaces page"
+// @Param description_format query string false "Format of the description: html (default), markdown or text"
 // @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
 // @Failure 500 {object} dtos.APIResponse
 // @Router /jobs [get]
 func (h *JobHandler) GetAllJobs(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	format, err := parseDescriptionFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
 
-	result, err := h.jobService.GetAllJobs(page, pageSize)
+	result, err := h.jobService.GetAllJobs(page, pageSize, c.Query("cursor"))
+	if errors.Is(err, ErrInvalidSearch) {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
 			Success: false,
@@ -248,6 +372,9 @@ func (h *JobHandler) GetAllJobs(c *gin.Context) {
 		})
 		return
 	}
+	for i := range result.Jobs {
+		applyDescriptionFormat(&result.Jobs[i], format)
+	}
 
 	c.JSON(http.StatusOK, dtos.APIResponse{
 		Success: true,
@@ -257,54 +384,164 @@ func (h *JobHandler) GetAllJobs(c *gin.Context) {
 
 // SearchJobs godoc
 // @Summary Search jobs
-// @Description Searches jobs with filters like query, work mode, skills, salary, etc.
+// @Description Searches jobs with filters like query, work mode, skills, salary, etc. List filters take comma-separated or repeated values, enum filters take names or numbers. Invalid parameters are all reported in errors.
 // @Tags Jobs
 // @Produce json
-// @Param query query string false "Search keyword"
-// @Param currency query string false "Currency code"
-// @Param work_mode query string false "Comma-separated work modes"
-// @Param work_type query string false "Comma-separated work types"
-// @Param skills query string false "Comma-separated skills"
+// @Param query query string false "Search keywords or the compact filter syntax, e.g. skills:go AND (remote:true OR country:DE) -company:acme"
+// @Param skills query string false "Comma-separated skill names, e.g. go,postgresql"
+// @Param company query string false "Comma-separated company slugs"
+// @Param category query string false "Comma-separated category slugs or names, e.g. engineering or backend. Includes subcategories"
 // @Param source query string false "Comma-separated sources"
+// @Param location query string false "Comma-separated cities, regions, countries or country ISO codes"
+// @Param work_mode query string false "Comma-separated work modes: remote, onsite, hybrid or 1-3"
+// @Param job_type query string false "Comma-separated job types: full_time, part_time, contract, internship, temporary or 1-5"
+// @Param work_type query string false "Deprecated alias of job_type"
+// @Param experience_level query string false "Comma-separated experience levels: entry, mid, senior, lead, executive or 1-5"
 // @Param min_salary query int false "Minimum salary"
 // @Param max_salary query int false "Maximum salary"
-// @Param is_active query bool false "Filter active jobs"
+// @Param currency query string false "Three-letter currency code, e.g. USD"
+// @Param salary_period query string false "Comma-separated salary periods"
+// @Param is_remote query bool false "Filter by remote flag"
+// @Param visa_sponsorship query bool false "Only jobs that do (true) or don't (false) sponsor visas"
+// @Param health_insurance query bool false "Filter by health insurance"
+// @Param paid_time_off query bool false "Filter by paid time off"
+// @Param flexible_schedule query bool false "Filter by flexible schedule"
+// @Param equity_offered query bool false "Filter by equity offered"
+// @Param security_clearance query bool false "Only jobs that do (true) or don't (false) require a security clearance"
+// @Param is_urgent query bool false "Filter by urgent hiring"
+// @Param company_size query string false "Comma-separated company sizes"
+// @Param industry query string false "Comma-separated industries"
+// @Param department query string false "Comma-separated departments"
+// @Param education_level query string false "Comma-separated education levels"
+// @Param travel_required query string false "Comma-separated travel requirements"
+// @Param contract_duration query int false "Contract duration in months"
+// @Param posted_after query string false "Posted on or after: 2024-01-31, an RFC 3339 time or an age such as 7d or 12h"
+// @Param posted_before query string false "Posted on or before: 2024-01-31, an RFC 3339 time or an age such as 7d or 12h"
+// @Param lat query number false "Latitude of the search centre"
+// @Param lng query number false "Longitude of the search centre"
+// @Param radius_km query number false "Search radius in km around lat/lng or near (default 50)"
+// @Param near query string false "City to search around, e.g. Berlin or Berlin, DE"
+// @Param tz query string false "IANA timezone of the searcher, e.g. Europe/Berlin"
+// @Param tz_overlap_hours query int false "Minimum shared working hours with the job's timezone or remote working hours (default 4)"
+// @Param remote_eligible_in query string false "Only remote jobs open to people in this country, ISO code or name, e.g. DE"
+// @Param language query string false "Comma-separated ISO 639-1 codes of the posting language, e.g. de,en. Also picks the stemmer for query"
 // @Param page query int false "Page number"
-// @Param page_size query int false "Page size"
+// @Param page_size query int false "Page size, at most 100"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, replaces page. Not available with sort_by=distance"
+// @Param sort_by query string false "created_at, posted_date, updated_at, salary_max, salary_min, company_name, title or distance"
+// @Param sort_order query string false "asc or desc (default)"
+// @Param facets query string false "Comma-separated facets to count: work_mode, job_type, experience_level, source, country, skills, category, salary, posted_date"
+// @Param description_format query string false "Format of the description: html (default), markdown or text"
+// @Param highlight query bool false "Add highlights to each job: title and summary with the words matching query marked, and excerpts of the description around them"
+// @Param highlight_pre_tag query string false "Inserted before every matching word (default <mark>). With an HTML tag the text around it is HTML-escaped"
+// @Param highlight_post_tag query string false "Inserted after every matching word (default </mark>)"
+// @Param highlight_fragment_size query int false "Approximate length of the description excerpts, 40-1000 (default 150)"
+// @Param auto_correct query bool false "When query finds nothing, return the results of its spelling correction instead, with auto_corrected set. Queries with few or no results get did_you_mean either way"
 // @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
 // @Failure 500 {object} dtos.APIResponse
 // @Router /jobs/search [get]
 func (h *JobHandler) SearchJobs(c *gin.Context) {
-	// Parse query parameters
-	params := &dtos.JobSearchParams{
-		Query:    c.Query("query"),
-		Currency: c.Query("currency"),
-		Offset:   0,
-		Limit:    20,
+	params, fieldErrors := ParseSearchParams(c.Request.URL.Query())
+	h.search(c, params, fieldErrors, c.Query("description_format"))
+}
+
+// SearchJobsByFilter godoc
+// @Summary Search jobs with a filter document
+// @Description Searches jobs with a JSON filter tree of nested and/or/not groups. Conditions take a field, an operator (eq, in, gt, gte, lt, lte, between) and a value, e.g. {"filter": {"and": [{"field": "skills", "value": ["go", "rust"]}, {"field": "salary", "op": "gte", "value": 100000}, {"not": {"field": "company", "value": "acme"}}]}}. query takes free text or the compact syntax skills:go AND (remote:true OR country:DE) -company:acme. Query string filters of GET /jobs/search apply as well.
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param request body dtos.JobSearchRequest true "Search request"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /jobs/search [post]
+func (h *JobHandler) SearchJobsByFilter(c *gin.Context) {
+	var request dtos.JobSearchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
 	}
 
-	// Parse page and page_size
-	if page, err := strconv.Atoi(c.DefaultQuery("page", "1")); err == nil && page > 0 {
-		params.Offset = (page - 1) * params.Limit
+	// The body's options are validated like their query string counterparts
+	values := c.Request.URL.Query()
+	setQueryValue := func(name, value string) {
+		if value != "" {
+			values.Set(name, value)
+		}
+	}
+	setQueryValue("query", request.Query)
+	if request.Page != 0 {
+		values.Set("page", strconv.Itoa(request.Page))
+	}
+	if request.PageSize != 0 {
+		values.Set("page_size", strconv.Itoa(request.PageSize))
+	}
+	setQueryValue("sort_by", request.SortBy)
+	setQueryValue("sort_order", request.SortOrder)
+	setQueryValue("cursor", request.Cursor)
+	if len(request.Facets) > 0 {
+		values["facets"] = request.Facets
 	}
-	if pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "20")); err == nil && pageSize > 0 && pageSize <= 100 {
-		params.Limit = pageSize
-		params.Offset = (params.Offset / 20) * pageSize // Adjust offset for new page size
+	if request.Highlight != nil {
+		values.Set("highlight", strconv.FormatBool(*request.Highlight))
+	}
+	setQueryValue("highlight_pre_tag", request.HighlightPreTag)
+	setQueryValue("highlight_post_tag", request.HighlightPostTag)
+	if request.FragmentSize != 0 {
+		values.Set("highlight_fragment_size", strconv.Itoa(request.FragmentSize))
+	}
+	if request.AutoCorrect != nil {
+		values.Set("auto_correct", strconv.FormatBool(*request.AutoCorrect))
 	}
 
-	// Parse salary range
-	if minSalaryStr := c.Query("min_salary"); minSalaryStr != "" {
-		if minSalary, err := strconv.Atoi(minSalaryStr); err == nil {
-			params.MinSalary = &minSalary
+	params, fieldErrors := ParseSearchParams(values)
+	if request.Filter != nil {
+		if params.Filter != nil {
+			params.Filter = &dtos.SearchFilter{And: []dtos.SearchFilter{*params.Filter, *request.Filter}}
+		} else {
+			params.Filter = request.Filter
 		}
 	}
-	if maxSalaryStr := c.Query("max_salary"); maxSalaryStr != "" {
-		if maxSalary, err := strconv.Atoi(maxSalaryStr); err == nil {
-			params.MaxSalary = &maxSalary
-		}
+
+	format := request.DescriptionFormat
+	if format == "" {
+		format = c.Query("description_format")
+	}
+	h.search(c, params, fieldErrors, format)
+}
+
+// search runs a parsed job search and writes the response
+func (h *JobHandler) search(c *gin.Context, params *dtos.JobSearchParams, fieldErrors []dtos.FieldError, rawFormat string) {
+	format, err := validateDescriptionFormat(rawFormat)
+	if err != nil {
+		fieldErrors = append(fieldErrors, dtos.FieldError{
+			Field:   "description_format",
+			Value:   rawFormat,
+			Message: err.Error(),
+		})
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid search parameters",
+			Errors:  fieldErrors,
+		})
+		return
 	}
 
 	result, err := h.jobService.SearchJobs(params)
+	if errors.Is(err, ErrInvalidSearch) {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
 			Success: false,
@@ -313,12 +550,139 @@ func (h *JobHandler) SearchJobs(c *gin.Context) {
 		return
 	}
 
+	for i := range result.Jobs {
+		applyDescriptionFormat(&result.Jobs[i], format)
+	}
+
 	c.JSON(http.StatusOK, dtos.APIResponse{
 		Success: true,
 		Data:    result,
 	})
 }
 
+// exportPageSize is how many jobs an export fetches per query
+const exportPageSize = 100
+
+// exportIgnoredParams are the search parameters that don't apply to exports
+var exportIgnoredParams = []string{"page", "page_size", "cursor", "sort_by", "sort_order", "facets", "highlight", "auto_correct"}
+
+// ExportJobs godoc
+// @Summary Export jobs
+// @Description Streams every job matching the filters of GET /jobs/search as newline-delimited JSON, one job per line, newest first. Paging, sorting, facets, highlights and auto-correction don't apply. Takes the export scope with an API key.
+// @Tags Jobs
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Param query query string false "Search query"
+// @Param description_format query string false "Format of the description: html (default), markdown or text"
+// @Success 200 {string} string "One job per line"
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /jobs/export [get]
+func (h *JobHandler) ExportJobs(c *gin.Context) {
+	values := c.Request.URL.Query()
+	for _, name := range exportIgnoredParams {
+		values.Del(name)
+	}
+	params, fieldErrors := ParseSearchParams(values)
+	rawFormat := c.Query("description_format")
+	format, err := validateDescriptionFormat(rawFormat)
+	if err != nil {
+		fieldErrors = append(fieldErrors, dtos.FieldError{
+			Field:   "description_format",
+			Value:   rawFormat,
+			Message: err.Error(),
+		})
+	}
+	if len(fieldErrors) > 0 {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid search parameters",
+			Errors:  fieldErrors,
+		})
+		return
+	}
+	params.Limit = exportPageSize
+	// Pages are chained by cursor, so the sort is fixed: geo searches would otherwise sort
+	// by distance, which has no cursor, and stop after the first page
+	params.SortBy, params.SortOrder = "created_at", "desc"
+
+	// The first page is fetched before the headers go out, so its errors still get a status
+	result, err := h.jobService.SearchJobs(params)
+	if errors.Is(err, ErrInvalidSearch) {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Export failed: " + err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", `attachment; filename="jobs.ndjson"`)
+	c.Status(http.StatusOK)
+	encoder := json.NewEncoder(c.Writer)
+	exported := 0
+	for {
+		for i := range result.Jobs {
+			applyDescriptionFormat(&result.Jobs[i], format)
+			if err := encoder.Encode(&result.Jobs[i]); err != nil {
+				// The client went away
+				return
+			}
+		}
+		exported += len(result.Jobs)
+		c.Writer.Flush()
+		if result.NextCursor == "" {
+			return
+		}
+
+		params.Cursor, params.Keyset = result.NextCursor, nil
+		if result, err = h.jobService.SearchJobs(params); err != nil {
+			// The status is already out, the client only sees the export end early
+			log.Printf("Export failed after %d jobs: %v", exported, err)
+			return
+		}
+	}
+}
+
+// parseDescriptionFormat reads description_format, html when omitted
+func parseDescriptionFormat(c *gin.Context) (string, error) {
+	return validateDescriptionFormat(c.Query("description_format"))
+}
+
+// validateDescriptionFormat checks a description format, html when empty
+func validateDescriptionFormat(format string) (string, error) {
+	format = strings.ToLower(format)
+	if format == "" {
+		return DescriptionFormatHTML, nil
+	}
+	switch format {
+	case DescriptionFormatHTML, DescriptionFormatMarkdown, DescriptionFormatText:
+		return format, nil
+	}
+	return "", fmt.Errorf("description_format must be one of %s, %s or %s",
+		DescriptionFormatHTML, DescriptionFormatMarkdown, DescriptionFormatText)
+}
+
+// applyDescriptionFormat swaps the sanitized HTML description for the requested rendition
+func applyDescriptionFormat(job *model.Job, format string) {
+	switch format {
+	case DescriptionFormatMarkdown:
+		job.Description = job.DescriptionMarkdown
+	case DescriptionFormatText:
+		job.Description = job.DescriptionText
+	}
+}
+
 // GetJobStats godoc
 // @Summary Get job statistics
 // @Description Returns statistics about jobs
@@ -343,23 +707,54 @@ func (h *JobHandler) GetJobStats(c *gin.Context) {
 	})
 }
 
+// GetCategories godoc
+// @Summary Get the category taxonomy
+// @Description Returns the job categories as a tree, e.g. Engineering > Backend Engineering. Slugs can be used in the category search filter
+// @Tags Jobs
+// @Produce json
+// @Success 200 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /categories [get]
+func (h *JobHandler) GetCategories(c *gin.Context) {
+	categories, err := h.jobService.GetCategories()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get categories: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    categories,
+	})
+}
+
 // RegisterJobRoutes registers all job-related routes
 func (h *JobHandler) RegisterJobRoutes(router *gin.RouterGroup) {
+	// Reads are public, API keys need the jobs:read scope for them
+	read := user.RequireScope(usermodel.ScopeJobsRead)
+
 	jobs := router.Group("/jobs")
 	{
 		// Single job operations
-		jobs.POST("", h.CreateJob)
-		jobs.GET("/:id", h.GetJob)
-		jobs.DELETE("/:id", h.DeleteJob)
-		jobs.PATCH("/:id/deactivate", h.DeactivateJob)
+		jobs.POST("", user.RequirePermission(user.PermissionCreateJobs), h.CreateJob)
+		jobs.GET("/:id", read, h.GetJob)
+		jobs.GET("/:id/similar", read, h.GetSimilarJobs)
+		jobs.DELETE("/:id", user.RequirePermission(user.PermissionModerateJobs), h.DeleteJob)
+		jobs.PATCH("/:id/deactivate", user.RequirePermission(user.PermissionModerateJobs), h.DeactivateJob)
 
 		// Batch operations
-		jobs.DELETE("/batch", h.BatchDeleteJobs)
+		jobs.DELETE("/batch", user.RequirePermission(user.PermissionBatchDeleteJobs), h.BatchDeleteJobs)
+		jobs.GET("/export", user.RequirePermission(user.PermissionExportJobs), h.ExportJobs)
 
 		// Query operations
-		jobs.GET("", h.GetAllJobs)
-		jobs.GET("/search", h.SearchJobs)
-		jobs.GET("/stats", h.GetJobStats)
+		jobs.GET("", read, h.GetAllJobs)
+		jobs.GET("/search", read, h.SearchJobs)
+		jobs.POST("/search", read, h.SearchJobsByFilter)
+		jobs.GET("/stats", read, h.GetJobStats)
 
 	}
+	router.GET("/categories", read, h.GetCategories)
 }
@@ -0,0 +1,466 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/constant"
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/mocks"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/internal/user"
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/bhati00/workova/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSearchRouter(service *mocks.MockJobService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(user.Authenticate(roleUserService{}))
+	NewJobHandler(service).RegisterJobRoutes(router.Group("/api"))
+	return router
+}
+
+func TestJobHandler_SearchJobsParams(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	boolPtr := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name  string
+		query string
+		check func(t *testing.T, params *dtos.JobSearchParams)
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, 0, params.Offset)
+				assert.Equal(t, 20, params.Limit)
+				assert.Nil(t, params.WorkMode)
+				assert.Nil(t, params.MinSalary)
+			},
+		},
+		{
+			name:  "highlight",
+			query: "query=go&highlight=true&highlight_pre_tag=**&highlight_post_tag=**&highlight_fragment_size=80",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, &dtos.HighlightOptions{PreTag: "**", PostTag: "**", FragmentSize: 80}, params.Highlight)
+			},
+		},
+		{
+			name:  "auto_correct",
+			query: "query=kubernets&auto_correct=true",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.True(t, params.AutoCorrect)
+			},
+		},
+		{
+			name:  "highlight off",
+			query: "query=go&highlight=false&highlight_pre_tag=**&highlight_post_tag=**",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Nil(t, params.Highlight)
+			},
+		},
+		{
+			name:  "keywords that look like filter syntax",
+			query: "query=Engineer%3A",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, "Engineer:", params.Query)
+				assert.Nil(t, params.Filter)
+			},
+		},
+		{
+			name:  "URL query",
+			query: "query=https%3A%2F%2Fexample.com",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, "https://example.com", params.Query)
+				assert.Nil(t, params.Filter)
+			},
+		},
+		{
+			name:  "paging",
+			query: "page=3&page_size=50",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, 100, params.Offset)
+				assert.Equal(t, 50, params.Limit)
+			},
+		},
+		{
+			name:  "enum names and numbers",
+			query: "work_mode=remote,3&job_type=Full-time&work_type=2&experience_level=senior,junior",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, []constant.WorkMode{constant.WorkModeRemote, constant.WorkModeHybrid}, params.WorkMode)
+				assert.Equal(t, []constant.JobType{constant.JobTypeFullTime, constant.JobTypePartTime}, params.JobType)
+				assert.Equal(t, []constant.ExperienceLevel{constant.ExperienceLevelSenior, constant.ExperienceLevelEntry}, params.ExperienceLevel)
+			},
+		},
+		{
+			name:  "comma and repeated lists",
+			query: "skills=go,%20rust,&skills=sql&source=linkedin&company=acme&location=Berlin,DE&company_size=11-50",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, []string{"go", "rust", "sql"}, params.Skills)
+				assert.Equal(t, []string{"linkedin"}, params.Source)
+				assert.Equal(t, []string{"acme"}, params.Company)
+				assert.Equal(t, []string{"Berlin", "DE"}, params.Location)
+				assert.Equal(t, []string{"11-50"}, params.CompanySize)
+			},
+		},
+		{
+			name:  "salary, booleans and sorting",
+			query: "min_salary=50000&max_salary=90000&currency=eur&is_remote=true&is_urgent=0&visa_sponsorship=false&sort_by=salary_max&sort_order=ASC",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, intPtr(50000), params.MinSalary)
+				assert.Equal(t, intPtr(90000), params.MaxSalary)
+				assert.Equal(t, "EUR", params.Currency)
+				assert.Equal(t, boolPtr(true), params.IsRemote)
+				assert.Equal(t, boolPtr(false), params.IsUrgent)
+				assert.Equal(t, boolPtr(false), params.VisaSponsorship)
+				assert.Equal(t, "salary_max", params.SortBy)
+				assert.Equal(t, "asc", params.SortOrder)
+			},
+		},
+		{
+			name:  "dates",
+			query: "posted_after=7d&posted_before=2030-01-31",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				if assert.NotNil(t, params.PostedAfter) {
+					assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), *params.PostedAfter, time.Minute)
+				}
+				assert.Equal(t, time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC), *params.PostedBefore)
+			},
+		},
+		{
+			name:  "geo and language",
+			query: "lat=52.5&lng=13.4&radius_km=25&tz=Europe/Berlin&tz_overlap_hours=3&language=DE,en&facets=skills,salary",
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, 52.5, *params.Latitude)
+				assert.Equal(t, 13.4, *params.Longitude)
+				assert.Equal(t, 25.0, *params.RadiusKm)
+				assert.Equal(t, "Europe/Berlin", params.Timezone)
+				assert.Equal(t, intPtr(3), params.TimezoneOverlap)
+				assert.Equal(t, []string{"de", "en"}, params.Language)
+				assert.Equal(t, []string{"skills", "salary"}, params.Facets)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(mocks.MockJobService)
+			var captured *dtos.JobSearchParams
+			service.On("SearchJobs", mock.Anything).
+				Run(func(args mock.Arguments) { captured = args.Get(0).(*dtos.JobSearchParams) }).
+				Return(&dtos.PaginatedJobsResponse{}, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/search?"+tt.query, nil)
+			newSearchRouter(service).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			if assert.NotNil(t, captured) {
+				tt.check(t, captured)
+			}
+		})
+	}
+}
+
+func TestJobHandler_SearchJobsInvalidParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantFields []string
+	}{
+		{"unknown work mode", "work_mode=remote,moon", []string{"work_mode"}},
+		{"unknown job type number", "job_type=9", []string{"job_type"}},
+		{"unknown experience level", "experience_level=guru", []string{"experience_level"}},
+		{"salary not a number", "min_salary=lots", []string{"min_salary"}},
+		{"salary range inverted", "min_salary=90000&max_salary=50000", []string{"min_salary"}},
+		{"bad boolean", "is_remote=maybe", []string{"is_remote"}},
+		{"page size too large", "page_size=500", []string{"page_size"}},
+		{"page zero", "page=0", []string{"page"}},
+		{"bad sort", "sort_by=random&sort_order=sideways", []string{"sort_by", "sort_order"}},
+		{"bad date", "posted_after=yesterday", []string{"posted_after"}},
+		{"bad currency", "currency=dollars", []string{"currency"}},
+		{"bad language", "language=english", []string{"language"}},
+		{"lat without lng", "lat=52.5", []string{"lat"}},
+		{"near with lat/lng", "near=Berlin&lat=1&lng=2", []string{"near"}},
+		{"overlap without tz", "tz_overlap_hours=3", []string{"tz_overlap_hours"}},
+		{"unknown facet", "facets=colour", []string{"facets"}},
+		{"bad description format", "description_format=pdf", []string{"description_format"}},
+		{"cursor with page", "cursor=abc&page=2", []string{"cursor"}},
+		{"highlight tag alone", "highlight=true&highlight_pre_tag=<b>", []string{"highlight_pre_tag"}},
+		{"highlight tag too long", "highlight=true&highlight_pre_tag=" + strings.Repeat("x", 33) + "&highlight_post_tag=y", []string{"highlight_pre_tag"}},
+		{"highlight fragment size", "highlight=true&highlight_fragment_size=5", []string{"highlight_fragment_size"}},
+		{"every error reported", "work_mode=moon&page_size=0&is_urgent=soon", []string{"work_mode", "is_urgent", "page_size"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(mocks.MockJobService)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/search?"+tt.query, nil)
+			newSearchRouter(service).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var response dtos.APIResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.False(t, response.Success)
+			fields := make([]string, len(response.Errors))
+			for i, fieldError := range response.Errors {
+				fields[i] = fieldError.Field
+				assert.NotEmpty(t, fieldError.Message)
+			}
+			assert.ElementsMatch(t, tt.wantFields, fields)
+			service.AssertNotCalled(t, "SearchJobs", mock.Anything)
+		})
+	}
+}
+
+func TestJobHandler_SearchJobsByFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		body       string
+		wantStatus int
+		check      func(t *testing.T, params *dtos.JobSearchParams)
+	}{
+		{
+			name:       "filter document and options",
+			url:        "/api/jobs/search",
+			body:       `{"filter": {"or": [{"field": "skills", "value": ["go"]}, {"field": "salary", "op": "gte", "value": 100000}]}, "page": 2, "page_size": 10, "facets": ["skills"]}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, &dtos.SearchFilter{Or: []dtos.SearchFilter{
+					{Field: "skills", Value: []interface{}{"go"}},
+					{Field: "salary", Op: "gte", Value: 100000.0},
+				}}, params.Filter)
+				assert.Equal(t, 10, params.Offset)
+				assert.Equal(t, 10, params.Limit)
+				assert.Equal(t, []string{"skills"}, params.Facets)
+			},
+		},
+		{
+			name:       "compact query combined with the filter and query string",
+			url:        "/api/jobs/search?is_remote=true",
+			body:       `{"query": "skills:go -company:acme", "filter": {"field": "country", "value": "DE"}}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Empty(t, params.Query)
+				assert.Equal(t, &dtos.SearchFilter{And: []dtos.SearchFilter{
+					{And: []dtos.SearchFilter{
+						{Field: "skills", Value: "go"},
+						{Not: &dtos.SearchFilter{Field: "company", Value: "acme"}},
+					}},
+					{Field: "country", Value: "DE"},
+				}}, params.Filter)
+				assert.Equal(t, utils.Bool(true), params.IsRemote)
+			},
+		},
+		{
+			name:       "plain keywords stay a text query",
+			url:        "/api/jobs/search",
+			body:       `{"query": "go developer"}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, params *dtos.JobSearchParams) {
+				assert.Equal(t, "go developer", params.Query)
+				assert.Nil(t, params.Filter)
+			},
+		},
+		{
+			name:       "malformed body",
+			url:        "/api/jobs/search",
+			body:       `{"filter": `,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "bad compact syntax",
+			url:        "/api/jobs/search",
+			body:       `{"query": "(skills:go"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "bad page size",
+			url:        "/api/jobs/search",
+			body:       `{"page_size": 1000}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(mocks.MockJobService)
+			var captured *dtos.JobSearchParams
+			service.On("SearchJobs", mock.Anything).
+				Run(func(args mock.Arguments) { captured = args.Get(0).(*dtos.JobSearchParams) }).
+				Return(&dtos.PaginatedJobsResponse{}, nil)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			newSearchRouter(service).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.check != nil && assert.NotNil(t, captured) {
+				tt.check(t, captured)
+			}
+			if tt.wantStatus != http.StatusOK {
+				service.AssertNotCalled(t, "SearchJobs", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestJobHandler_SearchJobsInvalidFilter(t *testing.T) {
+	service := new(mocks.MockJobService)
+	service.On("SearchJobs", mock.Anything).Return(nil, fmt.Errorf("%w: invalid filter: filter.colour: unknown field", ErrInvalidSearch))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/jobs/search", strings.NewReader(`{"filter": {"field": "colour", "value": "red"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	newSearchRouter(service).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown field")
+}
+
+func TestJobHandler_GetSimilarJobs(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		setup      func(service *mocks.MockJobService)
+		wantStatus int
+	}{
+		{
+			name: "default limit",
+			path: "/api/jobs/7/similar",
+			setup: func(service *mocks.MockJobService) {
+				service.On("GetSimilarJobs", uint(7), 10).Return([]dtos.SimilarJob{{Similarity: 0.5}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "limit",
+			path: "/api/jobs/7/similar?limit=3&description_format=text",
+			setup: func(service *mocks.MockJobService) {
+				service.On("GetSimilarJobs", uint(7), 3).Return([]dtos.SimilarJob{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "unknown job",
+			path: "/api/jobs/7/similar",
+			setup: func(service *mocks.MockJobService) {
+				service.On("GetSimilarJobs", uint(7), 10).Return(nil, ErrJobNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{"bad id", "/api/jobs/abc/similar", func(*mocks.MockJobService) {}, http.StatusBadRequest},
+		{"limit out of range", "/api/jobs/7/similar?limit=0", func(*mocks.MockJobService) {}, http.StatusBadRequest},
+		{"bad description format", "/api/jobs/7/similar?description_format=pdf", func(*mocks.MockJobService) {}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(mocks.MockJobService)
+			tt.setup(service)
+
+			w := httptest.NewRecorder()
+			newSearchRouter(service).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobHandler_ExportJobs(t *testing.T) {
+	service := &mocks.MockJobService{}
+	service.On("SearchJobs", mock.MatchedBy(func(params *dtos.JobSearchParams) bool {
+		return params.Cursor == "" && params.Query == "go" && params.Limit == exportPageSize && params.Offset == 0
+	})).Return(&dtos.PaginatedJobsResponse{
+		Jobs:       []model.Job{{ID: 3, Title: "Go Developer"}, {ID: 2, Title: "Go Engineer"}},
+		NextCursor: "next",
+	}, nil).Once()
+	service.On("SearchJobs", mock.MatchedBy(func(params *dtos.JobSearchParams) bool {
+		return params.Cursor == "next" && params.Query == "go"
+	})).Return(&dtos.PaginatedJobsResponse{
+		Jobs: []model.Job{{ID: 1, Title: "Senior Go Developer"}},
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/export?query=go&page=3&page_size=5", nil)
+	authenticateAs(req, usermodel.RoleAdmin)
+	w := httptest.NewRecorder()
+	newSearchRouter(service).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	if assert.Len(t, lines, 3) {
+		for i, id := range []uint{3, 2, 1} {
+			var job model.Job
+			assert.NoError(t, json.Unmarshal([]byte(lines[i]), &job))
+			assert.Equal(t, id, job.ID)
+		}
+	}
+	service.AssertExpectations(t)
+}
+
+func TestJobHandler_ExportJobsGeoSearch(t *testing.T) {
+	// 105 jobs near San Francisco, newest first, exported through the service
+	jobs := make([]model.Job, 105)
+	for i := range jobs {
+		jobs[i] = model.Job{ID: uint(len(jobs) - i), Title: "Go Developer", CreatedAt: time.Date(2025, 3, 1, 0, 0, len(jobs)-i, 0, time.UTC)}
+	}
+	geoSearch := func(params *dtos.JobSearchParams) bool {
+		return params.Latitude != nil && params.RadiusKm != nil && params.SortBy == "created_at" && params.SortOrder == "desc"
+	}
+
+	for _, query := range []string{"near=San%20Francisco", "lat=37.77&lng=-122.41&radius_km=50"} {
+		t.Run(query, func(t *testing.T) {
+			mockJobRepo := &mocks.MockJobRepository{}
+			mockJobRepo.On("SearchJobs", mock.MatchedBy(func(params *dtos.JobSearchParams) bool {
+				return geoSearch(params) && params.Keyset == nil
+			})).Return(jobs[:exportPageSize+1], int64(len(jobs)), nil).Once()
+			mockJobRepo.On("SearchJobs", mock.MatchedBy(func(params *dtos.JobSearchParams) bool {
+				return geoSearch(params) && params.Keyset != nil && params.Keyset.ID == jobs[exportPageSize-1].ID
+			})).Return(jobs[exportPageSize:], int64(len(jobs)), nil).Once()
+			service := NewJobService(mockJobRepo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{})
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(user.Authenticate(roleUserService{}))
+			NewJobHandler(service).RegisterJobRoutes(router.Group("/api"))
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/export?"+query, nil)
+			authenticateAs(req, usermodel.RoleAdmin)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, len(jobs), strings.Count(w.Body.String(), "\n"))
+			mockJobRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobHandler_ExportJobsFirstPageError(t *testing.T) {
+	service := &mocks.MockJobService{}
+	service.On("SearchJobs", mock.Anything).Return(nil, fmt.Errorf("%w: unknown city", ErrInvalidSearch))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/export?near=atlantis", nil)
+	authenticateAs(req, usermodel.RoleAdmin)
+	w := httptest.NewRecorder()
+	newSearchRouter(service).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	service.AssertExpectations(t)
+}
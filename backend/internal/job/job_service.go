@@ -5,11 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/bhati00/workova/backend/constant"
 	"github.com/bhati00/workova/backend/dtos"
 	"github.com/bhati00/workova/backend/internal/job/model"
 	"github.com/bhati00/workova/backend/internal/job/repository"
+	companyname "github.com/bhati00/workova/backend/pkg/company_name"
+	"github.com/bhati00/workova/backend/pkg/enrichment"
+	"github.com/bhati00/workova/backend/pkg/geo"
+	htmltext "github.com/bhati00/workova/backend/pkg/html_text"
+	"github.com/bhati00/workova/backend/pkg/language"
+	"github.com/bhati00/workova/backend/pkg/utils"
 	"gorm.io/gorm"
 )
 
@@ -25,29 +34,76 @@ type JobService interface {
 	DeleteJobsBatch(ids []uint) (*dtos.BatchResult, error)
 
 	// Query operations
-	GetAllJobs(page, pageSize int) (*dtos.PaginatedJobsResponse, error)
+	GetAllJobs(page, pageSize int, cursor string) (*dtos.PaginatedJobsResponse, error)
 	SearchJobs(params *dtos.JobSearchParams) (*dtos.PaginatedJobsResponse, error)
 	GetJobStats() (*dtos.JobStatsResponse, error)
+	GetCategories() ([]model.Category, error)
+	GetSimilarJobs(id uint, limit int) ([]dtos.SimilarJob, error)
 }
 
+// ErrInvalidSearch is returned when search parameters can't be resolved, e.g. an unknown near= city
+var ErrInvalidSearch = errors.New("invalid search parameters")
+
+const (
+	// DefaultRadiusKm applies to geo searches that don't send radius_km
+	DefaultRadiusKm = 50.0
+	// DefaultTimezoneOverlapHours applies to tz searches that don't send tz_overlap_hours
+	DefaultTimezoneOverlapHours = 4
+)
+
 // jobService implements JobService interface
 type jobService struct {
 	jobRepo      repository.JobRepository
 	skillRepo    repository.SkillRepository
 	categoryRepo repository.CategoryRepository
 	locationRepo repository.LocationRepository
+	companyRepo  repository.CompanyRepository
+
+	summaryLength  int
+	queryCorrector QueryCorrector
 }
 
-// NewJobService creates a new job service instance
-func NewJobService(jobRepo repository.JobRepository, skillRepo repository.SkillRepository, categoryRepo repository.CategoryRepository, locationRep repository.LocationRepository) JobService {
-	return &jobService{
-		jobRepo:      jobRepo,
-		skillRepo:    skillRepo,
-		categoryRepo: categoryRepo,
-		locationRepo: locationRep,
+// QueryCorrector proposes spelling corrections of search queries, see SuggestService
+type QueryCorrector interface {
+	// CorrectQuery returns query with misspelled words replaced, "" when there is nothing to correct
+	CorrectQuery(query string) (string, error)
+}
+
+// JobServiceOption customizes a job service
+type JobServiceOption func(*jobService)
+
+// WithSummaryLength sets the maximum length in characters of generated summaries
+func WithSummaryLength(length int) JobServiceOption {
+	return func(s *jobService) {
+		if length > 0 {
+			s.summaryLength = length
+		}
+	}
+}
+
+// WithQueryCorrector adds did_you_mean corrections to searches with few or no results
+func WithQueryCorrector(corrector QueryCorrector) JobServiceOption {
+	return func(s *jobService) {
+		s.queryCorrector = corrector
 	}
 }
 
+// NewJobService creates a new job service instance
+func NewJobService(jobRepo repository.JobRepository, skillRepo repository.SkillRepository, categoryRepo repository.CategoryRepository, locationRep repository.LocationRepository, companyRepo repository.CompanyRepository, opts ...JobServiceOption) JobService {
+	s := &jobService{
+		jobRepo:       jobRepo,
+		skillRepo:     skillRepo,
+		categoryRepo:  categoryRepo,
+		locationRepo:  locationRep,
+		companyRepo:   companyRepo,
+		summaryLength: enrichment.DefaultSummaryLength,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
 // CreateJob creates a new job with validation
 func (s *jobService) CreateJob(jobRequest dtos.JobRequest) (*dtos.JobResponse, error) {
 	job, err := ConvertJobRequest(jobRequest)
@@ -55,6 +111,29 @@ func (s *jobService) CreateJob(jobRequest dtos.JobRequest) (*dtos.JobResponse, e
 		return nil, fmt.Errorf("invalid job data: %w", err)
 	}
 
+	RenderDescription(job)
+	SummarizeJob(job, s.summaryLength)
+	IndexLanguage(job)
+	EnrichExperience(job)
+	EnrichBenefits(job)
+
+	eligibility := ParseRemoteEligibility(jobRequest, utils.StringValue(job.DescriptionText))
+	if job.IsRemote == nil && eligibility.IsRemote {
+		job.IsRemote = utils.Bool(true)
+	}
+	remote := isRemoteJob(job)
+	if remote && job.RemoteLocationRestriction == nil {
+		job.RemoteLocationRestriction = utils.String(eligibility.Summary())
+	}
+
+	categorySlugs := enrichment.ClassifyCategories(job.Title, utils.StringValue(job.DescriptionText))
+
+	if company, err := s.resolveCompany(job); err != nil {
+		log.Printf("Failed to resolve company %q (JobTitle: %s): %v", job.CompanyName, job.Title, err)
+	} else {
+		job.CompanyID = &company.ID
+	}
+
 	job, err = s.jobRepo.Create(job)
 	if err != nil {
 		log.Printf("Failed to create job (JobTitle: %s): %v", jobRequest.Title, err)
@@ -80,43 +159,150 @@ func (s *jobService) CreateJob(jobRequest dtos.JobRequest) (*dtos.JobResponse, e
 		}
 	}
 	// adding job locations
-	CountryIso := jobRequest.CountryIso
-	if CountryIso != "" {
-		countryObj, err := s.locationRepo.GetCountryByISO(CountryIso)
+	for _, locationRequest := range jobRequest.Locations {
+		s.createJobLocation(job, locationRequest)
+	}
+	// adding remote eligibility
+	if remote && (eligibility.Worldwide || eligibility.IsRestricted()) {
+		s.createRemoteEligibility(job, eligibility)
+	}
+	// add categories
+	s.assignCategories(job, jobRequest.Category, categorySlugs)
+	// add the term vector used to find similar jobs
+	s.indexTermVector(job)
+	response := &dtos.JobResponse{
+		ID:            job.ID,
+		ExternalJobID: utils.StringValue(job.ExternalJobID),
+		Title:         job.Title,
+		Slug:          utils.StringValue(job.Slug),
+		CreatedAt:     job.CreatedAt,
+		Message:       "Job created successfully",
+	}
+
+	return response, nil
+}
+
+// GetCategories returns the category taxonomy as a tree of top-level categories
+func (s *jobService) GetCategories() ([]model.Category, error) {
+	categories, err := s.categoryRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return buildCategoryTree(categories), nil
+}
+
+// buildCategoryTree nests categories under their parents, keeping their order.
+// Categories whose parent is missing are treated as top-level.
+func buildCategoryTree(categories []model.Category) []model.Category {
+	known := make(map[uint]bool, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+	children := make(map[uint][]model.Category)
+	var roots []model.Category
+	for _, category := range categories {
+		if category.ParentID != nil && known[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var attach func(nodes []model.Category) []model.Category
+	attach = func(nodes []model.Category) []model.Category {
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+	return attach(roots)
+}
+
+// assignCategories links a job to the category sent by the source and to the taxonomy
+// categories the classifier derived from its title and description
+func (s *jobService) assignCategories(job *model.Job, requested string, slugs []string) {
+	var categories []*model.Category
+	if requested != "" {
+		category, err := s.categoryRepo.GetCategoryByName(requested)
 		if err != nil {
-			// i need to map the country ISO to the country name
-			countryObj, _ = s.locationRepo.CreateCountry(&model.Country{Name: CountryIso, ISO: CountryIso})
+			category, err = s.categoryRepo.Create(&model.Category{Name: requested})
 		}
-		jobLocation := model.JobLocation{
-			JobID:     job.ID,
-			CountryID: countryObj.ID,
-			City:      jobRequest.City,
+		if err != nil {
+			log.Printf("Failed to create category %q for job (ID: %d): %v", requested, job.ID, err)
+		} else {
+			categories = append(categories, category)
 		}
-		s.locationRepo.CreateJobLocation(&jobLocation)
 	}
-	// add categories
-	category := jobRequest.Category
-	if category != "" {
-		categoryObj, err := s.categoryRepo.GetCategoryByName(category)
+	for _, slug := range slugs {
+		category, err := s.categoryRepo.GetCategoryBySlug(slug)
 		if err != nil {
-			categoryObj, _ = s.categoryRepo.Create(&model.Category{Name: category})
+			log.Printf("Failed to get category %s for job (ID: %d): %v", slug, job.ID, err)
+			continue
+		}
+		categories = append(categories, category)
+	}
+
+	linked := make(map[uint]bool, len(categories))
+	for _, category := range categories {
+		if linked[category.ID] {
+			continue
 		}
-		JobCategory := model.JobCategory{
+		linked[category.ID] = true
+		s.categoryRepo.CreateJobCategory(&model.JobCategory{
 			JobID:      job.ID,
-			CategoryID: categoryObj.ID,
+			CategoryID: category.ID,
+		})
+	}
+}
+
+// createJobLocation resolves the country and stores one location of a job
+func (s *jobService) createJobLocation(job *model.Job, locationRequest dtos.LocationRequest) {
+	countryIso := locationRequest.CountryIso
+	if countryIso == "" {
+		return
+	}
+	countryName := countryIso
+	if country, ok := geo.Default().LookupCountry(countryIso); ok {
+		countryIso = country.ISO
+		countryName = country.Name
+	}
+	countryObj, err := s.locationRepo.GetCountryByISO(countryIso)
+	if err != nil {
+		countryObj, err = s.locationRepo.CreateCountry(&model.Country{Name: countryName, ISO: countryIso})
+		if err != nil {
+			log.Printf("Failed to create country %s for job (ID: %d): %v", countryIso, job.ID, err)
+			return
 		}
-		s.categoryRepo.CreateJobCategory(&JobCategory)
 	}
-	response := &dtos.JobResponse{
-		ID:            job.ID,
-		ExternalJobID: *job.ExternalJobID,
-		Title:         job.Title,
-		Slug:          *job.Slug,
-		CreatedAt:     job.CreatedAt,
-		Message:       "Job created successfully",
+
+	jobLocation := ConvertJobLocation(locationRequest, countryIso)
+	jobLocation.JobID = job.ID
+	jobLocation.CountryID = countryObj.ID
+	if jobLocation.WorkMode == nil {
+		workMode := job.WorkMode
+		jobLocation.WorkMode = &workMode
 	}
+	s.locationRepo.CreateJobLocation(&jobLocation)
+}
 
-	return response, nil
+// createRemoteEligibility stores the parsed remote restrictions of a job
+func (s *jobService) createRemoteEligibility(job *model.Job, eligibility enrichment.RemoteEligibility) {
+	record := model.JobRemoteEligibility{
+		JobID:                      job.ID,
+		Worldwide:                  eligibility.Worldwide,
+		Regions:                    utils.String(strings.Join(eligibility.Regions, ",")),
+		TimezoneMinOffset:          eligibility.TimezoneMinOffset,
+		TimezoneMaxOffset:          eligibility.TimezoneMaxOffset,
+		WorkAuthorizationRequired:  eligibility.WorkAuthorizationRequired,
+		WorkAuthorizationCountries: utils.String(strings.Join(eligibility.WorkAuthorizationCountries, ",")),
+		Source:                     eligibility.Source,
+	}
+	for _, iso := range eligibility.Countries {
+		record.Countries = append(record.Countries, model.JobRemoteCountry{JobID: job.ID, CountryISO: iso})
+	}
+	if _, err := s.jobRepo.CreateRemoteEligibility(&record); err != nil {
+		log.Printf("Failed to create remote eligibility for job (ID: %d): %v", job.ID, err)
+	}
 }
 
 // GetJobByID retrieves a job by its ID
@@ -173,31 +359,16 @@ func (s *jobService) DeleteJobsBatch(ids []uint) (*dtos.BatchResult, error) {
 	return result, nil
 }
 
-// GetAllJobs retrieves all jobs with pagination
-func (s *jobService) GetAllJobs(page, pageSize int) (*dtos.PaginatedJobsResponse, error) {
+// GetAllJobs returns a page of jobs, newest first. A cursor from a previous page
+// replaces page.
+func (s *jobService) GetAllJobs(page, pageSize int, cursor string) (*dtos.PaginatedJobsResponse, error) {
 	if page < 1 {
 		page = 1
 	}
 	if pageSize < 1 || pageSize > 100 {
 		pageSize = 20
 	}
-
-	offset := (page - 1) * pageSize
-	jobs, err := s.jobRepo.GetAll(offset, pageSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get jobs: %w", err)
-	}
-
-	// Get total count (you might want to add this method to repository)
-	totalCount := int64(len(jobs)) // Placeholder - implement proper counting
-
-	return &dtos.PaginatedJobsResponse{
-		Jobs:        jobs,
-		TotalCount:  totalCount,
-		CurrentPage: page,
-		PageSize:    pageSize,
-		TotalPages:  int((totalCount + int64(pageSize) - 1) / int64(pageSize)),
-	}, nil
+	return s.SearchJobs(&dtos.JobSearchParams{Offset: (page - 1) * pageSize, Limit: pageSize, Cursor: cursor})
 }
 
 // SearchJobs searches jobs based on parameters
@@ -208,22 +379,165 @@ func (s *jobService) SearchJobs(params *dtos.JobSearchParams) (*dtos.PaginatedJo
 	if params.Offset < 0 {
 		params.Offset = 0
 	}
+	if err := s.resolveGeoParams(params); err != nil {
+		return nil, err
+	}
+	if err := validateFacets(params.Facets); err != nil {
+		return nil, err
+	}
+	if params.Filter != nil {
+		if err := repository.ValidateSearchFilter(params.Filter); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+		}
+	}
+	if params.Cursor != "" {
+		if err := decodeCursor(params); err != nil {
+			return nil, err
+		}
+		params.Offset = 0
+	}
+
+	response, err := s.runSearch(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.correctSearch(params, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
 
+// runSearch fetches a page of jobs for validated params, with its cursors and facets
+func (s *jobService) runSearch(params *dtos.JobSearchParams) (*dtos.PaginatedJobsResponse, error) {
+	// One extra job tells whether there is a page beyond this one
+	pageSize := params.Limit
+	params.Limit = pageSize + 1
 	jobs, totalCount, err := s.jobRepo.SearchJobs(params)
+	params.Limit = pageSize
 	if err != nil {
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
+	backward := params.Keyset != nil && params.Keyset.Backward
+	more := len(jobs) > pageSize
+	if more && backward {
+		jobs = jobs[1:]
+	} else if more {
+		jobs = jobs[:pageSize]
+	}
+	if params.Latitude != nil && params.Longitude != nil {
+		setJobDistances(jobs, *params.Latitude, *params.Longitude)
+	}
+	if params.Highlight != nil {
+		highlightJobs(jobs, params)
+	}
 
-	currentPage := (params.Offset / params.Limit) + 1
 	totalPages := int((totalCount + int64(params.Limit) - 1) / int64(params.Limit))
+	response := &dtos.PaginatedJobsResponse{
+		Jobs:       jobs,
+		TotalCount: totalCount,
+		PageSize:   params.Limit,
+		TotalPages: totalPages,
+	}
+	if params.Keyset == nil {
+		response.CurrentPage = (params.Offset / params.Limit) + 1
+	}
 
-	return &dtos.PaginatedJobsResponse{
-		Jobs:        jobs,
-		TotalCount:  totalCount,
-		CurrentPage: currentPage,
-		PageSize:    params.Limit,
-		TotalPages:  totalPages,
-	}, nil
+	// Distance sorts have no keyset, they page by offset only
+	if sortBy, _ := effectiveSort(params); sortBy != "distance" && len(jobs) > 0 {
+		if (backward && more) || (!backward && (params.Keyset != nil || params.Offset > 0)) {
+			response.PrevCursor = encodeCursor(params, &jobs[0], true)
+		}
+		if backward || more {
+			response.NextCursor = encodeCursor(params, &jobs[len(jobs)-1], false)
+		}
+	}
+
+	if len(params.Facets) > 0 {
+		if response.Facets, err = s.searchFacets(params); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// resolveGeoParams turns near= into coordinates, applies the default radius and
+// resolves tz/tz_overlap_hours into the list of job timezones that qualify
+func (s *jobService) resolveGeoParams(params *dtos.JobSearchParams) error {
+	if params.Near != "" {
+		city, ok := geo.Default().ResolvePlace(params.Near)
+		if !ok {
+			return fmt.Errorf("%w: unknown city %q", ErrInvalidSearch, params.Near)
+		}
+		params.Latitude = &city.Latitude
+		params.Longitude = &city.Longitude
+	}
+
+	if params.Latitude != nil && params.Longitude != nil {
+		if params.RadiusKm == nil {
+			radius := DefaultRadiusKm
+			params.RadiusKm = &radius
+		}
+		// Proximity is the natural order for a geo search
+		if params.SortBy == "" {
+			params.SortBy = "distance"
+			params.SortOrder = "asc"
+		}
+	}
+
+	if params.RemoteEligibleIn != "" {
+		country, ok := geo.Default().LookupCountry(params.RemoteEligibleIn)
+		if !ok {
+			return fmt.Errorf("%w: unknown country %q", ErrInvalidSearch, params.RemoteEligibleIn)
+		}
+		params.RemoteEligibleIn = country.ISO
+		if loc, err := time.LoadLocation(country.Timezone); err == nil {
+			offset := standardOffsetHours(loc, time.Now().Year())
+			params.RemoteEligibleOffset = &offset
+		}
+	}
+
+	if params.Timezone != "" {
+		loc, err := time.LoadLocation(params.Timezone)
+		if err != nil {
+			return fmt.Errorf("%w: unknown timezone %q", ErrInvalidSearch, params.Timezone)
+		}
+		if params.TimezoneOverlap == nil {
+			overlap := DefaultTimezoneOverlapHours
+			params.TimezoneOverlap = &overlap
+		}
+		candidates, err := s.locationRepo.GetTimezones()
+		if err != nil {
+			return fmt.Errorf("failed to load job timezones: %w", err)
+		}
+		params.Timezones = geo.TimezonesWithOverlap(loc, float64(*params.TimezoneOverlap), candidates, time.Now())
+		// Remote eligibility windows are stated in standard time, like remote_eligible_in
+		offset := standardOffsetHours(loc, time.Now().Year())
+		params.TimezoneOffset = &offset
+	}
+	return nil
+}
+
+// standardOffsetHours is the UTC offset of loc outside daylight saving time, the smaller of
+// the January and July offsets. Job postings state windows in standard time ("EST", "UTC-5").
+func standardOffsetHours(loc *time.Location, year int) float64 {
+	_, january := time.Date(year, time.January, 1, 12, 0, 0, 0, loc).Zone()
+	_, july := time.Date(year, time.July, 1, 12, 0, 0, 0, loc).Zone()
+	return float64(min(january, july)) / 3600
+}
+
+// setJobDistances sets DistanceKm to the closest location of each job that has coordinates
+func setJobDistances(jobs []model.Job, lat, lng float64) {
+	for i := range jobs {
+		for _, location := range jobs[i].JobLocations {
+			if location.Latitude == nil || location.Longitude == nil {
+				continue
+			}
+			distance := math.Round(geo.DistanceKm(lat, lng, *location.Latitude, *location.Longitude)*10) / 10
+			if jobs[i].DistanceKm == nil || distance < *jobs[i].DistanceKm {
+				jobs[i].DistanceKm = &distance
+			}
+		}
+	}
 }
 
 // GetJobStats returns job statistics
@@ -246,6 +560,208 @@ func (s *jobService) GetJobStats() (*dtos.JobStatsResponse, error) {
 	}, nil
 }
 
+// ParseRemoteEligibility reads remote restrictions from the title, the free-text
+// locations and the remote locations of a job request and its plain-text description
+func ParseRemoteEligibility(jobRequest dtos.JobRequest, description string) enrichment.RemoteEligibility {
+	locations := append([]string(nil), jobRequest.LocationText...)
+	for _, location := range jobRequest.Locations {
+		if location.WorkMode == constant.WorkModeRemote && location.CountryIso != "" {
+			locations = append(locations, "Remote, "+location.CountryIso)
+		}
+	}
+	return enrichment.ParseRemoteEligibility(jobRequest.Title, locations, description)
+}
+
+// resolveCompany finds the company of a job by website domain, then by normalized name,
+// and creates it when neither matches. Profile fields the company lacks are filled
+// from the job. A name match with a different domain is a different company.
+func (s *jobService) resolveCompany(job *model.Job) (*model.Company, error) {
+	domain := companyname.Domain(utils.StringValue(job.CompanyWebsite))
+	normalized := companyname.Normalize(job.CompanyName)
+	if normalized == "" {
+		return nil, errors.New("company name is empty after normalization")
+	}
+	industry := job.CompanyIndustry
+	if industry == nil {
+		industry = job.Industry
+	}
+
+	var company *model.Company
+	if domain != "" {
+		found, err := s.companyRepo.GetByDomain(domain)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		company = found
+		if company != nil && normalized != company.NormalizedName {
+			s.learnAlias(company, job.CompanyName, normalized)
+		}
+	}
+	if company == nil {
+		found, err := s.companyRepo.GetByNormalizedName(normalized)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if found != nil && (domain == "" || found.Domain == nil) {
+			company = found
+		}
+	}
+
+	if company == nil {
+		return s.companyRepo.Create(&model.Company{
+			Name:           strings.TrimSpace(job.CompanyName),
+			NormalizedName: normalized,
+			Slug:           companyname.Slug(job.CompanyName),
+			Domain:         utils.String(domain),
+			Website:        job.CompanyWebsite,
+			LogoURL:        job.CompanyLogoUrl,
+			Size:           job.CompanySize,
+			Industry:       industry,
+		})
+	}
+
+	changed := false
+	fill := func(field **string, value *string) {
+		if *field == nil && value != nil && *value != "" {
+			*field = value
+			changed = true
+		}
+	}
+	fill(&company.Domain, utils.String(domain))
+	fill(&company.Website, job.CompanyWebsite)
+	fill(&company.LogoURL, job.CompanyLogoUrl)
+	fill(&company.Size, job.CompanySize)
+	fill(&company.Industry, industry)
+	if changed {
+		if err := s.companyRepo.Update(company); err != nil {
+			return nil, err
+		}
+	}
+	return company, nil
+}
+
+// learnAlias remembers a name a company was matched under by domain, so jobs with that
+// name but without a website match it too. Names that already match a company are skipped.
+func (s *jobService) learnAlias(company *model.Company, name, normalized string) {
+	if _, err := s.companyRepo.GetByNormalizedName(normalized); !errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+	alias := &model.CompanyAlias{CompanyID: company.ID, Name: strings.TrimSpace(name), NormalizedName: normalized}
+	if _, err := s.companyRepo.CreateAlias(alias); err != nil {
+		log.Printf("Failed to add alias %q to company %s: %v", name, company.Slug, err)
+	}
+}
+
+// RenderDescription sanitizes the source HTML and stores the Markdown and plain-text renditions
+func RenderDescription(job *model.Job) {
+	if job.Description == nil {
+		return
+	}
+	sanitized := htmltext.Sanitize(*job.Description)
+	job.Description = utils.String(sanitized)
+	job.DescriptionMarkdown = utils.String(htmltext.ToMarkdown(sanitized))
+	job.DescriptionText = utils.String(htmltext.ToText(sanitized))
+}
+
+// SummarizeJob fills an empty Summary with the most informative sentences of the
+// description, or with "<title> at <company>" when there is no description to summarize
+func SummarizeJob(job *model.Job, maxLength int) {
+	if job.Summary != nil && *job.Summary != "" {
+		return
+	}
+	summary := enrichment.Summarize(job.Title, utils.StringValue(job.DescriptionText), maxLength)
+	if summary == "" {
+		summary = job.Title + " at " + job.CompanyName
+	}
+	job.Summary = utils.String(summary)
+}
+
+// IndexLanguage detects the language of the posting and stores the search terms of its
+// title, summary and description stemmed for that language. A detected language never
+// replaces one set by the source.
+func IndexLanguage(job *model.Job) {
+	text := job.Title + "\n" + utils.StringValue(job.DescriptionText)
+	if job.Language == nil {
+		job.Language = utils.String(language.Detect(text))
+	}
+	lang := utils.StringValue(job.Language)
+
+	terms := language.UniqueTerms(job.Title+"\n"+utils.StringValue(job.Summary)+"\n"+utils.StringValue(job.DescriptionText), lang)
+	if len(terms) > 0 {
+		// Padded with spaces so every term can be matched as " term "
+		job.SearchTerms = utils.String(" " + strings.Join(terms, " ") + " ")
+	}
+}
+
+// EnrichExperience infers the seniority and required years of a job. The inferred level
+// only fills ExperienceLevel when the source didn't send one.
+func EnrichExperience(job *model.Job) {
+	if job.ExperienceLevel != nil {
+		job.ExperienceLevelSource = utils.String(enrichment.SourceAggregator)
+	}
+
+	inference := enrichment.InferExperience(job.Title, utils.StringValue(job.DescriptionText))
+	job.InferredExperienceLevel = inference.Level
+	if job.ExperienceLevel == nil && inference.Level != nil {
+		job.ExperienceLevel = inference.Level
+		job.ExperienceLevelSource = utils.String(inference.Source)
+	}
+	if job.YearsExperienceMin == nil && job.YearsExperienceMax == nil {
+		job.YearsExperienceMin = inference.YearsMin
+		job.YearsExperienceMax = inference.YearsMax
+	}
+}
+
+// Provenance values of Job.BenefitsSource
+const (
+	BenefitsSourceAggregator  = "aggregator"
+	BenefitsSourceDescription = "description"
+	BenefitsSourceMixed       = "mixed"
+)
+
+// EnrichBenefits fills the perk, visa sponsorship and security clearance fields the source
+// left empty from the description and records where the values came from
+func EnrichBenefits(job *model.Job) {
+	fromSource := job.Benefits != nil || job.HealthInsurance != nil || job.PaidTimeOff != nil ||
+		job.FlexibleSchedule != nil || job.EquityOffered != nil || job.VisaSponsorship != nil || job.SecurityClearance != nil
+
+	inference := enrichment.InferBenefits(utils.StringValue(job.DescriptionText))
+	inferred := false
+	fillBool := func(field **bool, value *bool) {
+		if *field == nil && value != nil {
+			*field = value
+			inferred = true
+		}
+	}
+	if job.Benefits == nil && len(inference.Benefits) > 0 {
+		job.Benefits = utils.String(strings.Join(inference.Benefits, ", "))
+		inferred = true
+	}
+	fillBool(&job.HealthInsurance, inference.HealthInsurance)
+	fillBool(&job.PaidTimeOff, inference.PaidTimeOff)
+	fillBool(&job.FlexibleSchedule, inference.FlexibleSchedule)
+	fillBool(&job.EquityOffered, inference.EquityOffered)
+	fillBool(&job.VisaSponsorship, inference.VisaSponsorship)
+	if job.SecurityClearance == nil && inference.SecurityClearance != nil {
+		job.SecurityClearance = inference.SecurityClearance
+		inferred = true
+	}
+
+	switch {
+	case fromSource && inferred:
+		job.BenefitsSource = utils.String(BenefitsSourceMixed)
+	case inferred:
+		job.BenefitsSource = utils.String(BenefitsSourceDescription)
+	case fromSource:
+		job.BenefitsSource = utils.String(BenefitsSourceAggregator)
+	}
+}
+
+// isRemoteJob reports whether a job can be done fully remotely
+func isRemoteJob(job *model.Job) bool {
+	return (job.IsRemote != nil && *job.IsRemote) || job.WorkMode == constant.WorkModeRemote
+}
+
 func ConvertJobRequest(jobDto dtos.JobRequest) (*model.Job, error) {
 	// 1. Basic validations
 	if jobDto.Title == "" {
@@ -261,6 +777,15 @@ func ConvertJobRequest(jobDto dtos.JobRequest) (*model.Job, error) {
 		return nil, errors.New("work mode is required")
 	}
 
+	var lang *string
+	if jobDto.Language != nil && *jobDto.Language != "" {
+		code := strings.ToLower(strings.TrimSpace(*jobDto.Language))
+		if !isLanguageCode(code) {
+			return nil, errors.New("invalid language (use an ISO 639-1 code)")
+		}
+		lang = &code
+	}
+
 	// 2. Parse dates
 	var postedDate *time.Time
 	if jobDto.PostedDate != nil {
@@ -311,7 +836,12 @@ func ConvertJobRequest(jobDto dtos.JobRequest) (*model.Job, error) {
 		Slug:          slug,
 		Title:         jobDto.Title,
 		Description:   description,
-		CompanyName:   jobDto.CompanyName,
+		Language:      lang,
+
+		CompanyName:    jobDto.CompanyName,
+		CompanyWebsite: jobDto.CompanyWebsite,
+		CompanyLogoUrl: jobDto.CompanyLogoURL,
+		CompanySize:    jobDto.CompanySize,
 
 		JobType:         jobDto.JobType,
 		WorkMode:        jobDto.WorkMode,
@@ -332,3 +862,43 @@ func ConvertJobRequest(jobDto dtos.JobRequest) (*model.Job, error) {
 	}
 	return &job, nil
 }
+
+// isLanguageCode reports whether code looks like an ISO 639-1 code (two lower-case letters)
+func isLanguageCode(code string) bool {
+	return len(code) == 2 && code[0] >= 'a' && code[0] <= 'z' && code[1] >= 'a' && code[1] <= 'z'
+}
+
+// ConvertJobLocation builds a job location from the request and fills region,
+// coordinates and timezone from the offline gazetteer when the source didn't send them
+func ConvertJobLocation(locationDto dtos.LocationRequest, countryIso string) model.JobLocation {
+	location := model.JobLocation{
+		City:      locationDto.City,
+		Region:    locationDto.Region,
+		Latitude:  locationDto.Latitude,
+		Longitude: locationDto.Longitude,
+		Timezone:  locationDto.Timezone,
+	}
+	if locationDto.WorkMode != 0 {
+		workMode := locationDto.WorkMode
+		location.WorkMode = &workMode
+	}
+	if locationDto.City == nil || *locationDto.City == "" {
+		return location
+	}
+
+	city, ok := geo.Default().LookupCity(*locationDto.City, countryIso)
+	if !ok {
+		return location
+	}
+	if location.Region == nil {
+		location.Region = utils.String(city.Region)
+	}
+	if location.Latitude == nil || location.Longitude == nil {
+		location.Latitude = &city.Latitude
+		location.Longitude = &city.Longitude
+	}
+	if location.Timezone == nil {
+		location.Timezone = utils.String(city.Timezone)
+	}
+	return location
+}
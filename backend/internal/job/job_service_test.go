@@ -3,7 +3,9 @@ package job
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/bhati00/workova/backend/constant"
 	"github.com/bhati00/workova/backend/dtos"
@@ -26,9 +28,11 @@ func createValidJobRequest() dtos.JobRequest {
 		JobType:       constant.JobTypeContract, // assuming 1 is valid
 		WorkMode:      constant.WorkModeOnsite,  // assuming 1 is valid
 		Skills:        []string{"Go", "Python"},
-		CountryIso:    "US",
-		City:          utils.String("New York"),
-		Category:      "Engineering",
+		Locations: []dtos.LocationRequest{
+			{CountryIso: "US", City: utils.String("New York")},
+			{CountryIso: "Germany", City: utils.String("Berlin"), WorkMode: constant.WorkModeHybrid},
+		},
+		Category: "Engineering",
 	}
 }
 
@@ -45,18 +49,18 @@ func TestJobService_CreateJob(t *testing.T) {
 	tests := []struct {
 		name          string
 		jobRequest    dtos.JobRequest
-		setupMocks    func(*mocks.MockJobRepository, *mocks.MockSkillRepository, *mocks.MockCategoryRepository, *mocks.MockLocationRepository)
+		setupMocks    func(*mocks.MockJobRepository, *mocks.MockSkillRepository, *mocks.MockCategoryRepository, *mocks.MockLocationRepository, *mocks.MockCompanyRepository)
 		expectedError string
 	}{
 		{
 			name:       "successful_job_creation",
 			jobRequest: createValidJobRequest(),
-			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository) {
-				// No duplicate found
-				jobRepo.On("IsDuplicateJob", mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).Return(false, nil)
+			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository, companyRepo *mocks.MockCompanyRepository) {
+				// Existing company
+				companyRepo.On("GetByNormalizedName", "tech").Return(&model.Company{ID: 1, Name: "Tech Corp"}, nil)
 
 				// Successful job creation
-				createdJob := &model.Job{ID: 1, Title: "Software Engineer"}
+				createdJob := &model.Job{ID: 1, Title: "Software Engineer", WorkMode: constant.WorkModeOnsite}
 				jobRepo.On("Create", mock.AnythingOfType("*model.Job")).Return(createdJob, nil)
 
 				// Skills handling
@@ -67,30 +71,54 @@ func TestJobService_CreateJob(t *testing.T) {
 				jobRepo.On("CreateJobSkill", mock.AnythingOfType("*model.JobSkill")).Return(&model.JobSkill{}, nil).Twice()
 
 				// Location handling
-				country := &model.Country{ID: 1, Name: "US", ISO: "US"}
-				locationRepo.On("GetCountryByISO", "US").Return(country, nil)
-				locationRepo.On("CreateJobLocation", mock.AnythingOfType("*model.JobLocation")).Return(&model.JobLocation{}, nil)
+				locationRepo.On("GetCountryByISO", "US").Return(&model.Country{ID: 1, Name: "United States", ISO: "US"}, nil)
+				locationRepo.On("GetCountryByISO", "DE").Return(nil, gorm.ErrRecordNotFound)
+				locationRepo.On("CreateCountry", &model.Country{Name: "Germany", ISO: "DE"}).Return(&model.Country{ID: 2, Name: "Germany", ISO: "DE"}, nil)
+				locationRepo.On("CreateJobLocation", mock.MatchedBy(func(location *model.JobLocation) bool {
+					return location.CountryID == 1 && *location.City == "New York" && *location.WorkMode == constant.WorkModeOnsite
+				})).Return(&model.JobLocation{}, nil).Once()
+				locationRepo.On("CreateJobLocation", mock.MatchedBy(func(location *model.JobLocation) bool {
+					return location.CountryID == 2 && *location.Timezone == "Europe/Berlin" && *location.WorkMode == constant.WorkModeHybrid
+				})).Return(&model.JobLocation{}, nil).Once()
 
 				// Category handling
 				category := &model.Category{ID: 1, Name: "Engineering"}
 				categoryRepo.On("GetCategoryByName", "Engineering").Return(category, nil)
+				categoryRepo.On("GetCategoryBySlug", "engineering").Return(category, nil)
 				categoryRepo.On("CreateJobCategory", mock.AnythingOfType("*model.JobCategory")).Return(&model.JobCategory{}, nil)
+
+				// Term vector
+				jobRepo.On("GetDocumentFrequencies", []string{"engineer", "software"}).Return(map[string]int64{"engineer": 4}, int64(10), nil)
+				jobRepo.On("SaveTermVector", uint(1), []string{"engineer", "software"}, mock.AnythingOfType("map[string]float64")).Return(nil)
 			},
 			expectedError: "",
 		},
 		{
-			name:       "duplicate_job_error",
+			name:       "company_resolution_fails",
 			jobRequest: createValidJobRequest(),
-			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository) {
-				// Duplicate found
-				jobRepo.On("IsDuplicateJob", mock.AnythingOfType("*string"), mock.AnythingOfType("*string")).Return(true, nil)
+			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository, companyRepo *mocks.MockCompanyRepository) {
+				// The job is still created, without a company
+				companyRepo.On("GetByNormalizedName", "tech").Return(nil, errors.New("database error"))
+				jobRepo.On("Create", mock.MatchedBy(func(job *model.Job) bool {
+					return job.CompanyID == nil
+				})).Return(&model.Job{ID: 1, Title: "Software Engineer"}, nil)
+
+				skillRepo.On("GetByName", mock.Anything).Return(&model.Skill{ID: 1}, nil)
+				jobRepo.On("CreateJobSkill", mock.Anything).Return(&model.JobSkill{}, nil)
+				locationRepo.On("GetCountryByISO", mock.Anything).Return(&model.Country{ID: 1}, nil)
+				locationRepo.On("CreateJobLocation", mock.Anything).Return(&model.JobLocation{}, nil)
+				categoryRepo.On("GetCategoryByName", mock.Anything).Return(&model.Category{ID: 1}, nil)
+				categoryRepo.On("GetCategoryBySlug", mock.Anything).Return(&model.Category{ID: 1}, nil)
+				categoryRepo.On("CreateJobCategory", mock.Anything).Return(&model.JobCategory{}, nil)
+				jobRepo.On("GetDocumentFrequencies", []string{"engineer", "software"}).Return(map[string]int64{}, int64(0), nil)
+				jobRepo.On("SaveTermVector", uint(1), []string{"engineer", "software"}, mock.Anything).Return(nil)
 			},
-			expectedError: "duplicate job entry",
+			expectedError: "",
 		},
 		{
 			name:       "invalid_job_data_error",
 			jobRequest: createInvalidJobRequest(),
-			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository) {
+			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository, companyRepo *mocks.MockCompanyRepository) {
 				// No mocks needed - validation happens before repo calls
 			},
 			expectedError: "invalid job data",
@@ -98,8 +126,9 @@ func TestJobService_CreateJob(t *testing.T) {
 		{
 			name:       "job_creation_fails",
 			jobRequest: createValidJobRequest(),
-			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository) {
-				jobRepo.On("IsDuplicateJob", mock.Anything, mock.Anything).Return(false, nil)
+			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository, companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetByNormalizedName", "tech").Return(nil, gorm.ErrRecordNotFound)
+				companyRepo.On("Create", mock.AnythingOfType("*model.Company")).Return(&model.Company{ID: 1}, nil)
 				jobRepo.On("Create", mock.AnythingOfType("*model.Job")).Return(nil, errors.New("database error"))
 			},
 			expectedError: "failed to create job",
@@ -107,8 +136,8 @@ func TestJobService_CreateJob(t *testing.T) {
 		{
 			name:       "skill_not_found_creates_new_skill",
 			jobRequest: createValidJobRequest(),
-			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository) {
-				jobRepo.On("IsDuplicateJob", mock.Anything, mock.Anything).Return(false, nil)
+			setupMocks: func(jobRepo *mocks.MockJobRepository, skillRepo *mocks.MockSkillRepository, categoryRepo *mocks.MockCategoryRepository, locationRepo *mocks.MockLocationRepository, companyRepo *mocks.MockCompanyRepository) {
+				companyRepo.On("GetByNormalizedName", "tech").Return(&model.Company{ID: 1, Name: "Tech Corp"}, nil)
 				createdJob := &model.Job{ID: 1}
 				jobRepo.On("Create", mock.AnythingOfType("*model.Job")).Return(createdJob, nil)
 
@@ -123,6 +152,7 @@ func TestJobService_CreateJob(t *testing.T) {
 				locationRepo.On("GetCountryByISO", mock.Anything).Return(&model.Country{ID: 1}, nil)
 				locationRepo.On("CreateJobLocation", mock.Anything).Return(nil, errors.New("sdfd"))
 				categoryRepo.On("GetCategoryByName", mock.Anything).Return(&model.Category{ID: 1}, nil)
+				categoryRepo.On("GetCategoryBySlug", mock.Anything).Return(&model.Category{ID: 1}, nil)
 				categoryRepo.On("CreateJobCategory", mock.Anything).Return(nil, errors.New("asdf"))
 			},
 			expectedError: "",
@@ -136,11 +166,12 @@ func TestJobService_CreateJob(t *testing.T) {
 			mockSkillRepo := &mocks.MockSkillRepository{}
 			mockCategoryRepo := &mocks.MockCategoryRepository{}
 			mockLocationRepo := &mocks.MockLocationRepository{}
+			mockCompanyRepo := &mocks.MockCompanyRepository{}
 
-			tt.setupMocks(mockJobRepo, mockSkillRepo, mockCategoryRepo, mockLocationRepo)
+			tt.setupMocks(mockJobRepo, mockSkillRepo, mockCategoryRepo, mockLocationRepo, mockCompanyRepo)
 
 			// Create service
-			service := NewJobService(mockJobRepo, mockSkillRepo, mockCategoryRepo, mockLocationRepo)
+			service := NewJobService(mockJobRepo, mockSkillRepo, mockCategoryRepo, mockLocationRepo, mockCompanyRepo)
 
 			// Execute
 			_, err := service.CreateJob(tt.jobRequest)
@@ -158,6 +189,7 @@ func TestJobService_CreateJob(t *testing.T) {
 			mockSkillRepo.AssertExpectations(t)
 			mockCategoryRepo.AssertExpectations(t)
 			mockLocationRepo.AssertExpectations(t)
+			mockCompanyRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -194,7 +226,7 @@ func TestJobService_GetJobByID(t *testing.T) {
 			mockJobRepo.On("GetByID", tt.jobID).Return(tt.mockReturn, tt.mockError)
 
 			// Create service
-			service := NewJobService(mockJobRepo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{})
+			service := NewJobService(mockJobRepo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{})
 
 			// Execute
 			job, err := service.GetJobByID(tt.jobID)
@@ -257,7 +289,7 @@ func TestJobService_DeleteJob(t *testing.T) {
 			tt.setupMocks(mockJobRepo)
 
 			// Create service
-			service := NewJobService(mockJobRepo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{})
+			service := NewJobService(mockJobRepo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{})
 
 			// Execute
 			err := service.DeleteJob(tt.jobID)
@@ -323,6 +355,17 @@ func TestConvertJobRequest(t *testing.T) {
 			},
 			expectedErr: "work mode is required",
 		},
+		{
+			name: "invalid_language",
+			jobRequest: dtos.JobRequest{
+				Title:       "Software Engineer",
+				CompanyName: "Tech Corp",
+				JobType:     1,
+				WorkMode:    1,
+				Language:    utils.String("german"),
+			},
+			expectedErr: "invalid language",
+		},
 	}
 
 	for _, tt := range tests {
@@ -344,3 +387,589 @@ func TestConvertJobRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRemoteEligibility(t *testing.T) {
+	tests := []struct {
+		name          string
+		jobRequest    dtos.JobRequest
+		wantCountries []string
+		wantSource    string
+	}{
+		{
+			name: "restriction_from_location_text",
+			jobRequest: dtos.JobRequest{
+				Title:        "Backend Engineer",
+				LocationText: []string{"Remote - Germany"},
+			},
+			wantCountries: []string{"DE"},
+			wantSource:    "location",
+		},
+		{
+			name: "remote_locations_restrict_countries",
+			jobRequest: dtos.JobRequest{
+				Title: "Backend Engineer",
+				Locations: []dtos.LocationRequest{
+					{CountryIso: "US", WorkMode: constant.WorkModeRemote},
+					{CountryIso: "DE", City: utils.String("Berlin"), WorkMode: constant.WorkModeOnsite},
+				},
+			},
+			wantCountries: []string{"US"},
+			wantSource:    "location",
+		},
+		{
+			name: "title_wins_over_description",
+			jobRequest: dtos.JobRequest{
+				Title:       "Backend Engineer (Remote, Canada)",
+				Description: utils.String("Remote within the US."),
+			},
+			wantCountries: []string{"CA"},
+			wantSource:    "title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eligibility := ParseRemoteEligibility(tt.jobRequest, utils.StringValue(tt.jobRequest.Description))
+
+			assert.True(t, eligibility.IsRemote)
+			assert.Equal(t, tt.wantCountries, eligibility.Countries)
+			assert.Equal(t, tt.wantSource, eligibility.Source)
+		})
+	}
+}
+
+func TestEnrichExperience(t *testing.T) {
+	senior := constant.ExperienceLevelSenior
+	entry := constant.ExperienceLevelEntry
+	tests := []struct {
+		name         string
+		job          model.Job
+		wantLevel    *constant.ExperienceLevel
+		wantInferred *constant.ExperienceLevel
+		wantSource   *string
+		wantYearsMin *int
+	}{
+		{
+			name:         "inferred_from_title",
+			job:          model.Job{Title: "Senior Go Engineer", DescriptionText: utils.String("5+ years of experience")},
+			wantLevel:    &senior,
+			wantInferred: &senior,
+			wantSource:   utils.String("title"),
+			wantYearsMin: utils.Int(5),
+		},
+		{
+			name:         "aggregator_level_is_kept",
+			job:          model.Job{Title: "Senior Go Engineer", ExperienceLevel: &entry},
+			wantLevel:    &entry,
+			wantInferred: &senior,
+			wantSource:   utils.String("aggregator"),
+		},
+		{
+			name: "no_cues",
+			job:  model.Job{Title: "Go Engineer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := tt.job
+			EnrichExperience(&job)
+
+			assert.Equal(t, tt.wantLevel, job.ExperienceLevel)
+			assert.Equal(t, tt.wantInferred, job.InferredExperienceLevel)
+			assert.Equal(t, tt.wantSource, job.ExperienceLevelSource)
+			assert.Equal(t, tt.wantYearsMin, job.YearsExperienceMin)
+		})
+	}
+}
+
+func TestRenderDescription(t *testing.T) {
+	job := model.Job{Description: utils.String(`<div style="x"><p>Build <b>APIs</b></p><script>track()</script></div>`)}
+
+	RenderDescription(&job)
+
+	assert.Equal(t, "<p>Build <strong>APIs</strong></p>", *job.Description)
+	assert.Equal(t, "Build **APIs**", *job.DescriptionMarkdown)
+	assert.Equal(t, "Build APIs", *job.DescriptionText)
+
+	empty := model.Job{}
+	RenderDescription(&empty)
+	assert.Nil(t, empty.Description)
+	assert.Nil(t, empty.DescriptionText)
+}
+
+func TestSummarizeJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      model.Job
+		expected string
+	}{
+		{
+			name:     "short_description_is_the_summary",
+			job:      model.Job{Title: "Go Engineer", CompanyName: "Acme", DescriptionText: utils.String("Build payment APIs in Go.")},
+			expected: "Build payment APIs in Go.",
+		},
+		{
+			name:     "falls_back_to_title_and_company",
+			job:      model.Job{Title: "Go Engineer", CompanyName: "Acme"},
+			expected: "Go Engineer at Acme",
+		},
+		{
+			name:     "keeps_existing_summary",
+			job:      model.Job{Title: "Go Engineer", CompanyName: "Acme", Summary: utils.String("Sent by the source")},
+			expected: "Sent by the source",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := tt.job
+			SummarizeJob(&job, 300)
+			assert.Equal(t, tt.expected, *job.Summary)
+		})
+	}
+}
+
+func TestIndexLanguage(t *testing.T) {
+	tests := []struct {
+		name         string
+		job          model.Job
+		wantLanguage *string
+		wantTerms    []string
+	}{
+		{
+			name: "detects_german_and_stems_for_it",
+			job: model.Job{
+				Title:           "Softwareentwicklerin (m/w/d)",
+				DescriptionText: utils.String("Wir suchen eine Entwicklerin für unser Team in München. Du hast Erfahrung mit Go und bist neugierig."),
+			},
+			wantLanguage: utils.String("de"),
+			wantTerms:    []string{" softwareentwickl ", " entwickl ", " munch "},
+		},
+		{
+			name: "keeps_language_sent_by_source",
+			job: model.Job{
+				Title:           "Backend Engineers",
+				Language:        utils.String("en"),
+				DescriptionText: utils.String("Wir suchen eine Entwicklerin für unser Team. Du hast Erfahrung mit Go und bist neugierig."),
+			},
+			wantLanguage: utils.String("en"),
+			wantTerms:    []string{" backend ", " engineer "},
+		},
+		{
+			name:         "title_only_is_undetected_but_indexed",
+			job:          model.Job{Title: "Go Developers"},
+			wantLanguage: nil,
+			wantTerms:    []string{" go ", " developers "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := tt.job
+			IndexLanguage(&job)
+			assert.Equal(t, tt.wantLanguage, job.Language)
+			for _, term := range tt.wantTerms {
+				assert.Contains(t, utils.StringValue(job.SearchTerms), term)
+			}
+		})
+	}
+}
+
+func TestEnrichBenefits(t *testing.T) {
+	tests := []struct {
+		name       string
+		job        model.Job
+		wantSource *string
+		wantVisa   *bool
+		wantEquity *bool
+	}{
+		{
+			name:       "inferred_from_description",
+			job:        model.Job{DescriptionText: utils.String("Equity and visa sponsorship available.")},
+			wantSource: utils.String(BenefitsSourceDescription),
+			wantVisa:   utils.Bool(true),
+			wantEquity: utils.Bool(true),
+		},
+		{
+			name:       "source_value_is_kept",
+			job:        model.Job{VisaSponsorship: utils.Bool(false), DescriptionText: utils.String("Equity and visa sponsorship available.")},
+			wantSource: utils.String(BenefitsSourceMixed),
+			wantVisa:   utils.Bool(false),
+			wantEquity: utils.Bool(true),
+		},
+		{
+			name:       "source_only",
+			job:        model.Job{VisaSponsorship: utils.Bool(true)},
+			wantSource: utils.String(BenefitsSourceAggregator),
+			wantVisa:   utils.Bool(true),
+		},
+		{
+			name: "nothing_known",
+			job:  model.Job{DescriptionText: utils.String("Build APIs.")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := tt.job
+			EnrichBenefits(&job)
+
+			assert.Equal(t, tt.wantSource, job.BenefitsSource)
+			assert.Equal(t, tt.wantVisa, job.VisaSponsorship)
+			assert.Equal(t, tt.wantEquity, job.EquityOffered)
+		})
+	}
+}
+
+func TestJobService_GetCategories(t *testing.T) {
+	engineering, backend, missing := uint(1), uint(2), uint(99)
+	mockCategoryRepo := &mocks.MockCategoryRepository{}
+	mockCategoryRepo.On("GetAll").Return([]model.Category{
+		{ID: engineering, Name: "Engineering"},
+		{ID: backend, Name: "Backend Engineering", ParentID: &engineering},
+		{ID: 3, Name: "Go Services", ParentID: &backend},
+		{ID: 4, Name: "Orphan", ParentID: &missing},
+	}, nil)
+	service := NewJobService(&mocks.MockJobRepository{}, &mocks.MockSkillRepository{}, mockCategoryRepo, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{})
+
+	tree, err := service.GetCategories()
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "Engineering", tree[0].Name)
+	assert.Equal(t, "Backend Engineering", tree[0].Children[0].Name)
+	assert.Equal(t, "Go Services", tree[0].Children[0].Children[0].Name)
+	assert.Equal(t, "Orphan", tree[1].Name)
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestJobService_SearchJobsFacets(t *testing.T) {
+	t.Run("facets_ignore_their_own_filter", func(t *testing.T) {
+		mockJobRepo := &mocks.MockJobRepository{}
+		params := &dtos.JobSearchParams{
+			WorkMode: []constant.WorkMode{constant.WorkModeRemote},
+			Skills:   []string{"Go"},
+			Facets:   []string{dtos.FacetWorkMode, dtos.FacetSkills},
+		}
+		mockJobRepo.On("SearchJobs", params).Return([]model.Job{{ID: 1}}, int64(1), nil)
+		mockJobRepo.On("CountFacet", mock.MatchedBy(func(p *dtos.JobSearchParams) bool {
+			return p.WorkMode == nil && len(p.Skills) == 1
+		}), dtos.FacetWorkMode, 0).Return([]dtos.FacetValue{{Value: "1", Count: 1}, {Value: "3", Count: 4}}, nil)
+		mockJobRepo.On("CountFacet", mock.MatchedBy(func(p *dtos.JobSearchParams) bool {
+			return p.Skills == nil && len(p.WorkMode) == 1
+		}), dtos.FacetSkills, 20).Return(nil, nil)
+		service := NewJobService(mockJobRepo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{})
+
+		result, err := service.SearchJobs(params)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []dtos.FacetValue{{Value: "remote", Label: "Remote", Count: 1}, {Value: "hybrid", Label: "Hybrid", Count: 4}}, result.Facets[dtos.FacetWorkMode])
+		assert.Equal(t, []dtos.FacetValue{}, result.Facets[dtos.FacetSkills])
+		assert.Equal(t, []constant.WorkMode{constant.WorkModeRemote}, params.WorkMode)
+		mockJobRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown_facet", func(t *testing.T) {
+		service := NewJobService(&mocks.MockJobRepository{}, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{})
+
+		_, err := service.SearchJobs(&dtos.JobSearchParams{Facets: []string{"salary", "colour"}})
+
+		assert.ErrorIs(t, err, ErrInvalidSearch)
+	})
+}
+
+func TestJobService_SearchJobsCursor(t *testing.T) {
+	jobs := func(ids ...uint) []model.Job {
+		result := make([]model.Job, len(ids))
+		for i, id := range ids {
+			result[i] = model.Job{ID: id, Title: "Job", CreatedAt: time.Date(2025, 1, 1, 0, 0, int(id), 0, time.UTC)}
+		}
+		return result
+	}
+	newService := func(repo *mocks.MockJobRepository) JobService {
+		return NewJobService(repo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{})
+	}
+
+	t.Run("first_page_has_next_cursor_only", func(t *testing.T) {
+		mockJobRepo := &mocks.MockJobRepository{}
+		mockJobRepo.On("SearchJobs", mock.MatchedBy(func(p *dtos.JobSearchParams) bool {
+			return p.Limit == 3 && p.Keyset == nil
+		})).Return(jobs(9, 8, 7), int64(9), nil)
+
+		result, err := newService(mockJobRepo).SearchJobs(&dtos.JobSearchParams{Limit: 2})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Jobs, 2)
+		assert.Equal(t, int64(9), result.TotalCount)
+		assert.Equal(t, 1, result.CurrentPage)
+		assert.NotEmpty(t, result.NextCursor)
+		assert.Empty(t, result.PrevCursor)
+
+		// The next cursor points after the last job shown
+		params := &dtos.JobSearchParams{Cursor: result.NextCursor}
+		assert.NoError(t, decodeCursor(params))
+		assert.Equal(t, &dtos.JobKeyset{ID: 8, Value: result.Jobs[1].CreatedAt}, params.Keyset)
+	})
+
+	t.Run("backward_page_drops_the_extra_job_at_the_start", func(t *testing.T) {
+		mockJobRepo := &mocks.MockJobRepository{}
+		mockJobRepo.On("SearchJobs", mock.MatchedBy(func(p *dtos.JobSearchParams) bool {
+			return p.Keyset != nil && p.Keyset.Backward && p.Keyset.ID == 5 && p.Offset == 0
+		})).Return(jobs(8, 7, 6), int64(9), nil)
+		cursor := encodeCursor(&dtos.JobSearchParams{}, &jobs(5)[0], true)
+
+		result, err := newService(mockJobRepo).SearchJobs(&dtos.JobSearchParams{Limit: 2, Offset: 40, Cursor: cursor})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []model.Job{jobs(7)[0], jobs(6)[0]}, result.Jobs)
+		assert.Equal(t, 0, result.CurrentPage)
+		assert.NotEmpty(t, result.PrevCursor)
+		assert.NotEmpty(t, result.NextCursor)
+	})
+
+	t.Run("last_page_has_prev_cursor_only", func(t *testing.T) {
+		mockJobRepo := &mocks.MockJobRepository{}
+		mockJobRepo.On("SearchJobs", mock.Anything).Return(jobs(2, 1), int64(9), nil)
+		cursor := encodeCursor(&dtos.JobSearchParams{}, &jobs(3)[0], false)
+
+		result, err := newService(mockJobRepo).SearchJobs(&dtos.JobSearchParams{Limit: 2, Cursor: cursor})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Jobs, 2)
+		assert.Empty(t, result.NextCursor)
+		assert.NotEmpty(t, result.PrevCursor)
+	})
+
+	t.Run("invalid_cursors", func(t *testing.T) {
+		salaryCursor := encodeCursor(&dtos.JobSearchParams{SortBy: "salary_max", SortOrder: "asc"}, &model.Job{ID: 3}, false)
+		for _, params := range []*dtos.JobSearchParams{
+			{Cursor: "not a cursor"},
+			{Cursor: salaryCursor},
+			{Cursor: salaryCursor, SortBy: "salary_max"},
+			{Cursor: salaryCursor, SortBy: "distance", SortOrder: "asc"},
+		} {
+			_, err := newService(&mocks.MockJobRepository{}).SearchJobs(params)
+			assert.ErrorIs(t, err, ErrInvalidSearch, params.SortBy)
+		}
+
+		// A NULL sort value round-trips
+		params := &dtos.JobSearchParams{Cursor: salaryCursor, SortBy: "salary_max", SortOrder: "asc"}
+		assert.NoError(t, decodeCursor(params))
+		assert.Equal(t, &dtos.JobKeyset{ID: 3}, params.Keyset)
+	})
+}
+
+func TestJobService_GetSimilarJobs(t *testing.T) {
+	level := func(l constant.ExperienceLevel) *constant.ExperienceLevel { return &l }
+	companyID := func(id uint) *uint { return &id }
+	skills := func(names ...string) []model.JobSkill {
+		result := make([]model.JobSkill, len(names))
+		for i, name := range names {
+			result[i] = model.JobSkill{Skill: model.Skill{Name: name}}
+		}
+		return result
+	}
+	berlin := []model.JobLocation{{CountryID: 1, City: utils.String("Berlin")}}
+	newService := func(repo *mocks.MockJobRepository) JobService {
+		return NewJobService(repo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{})
+	}
+
+	source := &model.Job{ID: 1, Title: "Senior Go Engineer", CompanyID: companyID(1), WorkMode: constant.WorkModeOnsite,
+		ExperienceLevel: level(constant.ExperienceLevelSenior), JobSkills: skills("Go", "Kafka"), JobLocations: berlin,
+		JobCategories: []model.JobCategory{{CategoryID: 2}}}
+
+	t.Run("ranked_and_deduplicated", func(t *testing.T) {
+		repo := &mocks.MockJobRepository{}
+		repo.On("GetByID", uint(1)).Return(source, nil)
+		repo.On("GetSimilarCandidates", uint(1), similarCandidateLimit).Return(map[uint]float64{2: 0.9, 3: 0.8, 4: 0.2, 5: 0.7, 6: 0.6}, nil)
+		repo.On("GetOpenByIDs", mock.Anything).Return([]model.Job{
+			// Repost by the same company
+			{ID: 2, Title: "Senior  GO Engineer", CompanyID: companyID(1), JobSkills: skills("Go", "Kafka")},
+			{ID: 3, Title: "Go Developer", CompanyID: companyID(2), WorkMode: constant.WorkModeOnsite, InferredExperienceLevel: level(constant.ExperienceLevelMid),
+				JobSkills: skills("go", "Kafka", "SQL"), JobLocations: berlin, JobCategories: []model.JobCategory{{CategoryID: 2}}},
+			{ID: 4, Title: "Designer", CompanyID: companyID(3)},
+			// The same posting twice, only the better one stays
+			{ID: 5, Title: "Backend Engineer", CompanyID: companyID(4), JobSkills: skills("Go")},
+			{ID: 6, Title: "Backend Engineer", CompanyID: companyID(4)},
+		}, nil)
+
+		result, err := newService(repo).GetSimilarJobs(1, 10)
+
+		assert.NoError(t, err)
+		ids := make([]uint, len(result))
+		for i, job := range result {
+			ids[i] = job.ID
+		}
+		assert.Equal(t, []uint{3, 5, 4}, ids)
+		assert.Equal(t, []string{"go", "kafka"}, result[0].SharedSkills)
+		// 0.45*0.8 text + 0.25*2/3 skills + 0.1 category + 0.1 city + 0.1*0.5 adjacent level
+		assert.InDelta(t, 0.36+0.25*2/3.0+0.1+0.1+0.05, result[0].Similarity, 1e-9)
+		assert.InDelta(t, 0.45*0.2, result[2].Similarity, 1e-9)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		repo := &mocks.MockJobRepository{}
+		repo.On("GetByID", uint(1)).Return(source, nil)
+		repo.On("GetSimilarCandidates", uint(1), similarCandidateLimit).Return(map[uint]float64{3: 0.5, 4: 0.4}, nil)
+		repo.On("GetOpenByIDs", mock.Anything).Return([]model.Job{{ID: 3, Title: "A"}, {ID: 4, Title: "B"}}, nil)
+
+		result, err := newService(repo).GetSimilarJobs(1, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, uint(3), result[0].ID)
+	})
+
+	t.Run("no_candidates", func(t *testing.T) {
+		repo := &mocks.MockJobRepository{}
+		repo.On("GetByID", uint(1)).Return(source, nil)
+		repo.On("GetSimilarCandidates", uint(1), similarCandidateLimit).Return(map[uint]float64{}, nil)
+
+		result, err := newService(repo).GetSimilarJobs(1, 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		repo.AssertNotCalled(t, "GetOpenByIDs", mock.Anything)
+	})
+
+	t.Run("job_not_found", func(t *testing.T) {
+		repo := &mocks.MockJobRepository{}
+		repo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := newService(repo).GetSimilarJobs(9, 10)
+
+		assert.ErrorIs(t, err, ErrJobNotFound)
+	})
+}
+
+func TestJobService_SearchJobsHighlights(t *testing.T) {
+	search := func(params *dtos.JobSearchParams, jobs ...model.Job) []model.Job {
+		repo := &mocks.MockJobRepository{}
+		repo.On("SearchJobs", mock.Anything).Return(jobs, int64(len(jobs)), nil)
+		service := NewJobService(repo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{})
+		result, err := service.SearchJobs(params)
+		assert.NoError(t, err)
+		return result.Jobs
+	}
+	operator := model.Job{
+		ID:              1,
+		Title:           "Kubernetes Operator Engineer",
+		Language:        utils.String("en"),
+		Summary:         utils.String("Build operators for our platform."),
+		DescriptionText: utils.String("You will write Kubernetes operators in Go."),
+	}
+	unrelated := model.Job{ID: 2, Title: "Designer", Language: utils.String("en")}
+
+	t.Run("default_tags", func(t *testing.T) {
+		jobs := search(&dtos.JobSearchParams{Query: "kubernetes operator", Highlight: &dtos.HighlightOptions{}}, operator, unrelated)
+
+		assert.Equal(t, map[string][]string{
+			HighlightTitle:       {"<mark>Kubernetes</mark> <mark>Operator</mark> Engineer"},
+			HighlightSummary:     {"Build <mark>operators</mark> for our platform."},
+			HighlightDescription: {"You will write <mark>Kubernetes</mark> <mark>operators</mark> in Go."},
+		}, jobs[0].Highlights)
+		assert.Nil(t, jobs[1].Highlights)
+	})
+
+	t.Run("custom_tags_and_filter_text", func(t *testing.T) {
+		filter := &dtos.SearchFilter{And: []dtos.SearchFilter{
+			{Field: "text", Value: "platform"},
+			{Not: &dtos.SearchFilter{Field: "text", Value: "engineer"}},
+		}}
+		jobs := search(&dtos.JobSearchParams{Filter: filter, Highlight: &dtos.HighlightOptions{PreTag: "[", PostTag: "]"}}, operator)
+
+		assert.Equal(t, map[string][]string{HighlightSummary: {"Build operators for our [platform]."}}, jobs[0].Highlights)
+	})
+
+	t.Run("not_requested", func(t *testing.T) {
+		jobs := search(&dtos.JobSearchParams{Query: "kubernetes"}, operator)
+		assert.Nil(t, jobs[0].Highlights)
+	})
+}
+
+type queryCorrectorFunc func(query string) (string, error)
+
+func (f queryCorrectorFunc) CorrectQuery(query string) (string, error) {
+	return f(query)
+}
+
+func TestJobService_SearchJobsDidYouMean(t *testing.T) {
+	corrector := queryCorrectorFunc(func(query string) (string, error) {
+		switch query {
+		case "kubernets":
+			return "kubernetes", nil
+		case "broken":
+			return "", errors.New("index unavailable")
+		}
+		return "", nil
+	})
+	jobs := []model.Job{{ID: 1, Title: "Kubernetes Engineer"}, {ID: 2, Title: "Kubernetes Operator"}, {ID: 3, Title: "Platform Engineer, Kubernetes"}}
+	search := func(params *dtos.JobSearchParams, found int) (*dtos.PaginatedJobsResponse, *mocks.MockJobRepository) {
+		repo := &mocks.MockJobRepository{}
+		repo.On("SearchJobs", mock.MatchedBy(func(p *dtos.JobSearchParams) bool { return p.Query == "kubernetes" })).Return(jobs, int64(len(jobs)), nil)
+		repo.On("SearchJobs", mock.Anything).Return(jobs[:found], int64(found), nil)
+		service := NewJobService(repo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{}, WithQueryCorrector(corrector))
+		result, err := service.SearchJobs(params)
+		assert.NoError(t, err)
+		return result, repo
+	}
+
+	t.Run("zero_hits", func(t *testing.T) {
+		result, _ := search(&dtos.JobSearchParams{Query: "kubernets"}, 0)
+		assert.Equal(t, "kubernetes", result.DidYouMean)
+		assert.False(t, result.AutoCorrected)
+		assert.Empty(t, result.Jobs)
+	})
+
+	t.Run("auto_correct", func(t *testing.T) {
+		params := &dtos.JobSearchParams{Query: "kubernets", AutoCorrect: true, Highlight: &dtos.HighlightOptions{}}
+		result, _ := search(params, 0)
+		assert.Equal(t, "kubernetes", result.DidYouMean)
+		assert.True(t, result.AutoCorrected)
+		assert.Equal(t, int64(3), result.TotalCount)
+		assert.Equal(t, []string{"<mark>Kubernetes</mark> Engineer"}, result.Jobs[0].Highlights[HighlightTitle], "highlights follow the correction")
+		assert.Equal(t, "kubernets", params.Query)
+	})
+
+	t.Run("auto_correct_with_results", func(t *testing.T) {
+		result, _ := search(&dtos.JobSearchParams{Query: "kubernets", AutoCorrect: true}, 1)
+		assert.Equal(t, "kubernetes", result.DidYouMean, "low hits are only suggested")
+		assert.False(t, result.AutoCorrected)
+		assert.Len(t, result.Jobs, 1)
+	})
+
+	t.Run("enough_hits", func(t *testing.T) {
+		result, repo := search(&dtos.JobSearchParams{Query: "kubernets"}, 3)
+		assert.Empty(t, result.DidYouMean)
+		repo.AssertNumberOfCalls(t, "SearchJobs", 1)
+	})
+
+	t.Run("nothing_to_correct", func(t *testing.T) {
+		for _, query := range []string{"accountant", "broken"} {
+			result, _ := search(&dtos.JobSearchParams{Query: query, AutoCorrect: true}, 0)
+			assert.Empty(t, result.DidYouMean, query)
+		}
+	})
+
+	t.Run("filter_syntax", func(t *testing.T) {
+		filter, err := ParseFilterQuery("kubernets remote:true -broken")
+		assert.NoError(t, err)
+		correctedFilter := func(p *dtos.JobSearchParams) bool {
+			return p.Filter != nil && strings.TrimSpace(searchText(p)) == "kubernetes" && p.Filter.And[1].Field == "remote"
+		}
+		for _, autoCorrect := range []bool{false, true} {
+			repo := &mocks.MockJobRepository{}
+			repo.On("SearchJobs", mock.MatchedBy(correctedFilter)).Return(jobs, int64(len(jobs)), nil)
+			repo.On("SearchJobs", mock.Anything).Return([]model.Job{}, int64(0), nil)
+			service := NewJobService(repo, &mocks.MockSkillRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockLocationRepository{}, &mocks.MockCompanyRepository{}, WithQueryCorrector(corrector))
+
+			params := &dtos.JobSearchParams{Filter: filter, AutoCorrect: autoCorrect}
+			result, err := service.SearchJobs(params)
+			assert.NoError(t, err)
+			assert.Equal(t, "kubernetes", result.DidYouMean)
+			assert.Equal(t, autoCorrect, result.AutoCorrected)
+			assert.Equal(t, "kubernets", strings.TrimSpace(searchText(params)), "the filter of the search is left alone")
+		}
+	})
+}
@@ -14,8 +14,11 @@ import (
 
 // JobModule represents the complete job module with all dependencies
 type JobModule struct {
-	Service JobService
-	Handler *JobHandler
+	Service        JobService
+	Handler        *JobHandler
+	CompanyHandler *CompanyHandler
+	SuggestHandler *SuggestHandler
+	SynonymHandler *SynonymHandler
 }
 
 // InitializeJobModule initializes the complete job module
@@ -26,20 +29,43 @@ func InitializeJobModule(db *gorm.DB, config *config.Config) *JobModule {
 	var locationRepo repository.LocationRepository
 	var categoryRepo repository.CategoryRepository
 	var skillRepo repository.SkillRepository
+	var companyRepo repository.CompanyRepository
 	// Initialize repository with database connection
 	jobRepo = repository.NewJobRepository(db)
 	locationRepo = repository.NewLocationRepository(db)
 	categoryRepo = repository.NewCategoryRepository(db)
 	skillRepo = repository.NewSkillRepository(db)
+	companyRepo = repository.NewCompanyRepository(db)
+
+	// Build the suggestion index up front so the first typeahead request doesn't pay for it.
+	// Its vocabulary also corrects the spelling of searches.
+	suggestService := NewSuggestService(repository.NewSuggestRepository(db))
+	if err := suggestService.Refresh(); err != nil {
+		log.Printf("Failed to build suggestion index: %v", err)
+	}
 
 	// Initialize service with repository dependency
-	jobService := NewJobService(jobRepo, skillRepo, categoryRepo, locationRepo)
+	jobService := NewJobService(jobRepo, skillRepo, categoryRepo, locationRepo, companyRepo,
+		WithSummaryLength(config.SummaryLength), WithQueryCorrector(suggestService))
 
 	// Initialize handler with service dependency
 	jobHandler := NewJobHandler(jobService)
+	companyHandler := NewCompanyHandler(NewCompanyService(companyRepo), jobService)
+
+	suggestHandler := NewSuggestHandler(suggestService)
+
+	// Searches run without synonyms when the file can't be read, until it is fixed and reloaded
+	synonymService := NewSynonymService(config.SynonymsPath)
+	if err := synonymService.Load(); err != nil {
+		log.Printf("Failed to load synonyms: %v", err)
+	}
 
 	return &JobModule{
-		Handler: jobHandler,
+		Service:        jobService,
+		Handler:        jobHandler,
+		CompanyHandler: companyHandler,
+		SuggestHandler: suggestHandler,
+		SynonymHandler: NewSynonymHandler(synonymService),
 	}
 }
 
@@ -50,6 +76,10 @@ func (jm *JobModule) RegisterRoutes(router *gin.Engine) {
 
 	// Register job routes
 	jm.Handler.RegisterJobRoutes(v1)
+	jm.CompanyHandler.RegisterCompanyRoutes(v1)
+	jm.CompanyHandler.RegisterCompanyAdminRoutes(v1)
+	jm.SuggestHandler.RegisterSuggestRoutes(v1)
+	jm.SynonymHandler.RegisterSynonymAdminRoutes(v1)
 }
 
 func Migrate(dbPath string) {
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_job_locations_region;
+DROP INDEX IF EXISTS idx_job_locations_city;
+
+ALTER TABLE job_locations DROP COLUMN timezone;
+ALTER TABLE job_locations DROP COLUMN longitude;
+ALTER TABLE job_locations DROP COLUMN latitude;
+ALTER TABLE job_locations DROP COLUMN region;
@@ -0,0 +1,8 @@
+ALTER TABLE job_locations ADD COLUMN region VARCHAR(100);
+ALTER TABLE job_locations ADD COLUMN latitude REAL;
+ALTER TABLE job_locations ADD COLUMN longitude REAL;
+ALTER TABLE job_locations ADD COLUMN timezone VARCHAR(64);
+
+-- indexes
+CREATE INDEX idx_job_locations_city ON job_locations(city);
+CREATE INDEX idx_job_locations_region ON job_locations(region);
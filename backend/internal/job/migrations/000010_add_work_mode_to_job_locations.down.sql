@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_job_locations_work_mode;
+
+ALTER TABLE job_locations DROP COLUMN work_mode;
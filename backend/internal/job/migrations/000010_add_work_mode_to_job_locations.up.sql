@@ -0,0 +1,7 @@
+ALTER TABLE job_locations ADD COLUMN work_mode INTEGER;
+
+-- backfill from the owning job
+UPDATE job_locations SET work_mode = (SELECT jobs.work_mode FROM jobs WHERE jobs.id = job_locations.job_id);
+
+-- indexes
+CREATE INDEX idx_job_locations_work_mode ON job_locations(work_mode);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS job_remote_countries;
+DROP TABLE IF EXISTS job_remote_eligibilities;
@@ -0,0 +1,32 @@
+CREATE TABLE job_remote_eligibilities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL UNIQUE,
+    worldwide BOOLEAN DEFAULT 0,
+    regions VARCHAR(255),
+    timezone_min_offset REAL,
+    timezone_max_offset REAL,
+    work_authorization_required BOOLEAN DEFAULT 0,
+    work_authorization_countries VARCHAR(255),
+    source VARCHAR(20),
+
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_job
+        FOREIGN KEY (job_id) REFERENCES jobs(id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE job_remote_countries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL,
+    country_iso VARCHAR(2) NOT NULL,
+
+    CONSTRAINT fk_job
+        FOREIGN KEY (job_id) REFERENCES jobs(id)
+        ON DELETE CASCADE
+);
+
+-- indexes
+CREATE INDEX idx_job_remote_countries_job_id ON job_remote_countries(job_id);
+CREATE INDEX idx_job_remote_countries_country_iso ON job_remote_countries(country_iso, job_id);
@@ -0,0 +1,2 @@
+ALTER TABLE jobs DROP COLUMN experience_level_source;
+ALTER TABLE jobs DROP COLUMN inferred_experience_level;
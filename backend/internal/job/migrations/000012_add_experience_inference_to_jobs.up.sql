@@ -0,0 +1,5 @@
+ALTER TABLE jobs ADD COLUMN inferred_experience_level INTEGER;
+ALTER TABLE jobs ADD COLUMN experience_level_source VARCHAR(20);
+
+-- levels stored so far were all sent by the source
+UPDATE jobs SET experience_level_source = 'aggregator' WHERE experience_level IS NOT NULL;
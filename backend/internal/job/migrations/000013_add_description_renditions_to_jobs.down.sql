@@ -0,0 +1,2 @@
+ALTER TABLE jobs DROP COLUMN description_text;
+ALTER TABLE jobs DROP COLUMN description_markdown;
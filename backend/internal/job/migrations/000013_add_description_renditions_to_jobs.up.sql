@@ -0,0 +1,5 @@
+ALTER TABLE jobs ADD COLUMN description_markdown TEXT;
+ALTER TABLE jobs ADD COLUMN description_text TEXT;
+
+-- existing rows get the stored description until they are ingested again
+UPDATE jobs SET description_text = description, description_markdown = description WHERE description IS NOT NULL;
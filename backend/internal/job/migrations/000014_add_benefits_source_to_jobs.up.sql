@@ -0,0 +1,6 @@
+ALTER TABLE jobs ADD COLUMN benefits_source VARCHAR(20);
+
+-- values stored so far were all sent by the source
+UPDATE jobs SET benefits_source = 'aggregator'
+WHERE benefits IS NOT NULL OR health_insurance IS NOT NULL OR paid_time_off IS NOT NULL OR flexible_schedule IS NOT NULL
+    OR equity_offered IS NOT NULL OR visa_sponsorship IS NOT NULL OR security_clearance IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_language;
+
+ALTER TABLE jobs DROP COLUMN search_terms;
+ALTER TABLE jobs DROP COLUMN language;
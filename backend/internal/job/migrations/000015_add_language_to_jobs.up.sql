@@ -0,0 +1,4 @@
+ALTER TABLE jobs ADD COLUMN language VARCHAR(2);
+ALTER TABLE jobs ADD COLUMN search_terms TEXT;
+
+CREATE INDEX idx_language ON jobs(language);
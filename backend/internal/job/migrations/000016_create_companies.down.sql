@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_jobs_company_id;
+ALTER TABLE jobs DROP COLUMN company_id;
+
+DROP TABLE IF EXISTS companies;
@@ -0,0 +1,45 @@
+CREATE TABLE companies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    normalized_name VARCHAR(255) NOT NULL,
+    slug VARCHAR(150) NOT NULL,
+    domain VARCHAR(255),
+    website TEXT,
+    logo_url TEXT,
+    size VARCHAR(50),
+    industry VARCHAR(100),
+
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_companies_slug ON companies(slug);
+CREATE UNIQUE INDEX idx_companies_domain ON companies(domain);
+CREATE INDEX idx_companies_normalized_name ON companies(normalized_name);
+
+ALTER TABLE jobs ADD COLUMN company_id INTEGER;
+CREATE INDEX idx_jobs_company_id ON jobs(company_id);
+
+-- backfill one company per normalized name, approximating companyname.Normalize for ASCII names
+CREATE TEMP TABLE job_company_keys AS
+SELECT id AS job_id,
+    TRIM(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(
+        LOWER(TRIM(company_name)), '.', ''), ',', ''), '''', ''), '&', ' '), '-', ' '), '/', ' '),
+        '  ', ' '), '  ', ' '), '  ', ' ')) AS normalized_name
+FROM jobs;
+
+INSERT INTO companies (name, normalized_name, slug, website, logo_url, size, industry)
+SELECT MIN(jobs.company_name), keys.normalized_name, REPLACE(keys.normalized_name, ' ', '-'),
+    MAX(jobs.company_website), MAX(jobs.company_logo_url), MAX(jobs.company_size), MAX(jobs.company_industry)
+FROM jobs
+JOIN job_company_keys keys ON keys.job_id = jobs.id
+WHERE keys.normalized_name <> ''
+GROUP BY keys.normalized_name;
+
+UPDATE jobs SET company_id = (
+    SELECT companies.id FROM companies
+    JOIN job_company_keys keys ON keys.normalized_name = companies.normalized_name
+    WHERE keys.job_id = jobs.id
+);
+
+DROP TABLE job_company_keys;
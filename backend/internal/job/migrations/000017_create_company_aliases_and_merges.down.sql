@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS company_merges;
+DROP TABLE IF EXISTS company_aliases;
@@ -0,0 +1,145 @@
+CREATE TABLE company_aliases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    company_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    normalized_name VARCHAR(255) NOT NULL,
+    domain VARCHAR(255),
+
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_company
+        FOREIGN KEY (company_id) REFERENCES companies(id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_company_aliases_normalized_name ON company_aliases(normalized_name);
+CREATE UNIQUE INDEX idx_company_aliases_domain ON company_aliases(domain);
+CREATE INDEX idx_company_aliases_company_id ON company_aliases(company_id);
+
+CREATE TABLE company_merges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source_company_id INTEGER NOT NULL,
+    source_name VARCHAR(255) NOT NULL,
+    source_slug VARCHAR(150) NOT NULL,
+    source_domain VARCHAR(255),
+    target_company_id INTEGER NOT NULL,
+    target_slug VARCHAR(150) NOT NULL,
+    jobs_moved INTEGER DEFAULT 0,
+    aliases_moved INTEGER DEFAULT 0,
+    reason TEXT,
+    performed_by VARCHAR(255),
+
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_company_merges_source_slug ON company_merges(source_slug);
+CREATE INDEX idx_company_merges_target_company_id ON company_merges(target_company_id);
+
+-- keep the old keys as aliases, then strip legal suffixes like companyname.Normalize
+INSERT INTO company_aliases (company_id, name, normalized_name)
+SELECT MIN(id), MIN(name), normalized_name FROM companies GROUP BY normalized_name;
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% inc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 13) WHERE normalized_name LIKE '% incorporated';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% llc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% pllc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% ltd';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 8) WHERE normalized_name LIKE '% limited';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% corp';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 12) WHERE normalized_name LIKE '% corporation';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% co';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 8) WHERE normalized_name LIKE '% company';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% plc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% lp';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% llp';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% pty';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% pte';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% gmbh';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% mbh';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% ag';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% kg';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% ug';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% se';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% sa';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% sas';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% sarl';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% srl';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% spa';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% bv';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% nv';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% oy';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% oyj';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% ab';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% as';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% aps';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% kk';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% inc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 13) WHERE normalized_name LIKE '% incorporated';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% llc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% pllc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% ltd';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 8) WHERE normalized_name LIKE '% limited';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% corp';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 12) WHERE normalized_name LIKE '% corporation';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% co';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 8) WHERE normalized_name LIKE '% company';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% plc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% lp';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% llp';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% pty';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% pte';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% gmbh';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% mbh';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% ag';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% kg';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% ug';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% se';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% sa';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% sas';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% sarl';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% srl';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% spa';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% bv';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% nv';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% oy';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% oyj';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% ab';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% as';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% aps';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% kk';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% inc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 13) WHERE normalized_name LIKE '% incorporated';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% llc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% pllc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% ltd';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 8) WHERE normalized_name LIKE '% limited';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% corp';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 12) WHERE normalized_name LIKE '% corporation';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% co';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 8) WHERE normalized_name LIKE '% company';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% plc';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% lp';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% llp';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% pty';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% pte';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% gmbh';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% mbh';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% ag';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% kg';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% ug';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% se';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% sa';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% sas';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 5) WHERE normalized_name LIKE '% sarl';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% srl';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% spa';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% bv';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% nv';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% oy';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% oyj';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% ab';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% as';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 4) WHERE normalized_name LIKE '% aps';
+UPDATE companies SET normalized_name = SUBSTR(normalized_name, 1, LENGTH(normalized_name) - 3) WHERE normalized_name LIKE '% kk';
+
+DELETE FROM company_aliases
+WHERE normalized_name = (SELECT companies.normalized_name FROM companies WHERE companies.id = company_aliases.company_id);
@@ -0,0 +1,81 @@
+ALTER TABLE categories ADD COLUMN slug VARCHAR(100);
+ALTER TABLE categories ADD COLUMN parent_id INTEGER;
+ALTER TABLE categories ADD COLUMN position INTEGER DEFAULT 0;
+
+CREATE UNIQUE INDEX idx_categories_slug ON categories(slug);
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+
+-- seed the job-function taxonomy, parents first; existing categories with the same name are adopted
+INSERT INTO categories (name, slug, position) VALUES ('Engineering', 'engineering', 1)
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Backend Engineering', 'backend', id, 2 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Frontend Engineering', 'frontend', id, 3 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Full-Stack Engineering', 'fullstack', id, 4 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Mobile Engineering', 'mobile', id, 5 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'DevOps & Infrastructure', 'devops', id, 6 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Data Engineering', 'data-engineering', id, 7 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Machine Learning & AI', 'machine-learning', id, 8 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Security Engineering', 'security', id, 9 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'QA & Testing', 'qa', id, 10 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Embedded & Hardware', 'embedded', id, 11 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Engineering Management', 'engineering-management', id, 12 FROM categories WHERE slug = 'engineering'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, position) VALUES ('Data & Analytics', 'data', 13)
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Data Science', 'data-science', id, 14 FROM categories WHERE slug = 'data'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Data Analytics', 'analytics', id, 15 FROM categories WHERE slug = 'data'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, position) VALUES ('Product', 'product', 16)
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Product Management', 'product-management', id, 17 FROM categories WHERE slug = 'product'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, position) VALUES ('Design', 'design', 18)
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Product & UX Design', 'product-design', id, 19 FROM categories WHERE slug = 'design'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Graphic & Visual Design', 'graphic-design', id, 20 FROM categories WHERE slug = 'design'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, position) VALUES ('Marketing', 'marketing', 21)
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Growth Marketing', 'growth-marketing', id, 22 FROM categories WHERE slug = 'marketing'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Content & Communications', 'content-marketing', id, 23 FROM categories WHERE slug = 'marketing'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, position) VALUES ('Sales', 'sales', 24)
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Sales Development', 'sales-development', id, 25 FROM categories WHERE slug = 'sales'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Account Management', 'account-management', id, 26 FROM categories WHERE slug = 'sales'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, position) VALUES ('Customer Success & Support', 'customer-success', 27)
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, position = excluded.position;
+INSERT INTO categories (name, slug, position) VALUES ('Operations', 'operations', 28)
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'People & Recruiting', 'people', id, 29 FROM categories WHERE slug = 'operations'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Finance & Accounting', 'finance', id, 30 FROM categories WHERE slug = 'operations'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+INSERT INTO categories (name, slug, parent_id, position) SELECT 'Legal & Compliance', 'legal', id, 31 FROM categories WHERE slug = 'operations'
+    ON CONFLICT(name) DO UPDATE SET slug = excluded.slug, parent_id = excluded.parent_id, position = excluded.position;
+
+-- free-form categories created at ingestion get a slug from their name where it's free
+UPDATE categories SET slug = LOWER(REPLACE(REPLACE(TRIM(name), ',', ''), ' ', '-'))
+WHERE slug IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM categories other WHERE other.slug = LOWER(REPLACE(REPLACE(TRIM(categories.name), ',', ''), ' ', '-'))
+  )
+  AND id = (
+    SELECT MIN(same.id) FROM categories same
+    WHERE LOWER(REPLACE(REPLACE(TRIM(same.name), ',', ''), ' ', '-')) = LOWER(REPLACE(REPLACE(TRIM(categories.name), ',', ''), ' ', '-'))
+  );
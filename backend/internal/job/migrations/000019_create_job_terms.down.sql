@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS term_document_counts;
+DROP TABLE IF EXISTS job_terms;
@@ -0,0 +1,22 @@
+-- TF-IDF vector of every job: its highest weighted search terms, computed at ingestion
+CREATE TABLE job_terms (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL,
+    term VARCHAR(100) NOT NULL,
+    weight REAL NOT NULL,
+
+    CONSTRAINT fk_job
+        FOREIGN KEY (job_id) REFERENCES jobs(id)
+        ON DELETE CASCADE
+);
+
+-- number of indexed postings containing each term; the row with the empty term counts
+-- the indexed postings themselves
+CREATE TABLE term_document_counts (
+    term VARCHAR(100) PRIMARY KEY,
+    document_count INTEGER NOT NULL DEFAULT 0
+);
+
+-- indexes
+CREATE INDEX idx_job_terms_job_id ON job_terms(job_id);
+CREATE INDEX idx_job_terms_term ON job_terms(term, job_id, weight);
@@ -0,0 +1,6 @@
+-- restore the previous encoding of Y Combinator jobs
+UPDATE jobs SET work_mode = CASE WHEN COALESCE(is_remote, 0) = 1 THEN 2 ELSE 1 END
+WHERE source = 'Y Combinator';
+
+UPDATE job_locations SET work_mode = (SELECT jobs.work_mode FROM jobs WHERE jobs.id = job_locations.job_id)
+WHERE job_id IN (SELECT id FROM jobs WHERE source = 'Y Combinator');
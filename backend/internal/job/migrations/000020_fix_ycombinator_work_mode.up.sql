@@ -0,0 +1,8 @@
+-- Y Combinator jobs were stored with work_mode 1 (remote) for onsite postings and 2 (onsite)
+-- for remote ones. Derive it again from is_remote.
+UPDATE jobs SET work_mode = CASE WHEN COALESCE(is_remote, 0) = 1 THEN 1 ELSE 2 END
+WHERE source = 'Y Combinator';
+
+-- locations were backfilled from the inverted job value
+UPDATE job_locations SET work_mode = (SELECT jobs.work_mode FROM jobs WHERE jobs.id = job_locations.job_id)
+WHERE job_id IN (SELECT id FROM jobs WHERE source = 'Y Combinator');
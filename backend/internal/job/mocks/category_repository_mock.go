@@ -17,6 +17,14 @@ func (m *MockCategoryRepository) GetCategoryByName(name string) (*model.Category
 	return args.Get(0).(*model.Category), args.Error(1)
 }
 
+func (m *MockCategoryRepository) GetCategoryBySlug(slug string) (*model.Category, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Category), args.Error(1)
+}
+
 func (m *MockCategoryRepository) Create(category *model.Category) (*model.Category, error) {
 	args := m.Called(category)
 	if args.Get(0) == nil {
@@ -0,0 +1,88 @@
+package mocks
+
+import (
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCompanyRepository struct {
+	mock.Mock
+}
+
+func (m *MockCompanyRepository) Create(company *model.Company) (*model.Company, error) {
+	args := m.Called(company)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Company), args.Error(1)
+}
+
+func (m *MockCompanyRepository) Update(company *model.Company) error {
+	args := m.Called(company)
+	return args.Error(0)
+}
+
+func (m *MockCompanyRepository) GetBySlug(slug string) (*model.Company, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Company), args.Error(1)
+}
+
+func (m *MockCompanyRepository) GetByDomain(domain string) (*model.Company, error) {
+	args := m.Called(domain)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Company), args.Error(1)
+}
+
+func (m *MockCompanyRepository) GetByNormalizedName(normalizedName string) (*model.Company, error) {
+	args := m.Called(normalizedName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Company), args.Error(1)
+}
+
+func (m *MockCompanyRepository) List(query string, offset, limit int) ([]model.Company, int64, error) {
+	args := m.Called(query, offset, limit)
+	return args.Get(0).([]model.Company), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCompanyRepository) CountOpenJobs(companyIDs []uint) (map[uint]int64, error) {
+	args := m.Called(companyIDs)
+	return args.Get(0).(map[uint]int64), args.Error(1)
+}
+
+func (m *MockCompanyRepository) GetSources(companyIDs []uint) (map[uint][]string, error) {
+	args := m.Called(companyIDs)
+	return args.Get(0).(map[uint][]string), args.Error(1)
+}
+
+func (m *MockCompanyRepository) CreateAlias(alias *model.CompanyAlias) (*model.CompanyAlias, error) {
+	args := m.Called(alias)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CompanyAlias), args.Error(1)
+}
+
+func (m *MockCompanyRepository) GetAliases(companyID uint) ([]model.CompanyAlias, error) {
+	args := m.Called(companyID)
+	return args.Get(0).([]model.CompanyAlias), args.Error(1)
+}
+
+func (m *MockCompanyRepository) Merge(source, target *model.Company, reason, performedBy *string) (*model.CompanyMerge, error) {
+	args := m.Called(source, target, reason, performedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CompanyMerge), args.Error(1)
+}
+
+func (m *MockCompanyRepository) GetMerges(offset, limit int) ([]model.CompanyMerge, int64, error) {
+	args := m.Called(offset, limit)
+	return args.Get(0).([]model.CompanyMerge), args.Get(1).(int64), args.Error(2)
+}
@@ -50,14 +50,6 @@ func (m *MockJobRepository) BatchDelete(ids []uint) (*dtos.BatchResult, error) {
 	return args.Get(0).(*dtos.BatchResult), args.Error(1)
 }
 
-func (m *MockJobRepository) GetAll(offset, limit int) ([]model.Job, error) {
-	args := m.Called(offset, limit)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]model.Job), args.Error(1)
-}
-
 func (m *MockJobRepository) SearchJobs(params *dtos.JobSearchParams) ([]model.Job, int64, error) {
 	args := m.Called(params)
 	if args.Get(0) == nil {
@@ -66,6 +58,14 @@ func (m *MockJobRepository) SearchJobs(params *dtos.JobSearchParams) ([]model.Jo
 	return args.Get(0).([]model.Job), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockJobRepository) CountFacet(params *dtos.JobSearchParams, facet string, limit int) ([]dtos.FacetValue, error) {
+	args := m.Called(params, facet, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dtos.FacetValue), args.Error(1)
+}
+
 func (m *MockJobRepository) CountActiveJobs() (int64, error) {
 	args := m.Called()
 	return args.Get(0).(int64), args.Error(1)
@@ -78,6 +78,13 @@ func (m *MockJobRepository) CreateJobSkill(jobSkill *model.JobSkill) (*model.Job
 	}
 	return args.Get(0).(*model.JobSkill), args.Error(1)
 }
+func (m *MockJobRepository) CreateRemoteEligibility(eligibility *model.JobRemoteEligibility) (*model.JobRemoteEligibility, error) {
+	args := m.Called(eligibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.JobRemoteEligibility), args.Error(1)
+}
 func (m *MockJobRepository) CreateJobCategory(jobCategory *model.JobCategory) (*model.JobCategory, error) {
 	args := m.Called(jobCategory)
 	return args.Get(0).(*model.JobCategory), args.Error(1)
@@ -90,3 +97,32 @@ func (m *MockJobRepository) SoftDelete(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *MockJobRepository) SaveTermVector(jobID uint, terms []string, vector map[string]float64) error {
+	args := m.Called(jobID, terms, vector)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) GetOpenByIDs(ids []uint) ([]model.Job, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) GetDocumentFrequencies(terms []string) (map[string]int64, int64, error) {
+	args := m.Called(terms)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).(map[string]int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockJobRepository) GetSimilarCandidates(jobID uint, limit int) (map[uint]float64, error) {
+	args := m.Called(jobID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uint]float64), args.Error(1)
+}
@@ -0,0 +1,86 @@
+// internal/job/mocks/job_service_mock.go
+package mocks
+
+import (
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobService struct {
+	mock.Mock
+}
+
+func (m *MockJobService) CreateJob(request dtos.JobRequest) (*dtos.JobResponse, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dtos.JobResponse), args.Error(1)
+}
+
+func (m *MockJobService) GetJobByID(id uint) (*model.Job, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Job), args.Error(1)
+}
+
+func (m *MockJobService) DeleteJob(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockJobService) DeactivateJob(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockJobService) DeleteJobsBatch(ids []uint) (*dtos.BatchResult, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dtos.BatchResult), args.Error(1)
+}
+
+func (m *MockJobService) GetAllJobs(page, pageSize int, cursor string) (*dtos.PaginatedJobsResponse, error) {
+	args := m.Called(page, pageSize, cursor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dtos.PaginatedJobsResponse), args.Error(1)
+}
+
+func (m *MockJobService) SearchJobs(params *dtos.JobSearchParams) (*dtos.PaginatedJobsResponse, error) {
+	args := m.Called(params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dtos.PaginatedJobsResponse), args.Error(1)
+}
+
+func (m *MockJobService) GetJobStats() (*dtos.JobStatsResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dtos.JobStatsResponse), args.Error(1)
+}
+
+func (m *MockJobService) GetCategories() ([]model.Category, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Category), args.Error(1)
+}
+
+func (m *MockJobService) GetSimilarJobs(id uint, limit int) ([]dtos.SimilarJob, error) {
+	args := m.Called(id, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dtos.SimilarJob), args.Error(1)
+}
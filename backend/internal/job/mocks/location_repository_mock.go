@@ -32,3 +32,11 @@ func (m *MockLocationRepository) CreateJobLocation(jobLocation *model.JobLocatio
 	}
 	return args.Get(0).(*model.JobLocation), args.Error(1)
 }
+
+func (m *MockLocationRepository) GetTimezones() ([]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"github.com/bhati00/workova/backend/pkg/suggest"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSuggestRepository struct {
+	mock.Mock
+}
+
+func (m *MockSuggestRepository) GetSuggestionEntries() ([]suggest.Entry, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]suggest.Entry), args.Error(1)
+}
@@ -1,8 +1,14 @@
 package model
 
+// Category is a node of the job-function taxonomy, e.g. Engineering > Backend Engineering.
+// Top-level categories have no parent.
 type Category struct {
-	ID   uint   `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name string `gorm:"type:varchar(100);not null;unique" json:"name"`
+	ID       uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name     string     `gorm:"type:varchar(100);not null;unique" json:"name"`
+	Slug     *string    `gorm:"type:varchar(100);uniqueIndex:idx_categories_slug" json:"slug"`
+	ParentID *uint      `gorm:"index:idx_categories_parent_id" json:"parent_id"`
+	Position int        `gorm:"default:0" json:"-"` // Display order of the seeded taxonomy
+	Children []Category `gorm:"-" json:"children,omitempty"`
 }
 
 func (Category) TableName() string {
@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// Company is an employer. Jobs reference it through CompanyID and keep a copy of the
+// name and profile fields as the source sent them.
+type Company struct {
+	ID             uint    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name           string  `gorm:"size:255;not null" json:"name"`                                  // Display name, as first seen
+	NormalizedName string  `gorm:"size:255;not null;index:idx_companies_normalized_name" json:"-"` // Matching key, see companyname.Normalize
+	Slug           string  `gorm:"size:150;not null;uniqueIndex:idx_companies_slug" json:"slug"`   // URL-friendly identifier
+	Domain         *string `gorm:"size:255;uniqueIndex:idx_companies_domain" json:"domain"`        // Website host, e.g. "acme.io"
+	Website        *string `gorm:"type:text" json:"website"`
+	LogoURL        *string `gorm:"type:text" json:"logo_url"`
+	Size           *string `gorm:"size:50" json:"size"` // "1-10", "11-50", "51-200", etc.
+	Industry       *string `gorm:"size:100" json:"industry"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the Company model
+func (Company) TableName() string {
+	return "companies"
+}
+
+// CompanyAlias is another name a company is known by. Ingestion matches the normalized
+// alias like the company's own normalized name.
+type CompanyAlias struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CompanyID      uint      `gorm:"not null;index:idx_company_aliases_company_id" json:"company_id"`
+	Name           string    `gorm:"size:255;not null" json:"name"`
+	NormalizedName string    `gorm:"size:255;not null;uniqueIndex:idx_company_aliases_normalized_name" json:"normalized_name"`
+	Domain         *string   `gorm:"size:255;uniqueIndex:idx_company_aliases_domain" json:"domain"` // Domain of a merged-away company
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the CompanyAlias model
+func (CompanyAlias) TableName() string {
+	return "company_aliases"
+}
+
+// CompanyMerge is the audit record of a company merged into another. The source
+// company is deleted, so its identity is copied here.
+type CompanyMerge struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	SourceCompanyID uint      `gorm:"not null" json:"source_company_id"`
+	SourceName      string    `gorm:"size:255;not null" json:"source_name"`
+	SourceSlug      string    `gorm:"size:150;not null;index:idx_company_merges_source_slug" json:"source_slug"`
+	SourceDomain    *string   `gorm:"size:255" json:"source_domain"`
+	TargetCompanyID uint      `gorm:"not null;index:idx_company_merges_target_company_id" json:"target_company_id"`
+	TargetSlug      string    `gorm:"size:150;not null" json:"target_slug"`
+	JobsMoved       int64     `json:"jobs_moved"`
+	AliasesMoved    int64     `json:"aliases_moved"`
+	Reason          *string   `gorm:"type:text" json:"reason"`
+	PerformedBy     *string   `gorm:"size:255" json:"performed_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the CompanyMerge model
+func (CompanyMerge) TableName() string {
+	return "company_merges"
+}
@@ -15,21 +15,35 @@ type Job struct {
 
 	// Core job information
 	Title       string  `gorm:"size:255;not null;index:idx_title" json:"title"`
-	Description *string `gorm:"type:longtext" json:"description"` // Changed from 'text' to 'longtext'
+	Description *string `gorm:"type:longtext" json:"description"` // Sanitized HTML
 	Summary     *string `gorm:"type:text" json:"summary"`         // Short description/excerpt
 
-	// Company information. NOTE : 	i need to separate company as a different table
-	CompanyName     string  `gorm:"size:255;not null;index:idx_company" json:"company_name"`
-	CompanySize     *string `gorm:"size:50" json:"company_size"` // "1-10", "11-50", "51-200", etc.
-	CompanyLogoUrl  *string `gorm:"type:text" json:"company_logo_url"`
-	CompanyWebsite  *string `gorm:"type:text" json:"company_website"`
-	CompanyIndustry *string `gorm:"size:100" json:"company_industry"`
+	// Renditions of the sanitized HTML Description, served with ?description_format=
+	DescriptionMarkdown *string `gorm:"type:text" json:"-"`
+	DescriptionText     *string `gorm:"type:text" json:"-"` // Also used for text search and snippets
+
+	// Language of the posting (ISO 639-1) and its stemmed search terms, space-delimited
+	Language    *string `gorm:"size:2;index:idx_language" json:"language"`
+	SearchTerms *string `gorm:"type:text" json:"-"`
+
+	// Company information as sent by the source. The shared profile lives in Company.
+	CompanyID       *uint    `gorm:"index:idx_jobs_company_id" json:"company_id"`
+	Company         *Company `gorm:"foreignKey:CompanyID" json:"company,omitempty"`
+	CompanyName     string   `gorm:"size:255;not null;index:idx_company" json:"company_name"`
+	CompanySize     *string  `gorm:"size:50" json:"company_size"` // "1-10", "11-50", "51-200", etc.
+	CompanyLogoUrl  *string  `gorm:"type:text" json:"company_logo_url"`
+	CompanyWebsite  *string  `gorm:"type:text" json:"company_website"`
+	CompanyIndustry *string  `gorm:"size:100" json:"company_industry"`
 
 	// Employment details
 	JobType         constant.JobType          `gorm:"type:int;not null;default:1;index:idx_job_type" json:"job_type"`   // Default: FullTime
 	WorkMode        constant.WorkMode         `gorm:"type:int;not null;default:1;index:idx_work_mode" json:"work_mode"` // Default: Remote
 	ExperienceLevel *constant.ExperienceLevel `gorm:"type:int;index:idx_experience" json:"experience_level"`            // Nullable
 
+	// Seniority derived from the title or description, kept even when the source sent a level
+	InferredExperienceLevel *constant.ExperienceLevel `gorm:"type:int" json:"inferred_experience_level"`
+	ExperienceLevelSource   *string                   `gorm:"size:20" json:"experience_level_source"` // "aggregator", "title" or "description"
+
 	// Location information (normalized)
 	IsRemote *bool `gorm:"index:idx_remote;default:false" json:"is_remote"`
 
@@ -50,6 +64,7 @@ type Job struct {
 	HealthInsurance  *bool   `json:"health_insurance"`
 	PaidTimeOff      *bool   `json:"paid_time_off"`
 	FlexibleSchedule *bool   `json:"flexible_schedule"`
+	BenefitsSource   *string `gorm:"size:20" json:"benefits_source"` // Provenance of the benefit fields: "aggregator", "description" or "mixed"
 
 	// Application and contact details
 	ApplicationURL   *string `gorm:"type:text" json:"application_url"` // Made nullable since not all jobs might have this
@@ -95,10 +110,18 @@ type Job struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
+	// Computed at query time, only set for geo searches
+	DistanceKm *float64 `gorm:"-" json:"distance_km,omitempty"`
+	// Computed at query time, only set for searches asking for highlights: the title and
+	// summary with matching words marked and excerpts of the description, by field
+	Highlights map[string][]string `gorm:"-" json:"highlights,omitempty"`
+
 	// Foreign key relationships
 	JobSkills     []JobSkill    `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE" json:"job_skills,omitempty"`
 	JobCategories []JobCategory `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE" json:"job_categories,omitempty"`
 	JobLocations  []JobLocation `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE" json:"job_locations,omitempty"`
+
+	RemoteEligibility *JobRemoteEligibility `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE" json:"remote_eligibility,omitempty"`
 }
 
 // TableName specifies the table name for the Job model
@@ -1,5 +1,7 @@
 package model
 
+import "github.com/bhati00/workova/backend/constant"
+
 type Country struct {
 	ID   uint   `gorm:"primaryKey;autoIncrement" json:"id"`
 	Name string `gorm:"type:varchar(100);not null;unique" json:"name"`
@@ -11,12 +13,17 @@ func (Country) TableName() string {
 }
 
 type JobLocation struct {
-	ID        uint    `gorm:"primaryKey;autoIncrement" json:"id"`
-	JobID     uint    `gorm:"index;not null" json:"job_id"`
-	Job       Job     `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE" json:"job"`
-	CountryID uint    `gorm:"index;not null" json:"country_id"`
-	Country   Country `gorm:"foreignKey:CountryID;constraint:OnDelete:CASCADE" json:"country"`
-	City      *string `gorm:"type:varchar(100)" json:"city,omitempty"`
+	ID        uint               `gorm:"primaryKey;autoIncrement" json:"id"`
+	JobID     uint               `gorm:"index;not null" json:"job_id"`
+	Job       Job                `gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE" json:"job"`
+	CountryID uint               `gorm:"index;not null" json:"country_id"`
+	Country   Country            `gorm:"foreignKey:CountryID;constraint:OnDelete:CASCADE" json:"country"`
+	City      *string            `gorm:"type:varchar(100)" json:"city,omitempty"`
+	Region    *string            `gorm:"type:varchar(100)" json:"region,omitempty"` // State, province or Land
+	Latitude  *float64           `json:"latitude,omitempty"`
+	Longitude *float64           `json:"longitude,omitempty"`
+	Timezone  *string            `gorm:"type:varchar(64)" json:"timezone,omitempty"` // IANA timezone, e.g. "Europe/Berlin"
+	WorkMode  *constant.WorkMode `gorm:"type:int" json:"work_mode,omitempty"`        // Remote, onsite or hybrid at this location
 }
 
 func (JobLocation) TableName() string {
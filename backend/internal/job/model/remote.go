@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// JobRemoteEligibility is the structured form of restrictions like "Remote (US only)",
+// "Remote – EMEA" or "must overlap UTC-5 to UTC+1"
+type JobRemoteEligibility struct {
+	ID                         uint     `gorm:"primaryKey;autoIncrement" json:"id"`
+	JobID                      uint     `gorm:"uniqueIndex;not null" json:"job_id"`
+	Worldwide                  bool     `gorm:"default:false" json:"worldwide"`
+	Regions                    *string  `gorm:"type:varchar(255)" json:"regions,omitempty"` // Comma-separated, e.g. "EMEA,EU"
+	TimezoneMinOffset          *float64 `json:"timezone_min_offset,omitempty"`              // Hours from UTC
+	TimezoneMaxOffset          *float64 `json:"timezone_max_offset,omitempty"`              // Hours from UTC
+	WorkAuthorizationRequired  bool     `gorm:"default:false" json:"work_authorization_required"`
+	WorkAuthorizationCountries *string  `gorm:"type:varchar(255)" json:"work_authorization_countries,omitempty"` // Comma-separated ISO codes
+	Source                     string   `gorm:"type:varchar(20)" json:"source"`                                  // "title", "location" or "description"
+
+	// Countries people can work from, regions already expanded
+	Countries []JobRemoteCountry `gorm:"foreignKey:JobID;references:JobID;constraint:OnDelete:CASCADE" json:"countries,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (JobRemoteEligibility) TableName() string {
+	return "job_remote_eligibilities"
+}
+
+type JobRemoteCountry struct {
+	ID         uint   `gorm:"primaryKey;autoIncrement" json:"-"`
+	JobID      uint   `gorm:"index;not null" json:"-"`
+	CountryISO string `gorm:"type:varchar(2);not null" json:"country_iso"`
+}
+
+func (JobRemoteCountry) TableName() string {
+	return "job_remote_countries"
+}
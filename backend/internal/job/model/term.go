@@ -0,0 +1,24 @@
+package model
+
+// JobTerm is one weighted term of a job's TF-IDF vector, see similarity.NewVector
+type JobTerm struct {
+	ID     uint    `gorm:"primaryKey;autoIncrement" json:"-"`
+	JobID  uint    `gorm:"index;not null" json:"job_id"`
+	Term   string  `gorm:"type:varchar(100);not null" json:"term"`
+	Weight float64 `gorm:"not null" json:"weight"`
+}
+
+func (JobTerm) TableName() string {
+	return "job_terms"
+}
+
+// TermDocumentCount is the number of indexed postings containing a term. The empty term
+// counts the indexed postings.
+type TermDocumentCount struct {
+	Term          string `gorm:"primaryKey;type:varchar(100)" json:"term"`
+	DocumentCount int64  `gorm:"not null;default:0" json:"document_count"`
+}
+
+func (TermDocumentCount) TableName() string {
+	return "term_document_counts"
+}
@@ -0,0 +1,151 @@
+package job
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/mocks"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/internal/user"
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// roleUserService authenticates the access token "<role>" as the user <role>@workova.dev
+// with that role
+type roleUserService struct {
+	user.UserService
+}
+
+func (roleUserService) Authenticate(accessToken string) (*usermodel.User, *usermodel.Session, error) {
+	role := usermodel.Role(accessToken)
+	if !role.Valid() {
+		return nil, nil, user.ErrInvalidToken
+	}
+	return &usermodel.User{ID: 1, Email: string(role) + "@workova.dev", Role: role}, &usermodel.Session{ID: 1, UserID: 1}, nil
+}
+
+// scopeAPIKeyService authenticates the API key "<scope>,<scope>" as a key with those scopes
+type scopeAPIKeyService struct {
+	user.APIKeyService
+}
+
+func (scopeAPIKeyService) Authenticate(key string) (*usermodel.APIKey, *dtos.RateLimit, error) {
+	return &usermodel.APIKey{ID: 1, Scopes: key}, nil, nil
+}
+
+// authenticateAs makes the request as a user with the role, see roleUserService
+func authenticateAs(req *http.Request, role usermodel.Role) {
+	req.Header.Set("Authorization", "Bearer "+string(role))
+}
+
+func TestRoutes_Permissions(t *testing.T) {
+	synonymService, _ := newTestSynonymService(t, "swe => software engineer\n")
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(user.Authenticate(roleUserService{}))
+	api := router.Group("/api")
+	NewJobHandler(&mocks.MockJobService{}).RegisterJobRoutes(api)
+	NewCompanyHandler(NewCompanyService(&mocks.MockCompanyRepository{}), &mocks.MockJobService{}).RegisterCompanyAdminRoutes(api)
+	NewSynonymHandler(synonymService).RegisterSynonymAdminRoutes(api)
+
+	// Requests that get past the permission check are invalid, so no handler reaches a
+	// service: allowed roles get allowedStatus instead of 401 or 403.
+	tests := []struct {
+		method        string
+		path          string
+		body          string
+		allowed       []usermodel.Role
+		allowedStatus int
+	}{
+		{http.MethodPost, "/api/jobs", `{`, []usermodel.Role{usermodel.RoleEmployer, usermodel.RoleModerator, usermodel.RoleAdmin}, http.StatusBadRequest},
+		{http.MethodDelete, "/api/jobs/x", "", []usermodel.Role{usermodel.RoleModerator, usermodel.RoleAdmin}, http.StatusBadRequest},
+		{http.MethodPatch, "/api/jobs/x/deactivate", "", []usermodel.Role{usermodel.RoleModerator, usermodel.RoleAdmin}, http.StatusBadRequest},
+		{http.MethodDelete, "/api/jobs/batch", `{`, []usermodel.Role{usermodel.RoleAdmin}, http.StatusBadRequest},
+		{http.MethodGet, "/api/jobs/export?description_format=x", "", []usermodel.Role{usermodel.RoleAdmin}, http.StatusBadRequest},
+		{http.MethodPost, "/api/admin/companies/merge", `{`, []usermodel.Role{usermodel.RoleModerator, usermodel.RoleAdmin}, http.StatusBadRequest},
+		{http.MethodPost, "/api/admin/companies/acme/aliases", `{`, []usermodel.Role{usermodel.RoleModerator, usermodel.RoleAdmin}, http.StatusBadRequest},
+		{http.MethodGet, "/api/admin/synonyms", "", []usermodel.Role{usermodel.RoleAdmin}, http.StatusOK},
+		{http.MethodDelete, "/api/admin/synonyms/x", "", []usermodel.Role{usermodel.RoleAdmin}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusUnauthorized, w.Code, "anonymous: %s", w.Body.String())
+
+			for _, role := range usermodel.Roles {
+				req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+				req.Header.Set("Content-Type", "application/json")
+				authenticateAs(req, role)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				expectedStatus := http.StatusForbidden
+				for _, allowed := range tt.allowed {
+					if role == allowed {
+						expectedStatus = tt.allowedStatus
+					}
+				}
+				assert.Equal(t, expectedStatus, w.Code, "%s: %s", role, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRoutes_ReadsArePublic(t *testing.T) {
+	service := &mocks.MockJobService{}
+	service.On("GetCategories").Return([]model.Category{}, nil)
+
+	w := httptest.NewRecorder()
+	newSearchRouter(service).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/categories", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	service.AssertExpectations(t)
+}
+
+func TestRoutes_APIKeyScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(user.Authenticate(roleUserService{}), user.AuthenticateAPIKey(scopeAPIKeyService{}))
+	api := router.Group("/api")
+	NewJobHandler(&mocks.MockJobService{}).RegisterJobRoutes(api)
+	NewCompanyHandler(NewCompanyService(&mocks.MockCompanyRepository{}), &mocks.MockJobService{}).RegisterCompanyRoutes(api)
+
+	// As in TestRoutes_Permissions, requests that get past the scope check are invalid
+	tests := []struct {
+		method         string
+		path           string
+		body           string
+		scopes         string
+		expectedStatus int
+	}{
+		{http.MethodGet, "/api/jobs/search?page=0", "", "jobs:read", http.StatusBadRequest},
+		{http.MethodGet, "/api/jobs/search?page=0", "", "jobs:write,export", http.StatusForbidden},
+		{http.MethodGet, "/api/jobs/x", "", "export", http.StatusForbidden},
+		{http.MethodGet, "/api/companies/x/jobs", "", "jobs:write", http.StatusForbidden},
+		{http.MethodPost, "/api/jobs", `{`, "jobs:write", http.StatusBadRequest},
+		{http.MethodPost, "/api/jobs", `{`, "jobs:read,export", http.StatusForbidden},
+		{http.MethodGet, "/api/jobs/export?description_format=x", "", "export", http.StatusBadRequest},
+		{http.MethodGet, "/api/jobs/export?description_format=x", "", "jobs:read,jobs:write", http.StatusForbidden},
+		{http.MethodDelete, "/api/jobs/batch", `{`, "jobs:read,jobs:write,export", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path+" "+tt.scopes, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(user.APIKeyHeader, tt.scopes)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -9,6 +9,8 @@ type CategoryRepository interface {
 	Create(category *model.Category) (*model.Category, error)
 	CreateJobCategory(jobCategory *model.JobCategory) (*model.JobCategory, error)
 	GetCategoryByName(name string) (*model.Category, error)
+	GetCategoryBySlug(slug string) (*model.Category, error)
+	// GetAll returns every category in taxonomy order
 	GetAll() ([]model.Category, error)
 }
 
@@ -48,9 +50,16 @@ func (r categoryRepository) GetCategoryByName(name string) (*model.Category, err
 	}
 	return &category, nil
 }
+func (r categoryRepository) GetCategoryBySlug(slug string) (*model.Category, error) {
+	var category model.Category
+	if err := r.db.Where("slug = ?", slug).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
 func (r categoryRepository) GetAll() ([]model.Category, error) {
 	var categories []model.Category
-	if err := r.db.Find(&categories).Error; err != nil {
+	if err := r.db.Order("position, name").Find(&categories).Error; err != nil {
 		return nil, err
 	}
 	return categories, nil
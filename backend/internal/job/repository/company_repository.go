@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openJobCondition restricts jobs to live postings; its single arg is the current time
+const openJobCondition = "jobs.deleted_at IS NULL AND (jobs.expiry_date IS NULL OR jobs.expiry_date > ?)"
+
+type CompanyRepository interface {
+	Create(company *model.Company) (*model.Company, error)
+	Update(company *model.Company) error
+	GetBySlug(slug string) (*model.Company, error)
+	// GetByDomain matches the company's own domain or the domain of a company merged into it
+	GetByDomain(domain string) (*model.Company, error)
+	// GetByNormalizedName matches the company's own normalized name or one of its aliases
+	GetByNormalizedName(normalizedName string) (*model.Company, error)
+	// List returns companies whose name contains query, most open jobs first
+	List(query string, offset, limit int) ([]model.Company, int64, error)
+	CountOpenJobs(companyIDs []uint) (map[uint]int64, error)
+	GetSources(companyIDs []uint) (map[uint][]string, error)
+
+	// Aliases and merges
+	CreateAlias(alias *model.CompanyAlias) (*model.CompanyAlias, error)
+	GetAliases(companyID uint) ([]model.CompanyAlias, error)
+	Merge(source, target *model.Company, reason, performedBy *string) (*model.CompanyMerge, error)
+	GetMerges(offset, limit int) ([]model.CompanyMerge, int64, error)
+}
+
+type companyRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyRepository(db *gorm.DB) companyRepository {
+	return companyRepository{db: db}
+}
+
+// Create inserts the company, suffixing the slug with -2, -3, ... when it is taken
+func (r companyRepository) Create(company *model.Company) (*model.Company, error) {
+	base := company.Slug
+	for i := 2; ; i++ {
+		var count int64
+		if err := r.db.Model(&model.Company{}).Where("slug = ?", company.Slug).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			break
+		}
+		company.Slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	if err := r.db.Create(company).Error; err != nil {
+		return nil, err
+	}
+	return company, nil
+}
+
+func (r companyRepository) Update(company *model.Company) error {
+	return r.db.Save(company).Error
+}
+
+func (r companyRepository) GetBySlug(slug string) (*model.Company, error) {
+	var company model.Company
+	if err := r.db.Where("slug = ?", slug).First(&company).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r companyRepository) GetByDomain(domain string) (*model.Company, error) {
+	var company model.Company
+	err := r.db.Where("domain = ?", domain).
+		Or("id IN (SELECT company_id FROM company_aliases WHERE domain = ?)", domain).
+		First(&company).Error
+	if err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r companyRepository) GetByNormalizedName(normalizedName string) (*model.Company, error) {
+	var company model.Company
+	err := r.db.Where("normalized_name = ? OR id IN (SELECT company_id FROM company_aliases WHERE normalized_name = ?)",
+		normalizedName, normalizedName).Order("id").First(&company).Error
+	if err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r companyRepository) List(query string, offset, limit int) ([]model.Company, int64, error) {
+	var companies []model.Company
+	var total int64
+
+	db := r.db.Model(&model.Company{})
+	if query = strings.TrimSpace(strings.ToLower(query)); query != "" {
+		db = db.Where("LOWER(companies.name) LIKE ? OR companies.normalized_name LIKE ?", "%"+query+"%", "%"+query+"%")
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	openJobs := "(SELECT COUNT(*) FROM jobs WHERE jobs.company_id = companies.id AND " + openJobCondition + ")"
+	err := db.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                openJobs + " DESC, companies.name ASC",
+		Vars:               []interface{}{time.Now()},
+		WithoutParentheses: true,
+	}}).Offset(offset).Limit(limit).Find(&companies).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return companies, total, nil
+}
+
+func (r companyRepository) CountOpenJobs(companyIDs []uint) (map[uint]int64, error) {
+	var rows []struct {
+		CompanyID uint
+		Count     int64
+	}
+	err := r.db.Table("jobs").Select("jobs.company_id, COUNT(*) AS count").
+		Where("jobs.company_id IN ?", companyIDs).Where(openJobCondition, time.Now()).
+		Group("jobs.company_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CompanyID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetSources returns the distinct sources that posted jobs for each company
+func (r companyRepository) GetSources(companyIDs []uint) (map[uint][]string, error) {
+	var rows []struct {
+		CompanyID uint
+		Source    string
+	}
+	err := r.db.Table("jobs").Distinct("jobs.company_id", "jobs.source").
+		Where("jobs.company_id IN ? AND jobs.deleted_at IS NULL", companyIDs).
+		Order("jobs.source").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[uint][]string)
+	for _, row := range rows {
+		sources[row.CompanyID] = append(sources[row.CompanyID], row.Source)
+	}
+	return sources, nil
+}
+
+func (r companyRepository) CreateAlias(alias *model.CompanyAlias) (*model.CompanyAlias, error) {
+	if err := r.db.Create(alias).Error; err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
+
+func (r companyRepository) GetAliases(companyID uint) ([]model.CompanyAlias, error) {
+	var aliases []model.CompanyAlias
+	if err := r.db.Where("company_id = ?", companyID).Order("name").Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// Merge repoints the jobs and aliases of source to target, keeps the source names as
+// aliases, fills the target's empty profile fields from source, deletes source and
+// records the merge, all in one transaction
+func (r companyRepository) Merge(source, target *model.Company, reason, performedBy *string) (*model.CompanyMerge, error) {
+	merge := &model.CompanyMerge{
+		SourceCompanyID: source.ID,
+		SourceName:      source.Name,
+		SourceSlug:      source.Slug,
+		SourceDomain:    source.Domain,
+		TargetCompanyID: target.ID,
+		TargetSlug:      target.Slug,
+		Reason:          reason,
+		PerformedBy:     performedBy,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Soft-deleted jobs move too so they don't point at a deleted company
+		jobs := tx.Unscoped().Model(&model.Job{}).Where("company_id = ?", source.ID).Update("company_id", target.ID)
+		if jobs.Error != nil {
+			return jobs.Error
+		}
+		merge.JobsMoved = jobs.RowsAffected
+
+		aliases := tx.Model(&model.CompanyAlias{}).Where("company_id = ?", source.ID).Update("company_id", target.ID)
+		if aliases.Error != nil {
+			return aliases.Error
+		}
+		merge.AliasesMoved = aliases.RowsAffected
+
+		// The source's name and domain keep matching the target at ingestion
+		alias := model.CompanyAlias{CompanyID: target.ID, Name: source.Name, NormalizedName: source.NormalizedName}
+		if target.Domain != nil && source.Domain != nil && *target.Domain != *source.Domain {
+			alias.Domain = source.Domain
+		}
+		if source.NormalizedName != target.NormalizedName || alias.Domain != nil {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "normalized_name"}},
+				DoUpdates: clause.AssignmentColumns([]string{"company_id", "domain"}),
+			}).Create(&alias).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Delete(&model.Company{}, source.ID).Error; err != nil {
+			return err
+		}
+		if target.Domain == nil {
+			target.Domain = source.Domain
+		}
+		if target.Website == nil {
+			target.Website = source.Website
+		}
+		if target.LogoURL == nil {
+			target.LogoURL = source.LogoURL
+		}
+		if target.Size == nil {
+			target.Size = source.Size
+		}
+		if target.Industry == nil {
+			target.Industry = source.Industry
+		}
+		if err := tx.Save(target).Error; err != nil {
+			return err
+		}
+		return tx.Create(merge).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+func (r companyRepository) GetMerges(offset, limit int) ([]model.CompanyMerge, int64, error) {
+	var merges []model.CompanyMerge
+	var total int64
+	if err := r.db.Model(&model.CompanyMerge{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := r.db.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&merges).Error; err != nil {
+		return nil, 0, err
+	}
+	return merges, total, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// countCacheTTL bounds how stale a cached total can get. Writes through the repository
+	// clear the cache, the TTL covers writes from other processes such as the aggregator.
+	countCacheTTL = 30 * time.Second
+	// countCacheSize caps the number of distinct searches kept
+	countCacheSize = 1000
+)
+
+type countEntry struct {
+	count   int64
+	expires time.Time
+}
+
+// countCache keeps search totals so paging through results doesn't recount them
+type countCache struct {
+	mu      sync.Mutex
+	entries map[string]countEntry
+}
+
+func newCountCache() *countCache {
+	return &countCache{entries: make(map[string]countEntry)}
+}
+
+func (c *countCache) get(key string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return 0, false
+	}
+	return entry.count, true
+}
+
+func (c *countCache) set(key string, count int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if len(c.entries) >= countCacheSize {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= countCacheSize {
+			c.entries = make(map[string]countEntry)
+		}
+	}
+	c.entries[key] = countEntry{count: count, expires: now.Add(countCacheTTL)}
+}
+
+func (c *countCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]countEntry)
+}
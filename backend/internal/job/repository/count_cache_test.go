@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountCache(t *testing.T) {
+	cache := newCountCache()
+
+	_, ok := cache.get("go")
+	assert.False(t, ok)
+
+	cache.set("go", 3)
+	count, ok := cache.get("go")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), count)
+
+	cache.entries["rust"] = countEntry{count: 5, expires: time.Now().Add(-time.Second)}
+	_, ok = cache.get("rust")
+	assert.False(t, ok, "expired entry")
+
+	cache.clear()
+	_, ok = cache.get("go")
+	assert.False(t, ok)
+
+	for i := 0; i < countCacheSize+1; i++ {
+		cache.set(fmt.Sprint(i), int64(i))
+	}
+	assert.LessOrEqual(t, len(cache.entries), countCacheSize)
+	count, ok = cache.get(fmt.Sprint(countCacheSize))
+	assert.True(t, ok, "latest entry survives eviction")
+	assert.Equal(t, int64(countCacheSize), count)
+}
+
+func TestCountKey(t *testing.T) {
+	offset := func(hours float64) *float64 { return &hours }
+	at := func(day int) *time.Time {
+		t := time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	base := dtos.JobSearchParams{Query: "go"}
+
+	t.Run("unserialized filters change the key", func(t *testing.T) {
+		for name, change := range map[string]func(*dtos.JobSearchParams){
+			"Timezones":            func(p *dtos.JobSearchParams) { p.Timezones = []string{"Europe/Berlin"} },
+			"TimezoneOffset":       func(p *dtos.JobSearchParams) { p.TimezoneOffset = offset(1) },
+			"RemoteEligibleOffset": func(p *dtos.JobSearchParams) { p.RemoteEligibleOffset = offset(1) },
+			"CreatedAfter":         func(p *dtos.JobSearchParams) { p.CreatedAfter = at(1) },
+			"CreatedBefore":        func(p *dtos.JobSearchParams) { p.CreatedBefore = at(2) },
+		} {
+			changed := base
+			change(&changed)
+			assert.NotEqual(t, mustCountKey(t, &base), mustCountKey(t, &changed), name)
+
+			other := changed
+			change(&other)
+			assert.Equal(t, mustCountKey(t, &changed), mustCountKey(t, &other), name+" set twice")
+		}
+		assert.NotEqual(t,
+			mustCountKey(t, &dtos.JobSearchParams{CreatedAfter: at(1)}),
+			mustCountKey(t, &dtos.JobSearchParams{CreatedAfter: at(2)}))
+	})
+
+	t.Run("paging, sorting and display options share the key", func(t *testing.T) {
+		page := base
+		page.Offset, page.Limit, page.Cursor, page.Keyset = 40, 20, "abc", &dtos.JobKeyset{ID: 3}
+		page.SortBy, page.SortOrder, page.Facets = "salary_max", "asc", []string{"skills"}
+		page.Highlight, page.AutoCorrect = &dtos.HighlightOptions{}, true
+		assert.Equal(t, mustCountKey(t, &base), mustCountKey(t, &page))
+	})
+}
+
+func mustCountKey(t *testing.T, params *dtos.JobSearchParams) string {
+	key, err := countKey(params)
+	require.NoError(t, err)
+	return key
+}
+
+func TestJobRepository_SearchJobsCountsCreatedWindow(t *testing.T) {
+	db := newTestDB(t)
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 3; day++ {
+		job := model.Job{Title: "Go Engineer", CompanyName: "Acme", Source: "test", CreatedAt: start.AddDate(0, 0, day)}
+		require.NoError(t, db.Create(&job).Error)
+	}
+	repo := NewJobRepository(db)
+	search := func(after, before *time.Time) int64 {
+		_, total, err := repo.SearchJobs(&dtos.JobSearchParams{Query: "go", Limit: 10, CreatedAfter: after, CreatedBefore: before})
+		require.NoError(t, err)
+		return total
+	}
+	day := func(n int) *time.Time {
+		t := start.AddDate(0, 0, n)
+		return &t
+	}
+
+	// Each window is counted, not served from the total of the one before
+	assert.Equal(t, int64(3), search(nil, nil))
+	assert.Equal(t, int64(2), search(day(0), nil))
+	assert.Equal(t, int64(1), search(day(0), day(1)))
+	assert.Equal(t, int64(0), search(day(2), nil))
+}
@@ -2,15 +2,47 @@
 package repository
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/bhati00/workova/backend/constant"
 	"github.com/bhati00/workova/backend/dtos"
 	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/pkg/enrichment"
+	"github.com/bhati00/workova/backend/pkg/geo"
+	"github.com/bhati00/workova/backend/pkg/language"
+	"github.com/bhati00/workova/backend/pkg/synonym"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const MaxBatchSize = 100
 
+// squaredDistanceSQL is the squared equirectangular distance in degrees between a job
+// location and a point; args are lat, lat, lng, lng, longitude scale
+const squaredDistanceSQL = `((job_locations.latitude - ?) * (job_locations.latitude - ?)
+	+ (job_locations.longitude - ?) * (job_locations.longitude - ?) * ?)`
+
+// minSalarySQL and maxSalarySQL are the min_salary and max_salary filters, shared with the
+// salary facet so each bucket counts the jobs its filter returns; args are the amount twice
+const (
+	minSalarySQL = "(jobs.salary_min >= ? OR jobs.salary_max >= ?)"
+	maxSalarySQL = "(jobs.salary_max <= ? OR jobs.salary_min <= ?)"
+)
+
+// categoryDescendants selects the ids of categories matched by slug or lower-cased name
+// and of all their descendants; args are the slugs and the lower-cased names
+const categoryDescendants = `WITH RECURSIVE category_tree(id) AS (
+		SELECT categories.id FROM categories WHERE categories.slug IN ? OR LOWER(categories.name) IN ?
+		UNION
+		SELECT categories.id FROM categories JOIN category_tree ON categories.parent_id = category_tree.id
+	) SELECT id FROM category_tree`
+
 // JobRepository interface defines all job-related database operations
 type JobRepository interface {
 	// Single operations
@@ -22,25 +54,42 @@ type JobRepository interface {
 	CreateJobLocation(location *model.JobLocation) (*model.JobLocation, error)
 	CreateJobCategory(category *model.JobCategory) (*model.JobCategory, error)
 	CreateJobSkill(skill *model.JobSkill) (*model.JobSkill, error)
+	CreateRemoteEligibility(eligibility *model.JobRemoteEligibility) (*model.JobRemoteEligibility, error)
+	// SaveTermVector stores the TF-IDF vector of a job and counts its distinct terms in the
+	// document frequencies
+	SaveTermVector(jobID uint, terms []string, vector map[string]float64) error
 	// IsDuplicateJob(externalJobID *string, slug *string) (bool, error)
 
 	// Batch operations
 	BatchDelete(ids []uint) (*dtos.BatchResult, error)
 
 	// Query operations
-	GetAll(offset, limit int) ([]model.Job, error)
 	SearchJobs(searchParams *dtos.JobSearchParams) ([]model.Job, int64, error)
+	// CountFacet counts the jobs matching searchParams per value of a facet. Enum facets
+	// return the numeric value without a label; limit 0 returns every value.
+	CountFacet(searchParams *dtos.JobSearchParams, facet string, limit int) ([]dtos.FacetValue, error)
 	CountActiveJobs() (int64, error)
+	// GetOpenByIDs returns the open jobs among ids with their associations, in no particular order
+	GetOpenByIDs(ids []uint) ([]model.Job, error)
+
+	// Similarity
+	// GetDocumentFrequencies returns the number of indexed postings containing each of terms,
+	// and the number of indexed postings
+	GetDocumentFrequencies(terms []string) (map[string]int64, int64, error)
+	// GetSimilarCandidates returns open jobs sharing the highest weighted terms, skills or
+	// categories with a job, up to limit per kind, with the cosine similarity of their vectors
+	GetSimilarCandidates(jobID uint, limit int) (map[uint]float64, error)
 }
 
 // jobRepository implements JobRepository interface
 type jobRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	counts *countCache
 }
 
 // NewJobRepository creates a new job repository instance
 func NewJobRepository(db *gorm.DB) JobRepository {
-	return &jobRepository{db: db}
+	return &jobRepository{db: db, counts: newCountCache()}
 }
 
 // Create creates a new job record
@@ -48,6 +97,7 @@ func (r *jobRepository) Create(job *model.Job) (*model.Job, error) {
 	if err := r.db.Create(job).Error; err != nil {
 		return nil, err
 	}
+	r.counts.clear()
 	return job, nil
 }
 
@@ -56,6 +106,7 @@ func (r *jobRepository) CreateJobLocation(jobLocation *model.JobLocation) (*mode
 	if err := r.db.Create(jobLocation).Error; err != nil {
 		return nil, err
 	}
+	r.counts.clear()
 	return jobLocation, nil
 }
 
@@ -64,6 +115,7 @@ func (r *jobRepository) CreateJobCategory(jobCategory *model.JobCategory) (*mode
 	if err := r.db.Create(jobCategory).Error; err != nil {
 		return nil, err
 	}
+	r.counts.clear()
 	return jobCategory, nil
 }
 
@@ -72,15 +124,27 @@ func (r *jobRepository) CreateJobSkill(jobSkill *model.JobSkill) (*model.JobSkil
 	if err := r.db.Create(jobSkill).Error; err != nil {
 		return nil, err
 	}
+	r.counts.clear()
 	return jobSkill, nil
 }
 
+// Create remote eligibility record together with its countries
+func (r *jobRepository) CreateRemoteEligibility(eligibility *model.JobRemoteEligibility) (*model.JobRemoteEligibility, error) {
+	if err := r.db.Create(eligibility).Error; err != nil {
+		return nil, err
+	}
+	r.counts.clear()
+	return eligibility, nil
+}
+
 // GetByID retrieves a job by its primary key ID
 func (r *jobRepository) GetByID(id uint) (*model.Job, error) {
 	var job model.Job
-	err := r.db.Preload("job_skills").
-		Preload("job_categories").
-		Preload("job_locations").
+	err := r.db.Preload("JobSkills.Skill").
+		Preload("JobCategories.Category").
+		Preload("JobLocations.Country").
+		Preload("RemoteEligibility.Countries").
+		Preload("Company").
 		First(&job, id).Error
 	if err != nil {
 		return nil, err
@@ -90,21 +154,28 @@ func (r *jobRepository) GetByID(id uint) (*model.Job, error) {
 
 // Update updates an existing job record
 func (r *jobRepository) Update(job *model.Job) error {
+	defer r.counts.clear()
 	return r.db.Save(job).Error
 }
 
 // Delete hard deletes a job record
 func (r *jobRepository) Delete(id uint) error {
-	return r.db.Select("job_skills", "job_categories", "job_locations").Delete(&model.Job{}, id).Error
+	defer r.counts.clear()
+	if err := r.deleteDetachedRows([]uint{id}); err != nil {
+		return err
+	}
+	return r.db.Select("JobSkills", "JobCategories", "JobLocations", "RemoteEligibility").Delete(&model.Job{}, id).Error
 }
 
 // SoftDelete soft deletes a job record
 func (r *jobRepository) SoftDelete(id uint) error {
+	defer r.counts.clear()
 	return r.db.Delete(&model.Job{}, id).Error
 }
 
 // BatchDelete deletes multiple jobs by their primary key IDs
 func (r *jobRepository) BatchDelete(ids []uint) (*dtos.BatchResult, error) {
+	defer r.counts.clear()
 	result := &dtos.BatchResult{
 		TotalProcessed: len(ids),
 		Successful:     0,
@@ -122,11 +193,14 @@ func (r *jobRepository) BatchDelete(ids []uint) (*dtos.BatchResult, error) {
 		batch := ids[i:end]
 
 		// Try batch delete first
-		tx := r.db.Select("job_skills", "job_categories", "job_locations").Delete(&model.Job{}, batch)
+		if err := r.deleteDetachedRows(batch); err != nil {
+			log.Printf("Failed to delete remote countries and terms of jobs %v: %v", batch, err)
+		}
+		tx := r.db.Select("JobSkills", "JobCategories", "JobLocations", "RemoteEligibility").Delete(&model.Job{}, batch)
 		if tx.Error != nil {
 			// If batch fails, try individual deletes
 			for j, id := range batch {
-				if err := r.db.Select("job_skills", "job_categories", "job_locations").Delete(&model.Job{}, id).Error; err != nil {
+				if err := r.db.Select("JobSkills", "JobCategories", "JobLocations", "RemoteEligibility").Delete(&model.Job{}, id).Error; err != nil {
 					result.Failed++
 					result.Errors = append(result.Errors, dtos.BatchError{
 						Index: i + j,
@@ -146,13 +220,14 @@ func (r *jobRepository) BatchDelete(ids []uint) (*dtos.BatchResult, error) {
 	return result, nil
 }
 
-// GetAll retrieves all jobs with pagination
-func (r *jobRepository) GetAll(offset, limit int) ([]model.Job, error) {
-	var jobs []model.Job
-	err := r.db.Preload("job_skills").
-		Preload("job_categories").
-		Preload("job_locations").Offset(offset).Limit(limit).Find(&jobs).Error
-	return jobs, err
+// deleteDetachedRows removes the rows of jobs that deleting a job with its associations
+// doesn't reach: the countries of remote eligibilities, which hang off the eligibility, and
+// the term vectors, which the Job model doesn't load
+func (r *jobRepository) deleteDetachedRows(jobIDs []uint) error {
+	if err := r.db.Where("job_id IN ?", jobIDs).Delete(&model.JobRemoteCountry{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("job_id IN ?", jobIDs).Delete(&model.JobTerm{}).Error
 }
 
 // CountActiveJobs returns the count of active jobs
@@ -162,45 +237,354 @@ func (r *jobRepository) CountActiveJobs() (int64, error) {
 	return count, err
 }
 
-// SearchJobs searches jobs based on various parameters
-func (r *jobRepository) SearchJobs(params *dtos.JobSearchParams) ([]model.Job, int64, error) {
+// GetOpenByIDs returns the open jobs among ids with their associations
+func (r *jobRepository) GetOpenByIDs(ids []uint) ([]model.Job, error) {
 	var jobs []model.Job
-	var total int64
-
-	query := r.db.Preload("job_skills").
-		Preload("job_categories").
-		Preload("job_locations")
+	err := r.db.Preload("JobSkills.Skill").
+		Preload("JobCategories.Category").
+		Preload("JobLocations.Country").
+		Preload("RemoteEligibility.Countries").
+		Preload("Company").
+		Where("jobs.id IN ?", ids).
+		Where(openJobCondition, time.Now()).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
 
-	// Apply filters
-	query = r.applySearchFilters(query, params)
+// SearchJobs searches jobs based on various parameters. With params.Keyset the page
+// starts after (or ends before) that position instead of at params.Offset.
+func (r *jobRepository) SearchJobs(params *dtos.JobSearchParams) ([]model.Job, int64, error) {
+	var jobs []model.Job
 
-	// Get total count
-	if err := query.Count(&total).Error; err != nil {
+	total, err := r.countSearch(params)
+	if err != nil {
 		return nil, 0, err
 	}
 
-	// Apply pagination and get results
-	if err := query.Offset(params.Offset).Limit(params.Limit).Find(&jobs).Error; err != nil {
+	query := r.db.Model(&model.Job{}).
+		Preload("JobSkills.Skill").
+		Preload("JobCategories.Category").
+		Preload("JobLocations.Country").
+		Preload("RemoteEligibility.Countries").
+		Preload("Company")
+
+	// Apply filters, sorting and pagination and get results
+	query = r.applySearchFilters(query, params)
+	query = r.applySearchSort(query, params)
+	if params.Keyset == nil {
+		query = query.Offset(params.Offset)
+	}
+	if err := query.Limit(params.Limit).Find(&jobs).Error; err != nil {
 		return nil, 0, err
 	}
 
+	// Backward pages are read in reverse order
+	if params.Keyset != nil && params.Keyset.Backward {
+		for i, j := 0, len(jobs)-1; i < j; i, j = i+1, j-1 {
+			jobs[i], jobs[j] = jobs[j], jobs[i]
+		}
+	}
 	return jobs, total, nil
 }
 
+// countSearch counts the jobs matching params, cached by filters since every page of a
+// search has the same total
+func (r *jobRepository) countSearch(params *dtos.JobSearchParams) (int64, error) {
+	key, err := countKey(params)
+	if err != nil {
+		return 0, err
+	}
+
+	if total, ok := r.counts.get(key); ok {
+		return total, nil
+	}
+	var total int64
+	if err := r.applySearchFilters(r.db.Model(&model.Job{}), params).Count(&total).Error; err != nil {
+		return 0, err
+	}
+	r.counts.set(key, total)
+	return total, nil
+}
+
+// countKey is the count cache key of params: its filters without paging, sorting and
+// display options, plus the filters resolved by the service that aren't serialized
+func countKey(params *dtos.JobSearchParams) (string, error) {
+	filters := *params
+	filters.Offset, filters.Limit, filters.Cursor, filters.Keyset = 0, 0, "", nil
+	filters.SortBy, filters.SortOrder, filters.Facets, filters.Highlight = "", "", nil, nil
+	filters.AutoCorrect = false
+	key, err := json.Marshal(struct {
+		Filters              dtos.JobSearchParams
+		Timezones            []string
+		TimezoneOffset       *float64
+		RemoteEligibleOffset *float64
+		CreatedAfter         *time.Time
+		CreatedBefore        *time.Time
+	}{filters, params.Timezones, params.TimezoneOffset, params.RemoteEligibleOffset, params.CreatedAfter, params.CreatedBefore})
+	if err != nil {
+		return "", fmt.Errorf("failed to build count key: %w", err)
+	}
+	return string(key), nil
+}
+
+// SalaryFacetBounds are the upper bounds of the salary facet buckets, in the job's currency.
+// A job falls in every bucket its salary range overlaps, bounds included, like the
+// min_salary/max_salary filters.
+var SalaryFacetBounds = []int{50000, 100000, 150000, 200000}
+
+// PostedDateFacetWindows are the posted-date facet buckets, each counting the jobs posted
+// within that many days
+var PostedDateFacetWindows = []int{1, 3, 7, 30}
+
+// CountFacet counts the jobs matching params per value of a facet, most jobs first.
+// Salary and posted-date buckets keep their natural order and skip empty buckets.
+func (r *jobRepository) CountFacet(params *dtos.JobSearchParams, facet string, limit int) ([]dtos.FacetValue, error) {
+	ids := r.applySearchFilters(r.db.Model(&model.Job{}).Select("jobs.id"), params)
+
+	var query *gorm.DB
+	switch facet {
+	case dtos.FacetWorkMode:
+		// Same as the filter: a job has its own work mode and those of its locations
+		query = r.db.Raw(`SELECT CAST(modes.value AS TEXT) AS value, '' AS label, COUNT(DISTINCT modes.job_id) AS count FROM (
+				SELECT jobs.id AS job_id, jobs.work_mode AS value FROM jobs
+				UNION SELECT job_locations.job_id, job_locations.work_mode FROM job_locations
+			) AS modes
+			WHERE modes.value IS NOT NULL AND modes.job_id IN (?)
+			GROUP BY modes.value`, ids)
+	case dtos.FacetJobType, dtos.FacetExperienceLevel, dtos.FacetSource:
+		column := "jobs." + facet
+		query = r.db.Raw(`SELECT CAST(`+column+` AS TEXT) AS value, '' AS label, COUNT(*) AS count FROM jobs
+			WHERE `+column+` IS NOT NULL AND jobs.id IN (?)
+			GROUP BY `+column, ids)
+	case dtos.FacetCountry:
+		query = r.db.Raw(`SELECT countries.iso AS value, countries.name AS label, COUNT(DISTINCT job_locations.job_id) AS count
+			FROM job_locations JOIN countries ON countries.id = job_locations.country_id
+			WHERE job_locations.job_id IN (?)
+			GROUP BY countries.id`, ids)
+	case dtos.FacetSkills:
+		query = r.db.Raw(`SELECT skills.name AS value, skills.name AS label, COUNT(DISTINCT job_skills.job_id) AS count
+			FROM job_skills JOIN skills ON skills.id = job_skills.skill_id
+			WHERE job_skills.job_id IN (?)
+			GROUP BY skills.id`, ids)
+	case dtos.FacetCategory:
+		// A category counts the jobs of its subcategories too, like the filter
+		query = r.db.Raw(`WITH RECURSIVE category_closure(ancestor_id, id) AS (
+				SELECT categories.id, categories.id FROM categories
+				UNION
+				SELECT category_closure.ancestor_id, categories.id FROM categories
+				JOIN category_closure ON categories.parent_id = category_closure.id
+			)
+			SELECT COALESCE(categories.slug, categories.name) AS value, categories.name AS label,
+				COUNT(DISTINCT job_categories.job_id) AS count
+			FROM category_closure
+			JOIN job_categories ON job_categories.category_id = category_closure.id
+			JOIN categories ON categories.id = category_closure.ancestor_id
+			WHERE job_categories.job_id IN (?)
+			GROUP BY categories.id`, ids)
+	case dtos.FacetSalary:
+		return r.countSalaryBuckets(ids)
+	case dtos.FacetPostedDate:
+		return r.countPostedDateBuckets(ids)
+	default:
+		return nil, fmt.Errorf("unknown facet %q", facet)
+	}
+
+	sql, args := "SELECT value, label, count FROM (?) AS facet ORDER BY count DESC, value", []interface{}{query}
+	if limit > 0 {
+		sql += " LIMIT ?"
+		args = append(args, limit)
+	}
+	var values []dtos.FacetValue
+	if err := r.db.Raw(sql, args...).Scan(&values).Error; err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// countSalaryBuckets counts jobs per SalaryFacetBounds bucket. Values are "min-max"
+// ranges for min_salary/max_salary, the last one open-ended, and each bucket counts what
+// those filters return: a job whose salary range touches several buckets counts in each.
+func (r *jobRepository) countSalaryBuckets(ids *gorm.DB) ([]dtos.FacetValue, error) {
+	var sums []string
+	var args []interface{}
+	for i := 0; i <= len(SalaryFacetBounds); i++ {
+		low := 0
+		if i > 0 {
+			low = SalaryFacetBounds[i-1]
+		}
+		if i == len(SalaryFacetBounds) {
+			sums = append(sums, "SUM(CASE WHEN "+minSalarySQL+" THEN 1 ELSE 0 END)")
+			args = append(args, low, low)
+			continue
+		}
+		sums = append(sums, "SUM(CASE WHEN "+minSalarySQL+" AND "+maxSalarySQL+" THEN 1 ELSE 0 END)")
+		args = append(args, low, low, SalaryFacetBounds[i], SalaryFacetBounds[i])
+	}
+	args = append(args, ids)
+
+	counts := make([]sql.NullInt64, len(SalaryFacetBounds)+1)
+	dest := make([]interface{}, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	if err := r.db.Raw("SELECT "+strings.Join(sums, ", ")+" FROM jobs WHERE jobs.id IN (?)", args...).Row().Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	var values []dtos.FacetValue
+	for i, count := range counts {
+		if count.Int64 == 0 {
+			continue
+		}
+		low := 0
+		if i > 0 {
+			low = SalaryFacetBounds[i-1]
+		}
+		value := dtos.FacetValue{Value: strconv.Itoa(low) + "-", Label: formatThousands(low) + "+", Count: count.Int64}
+		if i < len(SalaryFacetBounds) {
+			high := SalaryFacetBounds[i]
+			value.Value += strconv.Itoa(high)
+			value.Label = formatThousands(low) + "–" + formatThousands(high)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// countPostedDateBuckets counts the jobs posted within each of PostedDateFacetWindows.
+// Buckets are cumulative; values are day counts such as "7d".
+func (r *jobRepository) countPostedDateBuckets(ids *gorm.DB) ([]dtos.FacetValue, error) {
+	now := time.Now()
+	var sums []string
+	var args []interface{}
+	for _, days := range PostedDateFacetWindows {
+		sums = append(sums, "SUM(CASE WHEN jobs.posted_date >= ? THEN 1 ELSE 0 END)")
+		args = append(args, now.AddDate(0, 0, -days))
+	}
+	args = append(args, ids)
+
+	counts := make([]sql.NullInt64, len(PostedDateFacetWindows))
+	dest := make([]interface{}, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	if err := r.db.Raw("SELECT "+strings.Join(sums, ", ")+" FROM jobs WHERE jobs.id IN (?)", args...).Row().Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	var values []dtos.FacetValue
+	for i, days := range PostedDateFacetWindows {
+		if counts[i].Int64 == 0 {
+			continue
+		}
+		label := "Past " + strconv.Itoa(days) + " days"
+		if days == 1 {
+			label = "Past 24 hours"
+		}
+		values = append(values, dtos.FacetValue{Value: strconv.Itoa(days) + "d", Label: label, Count: counts[i].Int64})
+	}
+	return values, nil
+}
+
+// formatThousands shortens round amounts, 150000 becomes "150k"
+func formatThousands(amount int) string {
+	if amount >= 1000 && amount%1000 == 0 {
+		return strconv.Itoa(amount/1000) + "k"
+	}
+	return strconv.Itoa(amount)
+}
+
+// stemmedQueryCondition requires every query word to appear in jobs.search_terms in one
+// of its stemmed forms. Words are stemmed for the filtered languages, or for all
+// supported languages when there is no language filter. A phrase with synonyms also
+// matches when all the words of one of its synonyms appear.
+func stemmedQueryCondition(q string, languages []string) (string, []interface{}) {
+	var groups []string
+	var args []interface{}
+	for _, phrases := range synonym.Default().Expand(q) {
+		var alternatives []string
+		var alternativeArgs []interface{}
+		for _, phrase := range phrases {
+			variants := language.QueryVariants(phrase, languages)
+			if len(variants) == 0 {
+				// Only stop words, which aren't indexed, so the phrase matches anything
+				alternatives = nil
+				break
+			}
+			var words []string
+			for _, forms := range variants {
+				var wordForms []string
+				for _, form := range forms {
+					wordForms = append(wordForms, "jobs.search_terms LIKE ?")
+					alternativeArgs = append(alternativeArgs, "% "+form+" %")
+				}
+				words = append(words, "("+strings.Join(wordForms, " OR ")+")")
+			}
+			alternatives = append(alternatives, strings.Join(words, " AND "))
+		}
+		if len(alternatives) == 0 {
+			continue
+		}
+		groups = append(groups, "("+strings.Join(alternatives, " OR ")+")")
+		args = append(args, alternativeArgs...)
+	}
+	if len(groups) == 0 {
+		return "", nil
+	}
+	return "(" + strings.Join(groups, " AND ") + ")", args
+}
+
+// textSearchCondition matches text in the title, description, company, summary or
+// keywords, or on the stemmed terms so "Entwicklerin" finds "Entwickler"
+func textSearchCondition(text string, languages []string) (string, []interface{}) {
+	searchTerm := "%" + strings.ToLower(text) + "%"
+	conditions := []string{
+		"LOWER(jobs.title) LIKE ?", "LOWER(jobs.description_text) LIKE ?", "LOWER(jobs.company_name) LIKE ?",
+		"LOWER(jobs.summary) LIKE ?", "LOWER(jobs.keywords) LIKE ?",
+	}
+	args := []interface{}{searchTerm, searchTerm, searchTerm, searchTerm, searchTerm}
+	if stemmed, stemmedArgs := stemmedQueryCondition(text, languages); stemmed != "" {
+		conditions = append(conditions, stemmed)
+		args = append(args, stemmedArgs...)
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
 // applySearchFilters applies search filters to the query
 func (r *jobRepository) applySearchFilters(query *gorm.DB, params *dtos.JobSearchParams) *gorm.DB {
 	// Text search in multiple fields
 	if params.Query != "" {
-		searchTerm := "%" + params.Query + "%"
-		query = query.Where(
-			"title ILIKE ? OR description ILIKE ? OR company_name ILIKE ? OR summary ILIKE ? OR keywords ILIKE ?",
-			searchTerm, searchTerm, searchTerm, searchTerm, searchTerm,
-		)
+		sql, args := textSearchCondition(params.Query, params.Language)
+		query = query.Where(sql, args...)
+	}
+
+	// Company filter, by slug
+	if len(params.Company) > 0 {
+		query = query.Where("jobs.company_id IN (SELECT companies.id FROM companies WHERE companies.slug IN ?)", params.Company)
+	}
+
+	// Category filter, including the descendants of each category
+	if len(params.Category) > 0 {
+		names := make([]string, len(params.Category))
+		for i, category := range params.Category {
+			names[i] = strings.ToLower(category)
+		}
+		query = query.Where("jobs.id IN (SELECT job_categories.job_id FROM job_categories WHERE job_categories.category_id IN ("+categoryDescendants+"))", params.Category, names)
+	}
+
+	// Language filter
+	if len(params.Language) > 0 {
+		query = query.Where("jobs.language IN ?", params.Language)
 	}
 
 	// Work mode filter
 	if len(params.WorkMode) > 0 {
-		query = query.Where("work_mode IN ?", params.WorkMode)
+		// A job matches when it or any of its locations has the work mode
+		query = query.Where("jobs.work_mode IN ? OR jobs.id IN (SELECT job_locations.job_id FROM job_locations WHERE job_locations.work_mode IN ?)",
+			params.WorkMode, params.WorkMode)
 	}
 
 	// Job type filter
@@ -213,12 +597,12 @@ func (r *jobRepository) applySearchFilters(query *gorm.DB, params *dtos.JobSearc
 		query = query.Where("experience_level IN ?", params.ExperienceLevel)
 	}
 
-	// Salary filters
+	// Salary filters, jobs whose salary range overlaps the requested one
 	if params.MinSalary != nil {
-		query = query.Where("salary_min >= ? OR salary_max >= ?", *params.MinSalary, *params.MinSalary)
+		query = query.Where(minSalarySQL, *params.MinSalary, *params.MinSalary)
 	}
 	if params.MaxSalary != nil {
-		query = query.Where("salary_max <= ? OR salary_min <= ?", *params.MaxSalary, *params.MaxSalary)
+		query = query.Where(maxSalarySQL, *params.MaxSalary, *params.MaxSalary)
 	}
 
 	// Currency filter
@@ -241,6 +625,25 @@ func (r *jobRepository) applySearchFilters(query *gorm.DB, params *dtos.JobSearc
 	if params.IsUrgent != nil {
 		query = query.Where("is_urgent = ?", *params.IsUrgent)
 	}
+	if params.HealthInsurance != nil {
+		query = query.Where("health_insurance = ?", *params.HealthInsurance)
+	}
+	if params.PaidTimeOff != nil {
+		query = query.Where("paid_time_off = ?", *params.PaidTimeOff)
+	}
+	if params.FlexibleSchedule != nil {
+		query = query.Where("flexible_schedule = ?", *params.FlexibleSchedule)
+	}
+	if params.EquityOffered != nil {
+		query = query.Where("equity_offered = ?", *params.EquityOffered)
+	}
+	if params.Clearance != nil {
+		if *params.Clearance {
+			query = query.Where("security_clearance IS NOT NULL AND security_clearance <> ?", enrichment.ClearanceNone)
+		} else {
+			query = query.Where("(security_clearance IS NULL OR security_clearance = ?)", enrichment.ClearanceNone)
+		}
+	}
 
 	// Company and industry filters
 	if len(params.CompanySize) > 0 {
@@ -275,50 +678,189 @@ func (r *jobRepository) applySearchFilters(query *gorm.DB, params *dtos.JobSearc
 	if params.PostedBefore != nil {
 		query = query.Where("posted_date <= ?", *params.PostedBefore)
 	}
+	if params.CreatedAfter != nil {
+		query = query.Where("jobs.created_at > ?", *params.CreatedAfter)
+	}
+	if params.CreatedBefore != nil {
+		query = query.Where("jobs.created_at <= ?", *params.CreatedBefore)
+	}
 
 	// Contract duration filter
 	if params.ContractDuration != nil {
 		query = query.Where("contract_months_duration = ?", *params.ContractDuration)
 	}
 
-	// Skills filter using junction table
+	// Skills filter, any of the skills by case-insensitive name
 	if len(params.Skills) > 0 {
-		query = query.Joins("JOIN job_skills ON jobs.id = job_skills.job_id").
-			Joins("JOIN skills ON job_skills.skill_id = skills.id").
-			Where("skills.name IN ? OR skills.slug IN ?", params.Skills, params.Skills).
-			Distinct()
+		skills := make([]string, len(params.Skills))
+		for i, skill := range params.Skills {
+			skills[i] = strings.ToLower(skill)
+		}
+		query = query.Where(`jobs.id IN (
+			SELECT job_skills.job_id FROM job_skills
+			JOIN skills ON skills.id = job_skills.skill_id
+			WHERE LOWER(skills.name) IN ?)`, skills)
 	}
 
-	// Location filter using junction table
+	// Location filter matches city, region, country name or ISO code
 	if len(params.Location) > 0 {
-		query = query.Joins("JOIN job_locations ON jobs.id = job_locations.job_id").
-			Where("job_locations.city IN ? OR job_locations.state IN ? OR job_locations.country IN ?",
-				params.Location, params.Location, params.Location).
-			Distinct()
+		locations := make([]string, len(params.Location))
+		for i, location := range params.Location {
+			locations[i] = strings.ToLower(strings.TrimSpace(location))
+		}
+		query = query.Where(`jobs.id IN (
+			SELECT job_locations.job_id FROM job_locations
+			JOIN countries ON countries.id = job_locations.country_id
+			WHERE LOWER(job_locations.city) IN ? OR LOWER(job_locations.region) IN ?
+				OR LOWER(countries.name) IN ? OR LOWER(countries.iso) IN ?)`,
+			locations, locations, locations, locations)
+	}
+
+	// Radius filter: bounding box first so SQLite can use plain comparisons, then an
+	// equirectangular distance check which is accurate enough at city scale
+	if params.Latitude != nil && params.Longitude != nil && params.RadiusKm != nil {
+		lat, lng, radius := *params.Latitude, *params.Longitude, *params.RadiusKm
+		minLat, maxLat, minLng, maxLng := geo.BoundingBox(lat, lng, radius)
+		radiusDeg := geo.KmToDegrees(radius)
+		query = query.Where(`jobs.id IN (
+			SELECT job_locations.job_id FROM job_locations
+			WHERE job_locations.latitude BETWEEN ? AND ? AND job_locations.longitude BETWEEN ? AND ?
+				AND `+squaredDistanceSQL+` <= ?)`,
+			minLat, maxLat, minLng, maxLng, lat, lat, lng, lng, geo.LongitudeScale(lat), radiusDeg*radiusDeg)
+	}
+
+	// Timezone filter, the service resolves tz/tz_overlap_hours into the matching timezones.
+	// Remote jobs often have no located office, they match when some offset of their
+	// eligibility window is within WorkdayHours-overlap hours of the searcher's offset.
+	if params.Timezone != "" {
+		conditions := []string{"jobs.id IN (SELECT job_locations.job_id FROM job_locations WHERE job_locations.timezone IN ?)"}
+		args := []interface{}{params.Timezones}
+		if params.TimezoneOffset != nil && params.TimezoneOverlap != nil {
+			margin := geo.WorkdayHours - float64(*params.TimezoneOverlap)
+			// Shifted by a day too, for windows on the other side of the date line
+			var windows []string
+			for _, offset := range []float64{*params.TimezoneOffset, *params.TimezoneOffset - 24, *params.TimezoneOffset + 24} {
+				windows = append(windows, "(job_remote_eligibilities.timezone_min_offset - ? <= ? AND ? <= job_remote_eligibilities.timezone_max_offset + ?)")
+				args = append(args, margin, offset, offset, margin)
+			}
+			conditions = append(conditions, `jobs.id IN (SELECT job_remote_eligibilities.job_id FROM job_remote_eligibilities
+				WHERE job_remote_eligibilities.timezone_min_offset IS NOT NULL AND job_remote_eligibilities.timezone_max_offset IS NOT NULL
+					AND (`+strings.Join(windows, " OR ")+`))`)
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+
+	// Remote eligibility filter: remote jobs open to the country, either unrestricted or
+	// listing it, and whose timezone window (if any, without a country list) covers it
+	if params.RemoteEligibleIn != "" {
+		query = query.Where("(jobs.is_remote = ? OR jobs.work_mode = ?)", true, constant.WorkModeRemote).
+			Where(`(NOT EXISTS (SELECT 1 FROM job_remote_countries WHERE job_remote_countries.job_id = jobs.id)
+				OR EXISTS (SELECT 1 FROM job_remote_countries WHERE job_remote_countries.job_id = jobs.id
+					AND job_remote_countries.country_iso = ?))`, params.RemoteEligibleIn)
+		if params.RemoteEligibleOffset != nil {
+			offset := *params.RemoteEligibleOffset
+			query = query.Where(`NOT EXISTS (SELECT 1 FROM job_remote_eligibilities
+				WHERE job_remote_eligibilities.job_id = jobs.id
+					AND job_remote_eligibilities.timezone_min_offset IS NOT NULL
+					AND NOT EXISTS (SELECT 1 FROM job_remote_countries WHERE job_remote_countries.job_id = jobs.id)
+					AND (? < job_remote_eligibilities.timezone_min_offset OR ? > job_remote_eligibilities.timezone_max_offset))`,
+				offset, offset)
+		}
+	}
+
+	// Boolean filter tree from POST /jobs/search or the compact query syntax
+	if params.Filter != nil {
+		sql, args, err := compileSearchFilter(params.Filter, params.Language)
+		if err != nil {
+			_ = query.AddError(err)
+			return query
+		}
+		query = query.Where(sql, args...)
 	}
 
-	// Apply sorting
-	if params.SortBy != "" {
+	return query
+}
+
+// sortColumn is a column search results can be sorted and paged by
+type sortColumn struct {
+	column   string
+	nullable bool // NULLs sort last in both directions
+}
+
+var sortColumns = map[string]sortColumn{
+	"created_at":   {"jobs.created_at", false},
+	"updated_at":   {"jobs.updated_at", false},
+	"posted_date":  {"jobs.posted_date", true},
+	"salary_max":   {"jobs.salary_max", true},
+	"salary_min":   {"jobs.salary_min", true},
+	"company_name": {"jobs.company_name", false},
+	"title":        {"jobs.title", false},
+}
+
+// applySearchSort orders search results by params.SortBy, newest first by default, with
+// the ID as tie-breaker so the order is stable for keyset pagination
+func (r *jobRepository) applySearchSort(query *gorm.DB, params *dtos.JobSearchParams) *gorm.DB {
+	descending := params.SortOrder != "asc"
+
+	if params.SortBy == "distance" && params.Latitude != nil && params.Longitude != nil {
 		order := "DESC"
-		if params.SortOrder == "asc" {
+		if !descending {
 			order = "ASC"
 		}
+		lat, lng := *params.Latitude, *params.Longitude
+		return query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL: `(SELECT MIN(` + squaredDistanceSQL + `) FROM job_locations
+				WHERE job_locations.job_id = jobs.id AND job_locations.latitude IS NOT NULL) ` + order + `, jobs.id ` + order,
+			Vars:               []interface{}{lat, lat, lng, lng, geo.LongitudeScale(lat)},
+			WithoutParentheses: true,
+		}})
+	}
 
-		switch params.SortBy {
-		case "created_at", "posted_date", "updated_at":
-			query = query.Order(params.SortBy + " " + order)
-		case "salary_max", "salary_min":
-			query = query.Order(params.SortBy + " " + order + " NULLS LAST")
-		case "company_name", "title":
-			query = query.Order(params.SortBy + " " + order)
-		default:
-			query = query.Order("created_at DESC") // Default sorting
+	sort, ok := sortColumns[params.SortBy]
+	if !ok {
+		sort, descending = sortColumns["created_at"], true // Default sorting
+	}
+
+	// A backward page is read in the opposite order, the repository reverses it
+	if params.Keyset != nil {
+		query = query.Where(keysetCondition(sort, descending, params.Keyset))
+		if params.Keyset.Backward {
+			descending = !descending
 		}
-	} else {
-		query = query.Order("created_at DESC") // Default sorting
 	}
 
-	return query
+	order, nulls := "ASC", " NULLS FIRST"
+	if descending {
+		order = "DESC"
+	}
+	if params.Keyset == nil || !params.Keyset.Backward {
+		nulls = " NULLS LAST"
+	}
+	if !sort.nullable {
+		nulls = ""
+	}
+	return query.Order(sort.column + " " + order + nulls + ", jobs.id " + order)
+}
+
+// keysetCondition selects the rows after the keyset position in the sort order, or
+// before it for a backward keyset. NULLs come after every value.
+func keysetCondition(sort sortColumn, descending bool, keyset *dtos.JobKeyset) clause.Expr {
+	op := ">"
+	if descending != keyset.Backward {
+		op = "<"
+	}
+	column := sort.column
+
+	switch {
+	case keyset.Value == nil && keyset.Backward:
+		return gorm.Expr("(("+column+" IS NULL AND jobs.id "+op+" ?) OR "+column+" IS NOT NULL)", keyset.ID)
+	case keyset.Value == nil:
+		return gorm.Expr("("+column+" IS NULL AND jobs.id "+op+" ?)", keyset.ID)
+	case sort.nullable && !keyset.Backward:
+		return gorm.Expr("("+column+" "+op+" ? OR ("+column+" = ? AND jobs.id "+op+" ?) OR "+column+" IS NULL)",
+			keyset.Value, keyset.Value, keyset.ID)
+	}
+	return gorm.Expr("("+column+" "+op+" ? OR ("+column+" = ? AND jobs.id "+op+" ?))", keyset.Value, keyset.Value, keyset.ID)
 }
 
 // func (r *jobRepository) IsDuplicateJob(externalJobID *string, slug *string) (bool, error) {
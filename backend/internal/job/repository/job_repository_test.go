@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/constant"
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// searchIDs returns the IDs of the jobs SearchJobs finds for params, in result order
+func searchIDs(t *testing.T, repo JobRepository, params dtos.JobSearchParams) []uint {
+	if params.Limit == 0 {
+		params.Limit = 20
+	}
+	jobs, _, err := repo.SearchJobs(&params)
+	require.NoError(t, err)
+	ids := []uint{}
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+func TestJobRepository_SearchJobsTimezone(t *testing.T) {
+	db := newTestDB(t)
+	window := func(min, max float64) *model.JobRemoteEligibility {
+		return &model.JobRemoteEligibility{TimezoneMinOffset: &min, TimezoneMaxOffset: &max}
+	}
+	jobs := []model.Job{
+		{Title: "Berlin office", CompanyName: "Acme", Source: "test", JobLocations: []model.JobLocation{
+			{Country: model.Country{Name: "Germany", ISO: "DE"}, Timezone: utils.String("Europe/Berlin")},
+		}},
+		{Title: "Remote, CET overlap", CompanyName: "Acme", Source: "test", IsRemote: utils.Bool(true), RemoteEligibility: window(0, 1)},
+		{Title: "Remote, US hours", CompanyName: "Acme", Source: "test", IsRemote: utils.Bool(true), RemoteEligibility: window(-8, -5)},
+		{Title: "Remote, New Zealand", CompanyName: "Acme", Source: "test", IsRemote: utils.Bool(true), RemoteEligibility: window(12, 13)},
+		{Title: "Remote, anywhere", CompanyName: "Acme", Source: "test", IsRemote: utils.Bool(true), RemoteEligibility: &model.JobRemoteEligibility{Worldwide: true}},
+	}
+	require.NoError(t, db.Create(&jobs).Error)
+	repo := NewJobRepository(db)
+	offset := func(hours float64) *float64 { return &hours }
+	overlap := func(hours int) *int { return &hours }
+
+	tests := []struct {
+		name   string
+		params dtos.JobSearchParams
+		want   []uint
+	}{
+		{
+			name:   "located timezone or eligibility window",
+			params: dtos.JobSearchParams{Timezone: "Europe/London", TimezoneOverlap: overlap(1), TimezoneOffset: offset(0), Timezones: []string{"Europe/Berlin"}},
+			want:   []uint{1, 2, 3},
+		},
+		{
+			name:   "window too far for the overlap",
+			params: dtos.JobSearchParams{Timezone: "Europe/London", TimezoneOverlap: overlap(4), TimezoneOffset: offset(0), Timezones: []string{"Europe/Berlin"}},
+			want:   []uint{1, 2},
+		},
+		{
+			name:   "no located timezone qualifies",
+			params: dtos.JobSearchParams{Timezone: "America/New_York", TimezoneOverlap: overlap(4), TimezoneOffset: offset(-5), Timezones: []string{}},
+			want:   []uint{3},
+		},
+		{
+			name:   "window across the date line",
+			params: dtos.JobSearchParams{Timezone: "Pacific/Pago_Pago", TimezoneOverlap: overlap(6), TimezoneOffset: offset(-11), Timezones: []string{}},
+			want:   []uint{4},
+		},
+		{
+			name:   "without a resolved offset",
+			params: dtos.JobSearchParams{Timezone: "Europe/London", Timezones: []string{"Europe/Berlin"}},
+			want:   []uint{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.want, searchIDs(t, repo, tt.params))
+		})
+	}
+}
+
+func TestJobRepository_SalaryFacetMatchesFilter(t *testing.T) {
+	db := newTestDB(t)
+	salary := func(min, max *int) model.Job {
+		return model.Job{Title: "Engineer", CompanyName: "Acme", Source: "test", SalaryMin: min, SalaryMax: max}
+	}
+	jobs := []model.Job{
+		salary(utils.Int(40000), utils.Int(60000)),   // Across the 50k bound
+		salary(utils.Int(80000), utils.Int(100000)),  // Up to a bound
+		salary(utils.Int(100000), utils.Int(120000)), // From a bound
+		salary(nil, utils.Int(90000)),
+		salary(utils.Int(180000), nil),
+		salary(utils.Int(120000), utils.Int(250000)),
+		salary(nil, nil),
+	}
+	require.NoError(t, db.Create(&jobs).Error)
+	repo := NewJobRepository(db)
+
+	values, err := repo.CountFacet(&dtos.JobSearchParams{}, dtos.FacetSalary, 0)
+	require.NoError(t, err)
+	require.Len(t, values, len(SalaryFacetBounds)+1)
+
+	// Selecting a bucket returns exactly the jobs it counts
+	counts := []int64{}
+	for _, value := range values {
+		counts = append(counts, value.Count)
+		low, high, _ := strings.Cut(value.Value, "-")
+		params := &dtos.JobSearchParams{Limit: 20}
+		params.MinSalary = utils.Int(mustAtoi(t, low))
+		if high != "" {
+			params.MaxSalary = utils.Int(mustAtoi(t, high))
+		}
+		_, total, err := repo.SearchJobs(params)
+		require.NoError(t, err)
+		assert.Equal(t, value.Count, total, value.Value)
+	}
+	assert.Equal(t, []int64{1, 4, 3, 2, 1}, counts)
+}
+
+func mustAtoi(t *testing.T, s string) int {
+	n, err := strconv.Atoi(s)
+	require.NoError(t, err)
+	return n
+}
+
+func TestJobRepository_SearchJobsRadius(t *testing.T) {
+	db := newTestDB(t)
+	germany := model.Country{Name: "Germany", ISO: "DE"}
+	require.NoError(t, db.Create(&germany).Error)
+	at := func(city string, lat, lng float64) model.JobLocation {
+		return model.JobLocation{CountryID: germany.ID, City: utils.String(city), Latitude: &lat, Longitude: &lng}
+	}
+	job := func(title string, locations ...model.JobLocation) model.Job {
+		return model.Job{Title: title, CompanyName: "Acme", Source: "test", JobLocations: locations}
+	}
+	jobs := []model.Job{
+		job("Berlin", at("Berlin", 52.52, 13.405)),
+		job("Potsdam", at("Potsdam", 52.39, 13.06)),                                    // 27 km
+		job("Hamburg", at("Hamburg", 53.55, 9.99)),                                     // 255 km
+		job("Munich", at("Munich", 48.14, 11.58)),                                      // 504 km
+		job("Germany", model.JobLocation{CountryID: germany.ID}),                       // No coordinates
+		job("Paris or Leipzig", at("Paris", 48.86, 2.35), at("Leipzig", 51.34, 12.37)), // 150 km
+		job("Box corner", at("Frankfurt (Oder)", 52.92, 14.105)),                       // 65 km, in the 50 km bounding box
+	}
+	require.NoError(t, db.Create(&jobs).Error)
+	repo := NewJobRepository(db)
+
+	search := func(radius float64, sortOrder string) []uint {
+		lat, lng := 52.52, 13.405
+		return searchIDs(t, repo, dtos.JobSearchParams{Latitude: &lat, Longitude: &lng, RadiusKm: &radius,
+			SortBy: "distance", SortOrder: sortOrder})
+	}
+
+	assert.Equal(t, []uint{1, 2}, search(50, "asc"))
+	assert.Equal(t, []uint{1, 2, 7, 6}, search(200, "asc"), "nearest location of a multi-location job")
+	assert.Equal(t, []uint{3, 6, 7, 2, 1}, search(300, "desc"))
+	assert.Equal(t, []uint{1, 2, 7, 6, 3, 4}, search(600, "asc"))
+}
+
+func TestJobRepository_SearchJobsRemoteEligibleIn(t *testing.T) {
+	db := newTestDB(t)
+	remote := func(title string, eligibility *model.JobRemoteEligibility) model.Job {
+		return model.Job{Title: title, CompanyName: "Acme", Source: "test", IsRemote: utils.Bool(true),
+			WorkMode: constant.WorkModeRemote, RemoteEligibility: eligibility}
+	}
+	countries := func(isos ...string) []model.JobRemoteCountry {
+		list := make([]model.JobRemoteCountry, len(isos))
+		for i, iso := range isos {
+			list[i] = model.JobRemoteCountry{CountryISO: iso}
+		}
+		return list
+	}
+	min, max := -8.0, -5.0
+	jobs := []model.Job{
+		remote("Anywhere", nil),
+		remote("Europe", &model.JobRemoteEligibility{Countries: countries("DE", "FR")}),
+		remote("US hours", &model.JobRemoteEligibility{TimezoneMinOffset: &min, TimezoneMaxOffset: &max}),
+		remote("US only, US hours", &model.JobRemoteEligibility{TimezoneMinOffset: &min, TimezoneMaxOffset: &max, Countries: countries("US")}),
+		{Title: "Onsite", CompanyName: "Acme", Source: "test", IsRemote: utils.Bool(false), WorkMode: constant.WorkModeOnsite},
+		{Title: "Remote by work mode", CompanyName: "Acme", Source: "test", IsRemote: utils.Bool(false), WorkMode: constant.WorkModeRemote},
+	}
+	require.NoError(t, db.Create(&jobs).Error)
+	repo := NewJobRepository(db)
+	offset := func(hours float64) *float64 { return &hours }
+
+	tests := []struct {
+		name    string
+		country string
+		offset  *float64
+		want    []uint
+	}{
+		{"listed country", "DE", offset(1), []uint{1, 2, 6}},
+		{"window covers the offset", "US", offset(-5), []uint{1, 3, 4, 6}},
+		{"country list wins over the window", "CA", offset(-5), []uint{1, 3, 6}},
+		{"window misses the offset", "GB", offset(0), []uint{1, 6}},
+		{"no offset resolved", "GB", nil, []uint{1, 3, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := searchIDs(t, repo, dtos.JobSearchParams{RemoteEligibleIn: tt.country, RemoteEligibleOffset: tt.offset})
+			assert.ElementsMatch(t, tt.want, ids)
+		})
+	}
+}
+
+func TestJobRepository_SearchJobsKeysetPages(t *testing.T) {
+	db := newTestDB(t)
+	day := func(n int) *time.Time {
+		t := time.Date(2025, 3, n, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	job := func(salary *int, posted *time.Time) model.Job {
+		return model.Job{Title: "Engineer", CompanyName: "Acme", Source: "test", SalaryMax: salary, PostedDate: posted}
+	}
+	jobs := []model.Job{
+		job(utils.Int(100), day(3)),
+		job(nil, day(1)),
+		job(utils.Int(200), nil),
+		job(utils.Int(100), day(2)),
+		job(nil, nil),
+		job(utils.Int(300), day(2)),
+		job(utils.Int(200), day(5)),
+	}
+	require.NoError(t, db.Create(&jobs).Error)
+	repo := NewJobRepository(db)
+
+	tests := []struct {
+		sortBy, sortOrder string
+		value             func(model.Job) interface{}
+		want              []uint // NULLs last in both directions, ties by ID
+	}{
+		{"salary_max", "desc", salaryValue, []uint{6, 7, 3, 4, 1, 5, 2}},
+		{"salary_max", "asc", salaryValue, []uint{1, 4, 3, 7, 6, 2, 5}},
+		{"posted_date", "desc", postedValue, []uint{7, 1, 6, 4, 2, 5, 3}},
+		{"posted_date", "asc", postedValue, []uint{2, 4, 6, 1, 7, 3, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy+" "+tt.sortOrder, func(t *testing.T) {
+			page := func(keyset *dtos.JobKeyset) []model.Job {
+				found, total, err := repo.SearchJobs(&dtos.JobSearchParams{SortBy: tt.sortBy, SortOrder: tt.sortOrder, Limit: 2, Keyset: keyset})
+				require.NoError(t, err)
+				assert.Equal(t, int64(len(jobs)), total)
+				return found
+			}
+			ids := func(jobs []model.Job) []uint {
+				list := []uint{}
+				for _, job := range jobs {
+					list = append(list, job.ID)
+				}
+				return list
+			}
+
+			// Forward from the first page to an empty one
+			var pages [][]model.Job
+			for current := page(nil); len(current) > 0; {
+				pages = append(pages, current)
+				last := current[len(current)-1]
+				current = page(&dtos.JobKeyset{Value: tt.value(last), ID: last.ID})
+			}
+			var forward []uint
+			for _, p := range pages {
+				forward = append(forward, ids(p)...)
+			}
+			assert.Equal(t, tt.want, forward)
+
+			// Backward from the last page to the first, each page in sort order
+			var backward []uint
+			first := pages[len(pages)-1][0]
+			for current := page(&dtos.JobKeyset{Value: tt.value(first), ID: first.ID, Backward: true}); len(current) > 0; {
+				backward = append(ids(current), backward...)
+				first = current[0]
+				current = page(&dtos.JobKeyset{Value: tt.value(first), ID: first.ID, Backward: true})
+			}
+			assert.Equal(t, tt.want[:len(tt.want)-len(pages[len(pages)-1])], backward)
+		})
+	}
+}
+
+// salaryValue and postedValue are the keyset values of a job, as decoded from cursors
+func salaryValue(job model.Job) interface{} {
+	if job.SalaryMax == nil {
+		return nil
+	}
+	return *job.SalaryMax
+}
+
+func postedValue(job model.Job) interface{} {
+	if job.PostedDate == nil {
+		return nil
+	}
+	return *job.PostedDate
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// documentCountTerm is the term_document_counts row counting the indexed postings
+const documentCountTerm = ""
+
+// SaveTermVector stores the vector of a job and adds the job to the document frequencies
+// of its distinct terms, in one transaction
+func (r *jobRepository) SaveTermVector(jobID uint, terms []string, vector map[string]float64) error {
+	counts := make([]model.TermDocumentCount, 0, len(terms)+1)
+	counts = append(counts, model.TermDocumentCount{Term: documentCountTerm, DocumentCount: 1})
+	for _, term := range terms {
+		counts = append(counts, model.TermDocumentCount{Term: term, DocumentCount: 1})
+	}
+	rows := make([]model.JobTerm, 0, len(vector))
+	for term, weight := range vector {
+		rows = append(rows, model.JobTerm{JobID: jobID, Term: term, Weight: weight})
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "term"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"document_count": gorm.Expr("term_document_counts.document_count + 1")}),
+		}).CreateInBatches(counts, MaxBatchSize).Error
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+func (r *jobRepository) GetDocumentFrequencies(terms []string) (map[string]int64, int64, error) {
+	var rows []model.TermDocumentCount
+	lookup := append([]string{documentCountTerm}, terms...)
+	for i := 0; i < len(lookup); i += MaxBatchSize {
+		end := min(i+MaxBatchSize, len(lookup))
+		var batch []model.TermDocumentCount
+		if err := r.db.Where("term IN ?", lookup[i:end]).Find(&batch).Error; err != nil {
+			return nil, 0, err
+		}
+		rows = append(rows, batch...)
+	}
+
+	frequencies := make(map[string]int64, len(rows))
+	var documents int64
+	for _, row := range rows {
+		if row.Term == documentCountTerm {
+			documents = row.DocumentCount
+		} else {
+			frequencies[row.Term] = row.DocumentCount
+		}
+	}
+	return frequencies, documents, nil
+}
+
+// GetSimilarCandidates looks candidates up through the term, skill and category indexes
+// so only jobs sharing something with the job are scored
+func (r *jobRepository) GetSimilarCandidates(jobID uint, limit int) (map[uint]float64, error) {
+	now := time.Now()
+	var rows []struct {
+		JobID uint
+		Score float64
+	}
+	err := r.db.Raw(`WITH source AS (SELECT term, weight FROM job_terms WHERE job_id = @job),
+		candidates AS (
+			SELECT job_id FROM (
+				SELECT job_terms.job_id AS job_id FROM job_terms
+				JOIN source ON source.term = job_terms.term
+				JOIN jobs ON jobs.id = job_terms.job_id
+				WHERE job_terms.job_id <> @job AND `+namedOpenJobCondition+`
+				GROUP BY job_terms.job_id
+				ORDER BY SUM(source.weight * job_terms.weight) DESC
+				LIMIT @limit)
+			UNION
+			SELECT job_id FROM (
+				SELECT job_skills.job_id AS job_id FROM job_skills
+				JOIN jobs ON jobs.id = job_skills.job_id
+				WHERE job_skills.skill_id IN (SELECT skill_id FROM job_skills WHERE job_id = @job)
+					AND job_skills.job_id <> @job AND `+namedOpenJobCondition+`
+				GROUP BY job_skills.job_id
+				ORDER BY COUNT(DISTINCT job_skills.skill_id) DESC, job_skills.job_id DESC
+				LIMIT @limit)
+			UNION
+			SELECT job_id FROM (
+				SELECT job_categories.job_id AS job_id FROM job_categories
+				JOIN jobs ON jobs.id = job_categories.job_id
+				WHERE job_categories.category_id IN (SELECT category_id FROM job_categories WHERE job_id = @job)
+					AND job_categories.job_id <> @job AND `+namedOpenJobCondition+`
+				GROUP BY job_categories.job_id
+				ORDER BY COUNT(*) DESC, job_categories.job_id DESC
+				LIMIT @limit)
+		)
+		SELECT candidates.job_id AS job_id, COALESCE((
+			SELECT SUM(source.weight * job_terms.weight) FROM job_terms
+			JOIN source ON source.term = job_terms.term
+			WHERE job_terms.job_id = candidates.job_id
+		), 0) AS score
+		FROM candidates`,
+		map[string]interface{}{"job": jobID, "limit": limit, "now": now}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		candidates[row.JobID] = row.Score
+	}
+	return candidates, nil
+}
+
+// namedOpenJobCondition is openJobCondition for queries with named arguments
+const namedOpenJobCondition = "jobs.deleted_at IS NULL AND (jobs.expiry_date IS NULL OR jobs.expiry_date > @now)"
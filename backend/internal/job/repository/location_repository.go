@@ -10,6 +10,7 @@ type LocationRepository interface {
 	CreateCountry(country *model.Country) (*model.Country, error)
 	CreateJobLocation(jobLocation *model.JobLocation) (*model.JobLocation, error)
 	GetCountryByISO(iso string) (*model.Country, error)
+	GetTimezones() ([]string, error)
 }
 
 type locationRepostiory struct {
@@ -48,3 +49,12 @@ func (r locationRepostiory) GetCountryByISO(iso string) (*model.Country, error)
 	}
 	return &country, nil
 }
+
+// GetTimezones returns the distinct timezones stored on job locations
+func (r locationRepostiory) GetTimezones() ([]string, error) {
+	var timezones []string
+	if err := r.db.Model(&model.JobLocation{}).Where("timezone IS NOT NULL").Distinct().Pluck("timezone", &timezones).Error; err != nil {
+		return nil, err
+	}
+	return timezones, nil
+}
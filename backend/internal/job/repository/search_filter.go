@@ -0,0 +1,406 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bhati00/workova/backend/constant"
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/pkg/enrichment"
+	"github.com/bhati00/workova/backend/pkg/utils"
+)
+
+// ErrInvalidFilter is returned for search filter trees that can't be compiled
+var ErrInvalidFilter = errors.New("invalid filter")
+
+const (
+	maxFilterDepth  = 8
+	maxFilterNodes  = 100
+	maxFilterValues = 100
+)
+
+type filterKind int
+
+const (
+	filterString filterKind = iota
+	filterEnum
+	filterBool
+	filterNumber
+	filterDate
+)
+
+// filterField describes a field of the search filter tree. Only fixed SQL fragments are
+// used, values are always bound as arguments.
+type filterField struct {
+	kind filterKind
+	enum func(string) (int, bool)
+	// match is the condition for "any of values"
+	match func(values []interface{}) (string, []interface{})
+	// compare is the condition for a range operator, nil when the field has no order
+	compare func(operator string, value interface{}) (string, []interface{})
+}
+
+var comparisonOperators = map[string]string{
+	dtos.FilterOpGt: ">", dtos.FilterOpGte: ">=", dtos.FilterOpLt: "<", dtos.FilterOpLte: "<=",
+}
+
+// columnIn matches column against the values
+func columnIn(column string) func([]interface{}) (string, []interface{}) {
+	return func(values []interface{}) (string, []interface{}) {
+		return column + " IN ?", []interface{}{values}
+	}
+}
+
+// lowerIn matches the lower-cased values against sql, which may use the ? placeholder
+// several times
+func lowerIn(sql string) func([]interface{}) (string, []interface{}) {
+	return func(values []interface{}) (string, []interface{}) {
+		lowered := make([]interface{}, len(values))
+		for i, value := range values {
+			lowered[i] = strings.ToLower(value.(string))
+		}
+		args := make([]interface{}, strings.Count(sql, "?"))
+		for i := range args {
+			args[i] = lowered
+		}
+		return sql, args
+	}
+}
+
+// columnCompare compares column with a range operator
+func columnCompare(column string) func(string, interface{}) (string, []interface{}) {
+	return func(operator string, value interface{}) (string, []interface{}) {
+		return column + " " + comparisonOperators[operator] + " ?", []interface{}{value}
+	}
+}
+
+func boolField(column string) filterField {
+	return filterField{kind: filterBool, match: func(values []interface{}) (string, []interface{}) {
+		return column + " = ?", []interface{}{values[0]}
+	}}
+}
+
+func stringField(column string) filterField {
+	return filterField{kind: filterString, match: columnIn(column)}
+}
+
+func enumField(column string, parse func(string) (int, bool)) filterField {
+	return filterField{kind: filterEnum, enum: parse, match: columnIn(column)}
+}
+
+// searchFilterFields are the fields of the search filter tree, the same filters
+// applySearchFilters supports. "text" searches like the query parameter.
+var searchFilterFields = map[string]filterField{
+	"skills": {kind: filterString, match: lowerIn(`jobs.id IN (SELECT job_skills.job_id FROM job_skills
+		JOIN skills ON skills.id = job_skills.skill_id WHERE LOWER(skills.name) IN ?)`)},
+	"company": {kind: filterString, match: lowerIn("jobs.company_id IN (SELECT companies.id FROM companies WHERE companies.slug IN ?)")},
+	"category": {kind: filterString, match: func(values []interface{}) (string, []interface{}) {
+		_, lowered := lowerIn("?")(values)
+		return "jobs.id IN (SELECT job_categories.job_id FROM job_categories WHERE job_categories.category_id IN (" + categoryDescendants + "))",
+			[]interface{}{values, lowered[0]}
+	}},
+	"location": {kind: filterString, match: lowerIn(`jobs.id IN (
+		SELECT job_locations.job_id FROM job_locations
+		JOIN countries ON countries.id = job_locations.country_id
+		WHERE LOWER(job_locations.city) IN ? OR LOWER(job_locations.region) IN ?
+			OR LOWER(countries.name) IN ? OR LOWER(countries.iso) IN ?)`)},
+	"country": {kind: filterString, match: lowerIn(`jobs.id IN (
+		SELECT job_locations.job_id FROM job_locations
+		JOIN countries ON countries.id = job_locations.country_id
+		WHERE LOWER(countries.name) IN ? OR LOWER(countries.iso) IN ?)`)},
+	"language": {kind: filterString, match: lowerIn("jobs.language IN ?")},
+	"currency": {kind: filterString, match: func(values []interface{}) (string, []interface{}) {
+		upper := make([]interface{}, len(values))
+		for i, value := range values {
+			upper[i] = strings.ToUpper(value.(string))
+		}
+		return "jobs.salary_currency IN ?", []interface{}{upper}
+	}},
+	"source":          stringField("jobs.source"),
+	"salary_period":   stringField("jobs.salary_period"),
+	"company_size":    stringField("jobs.company_size"),
+	"industry":        stringField("jobs.industry"),
+	"department":      stringField("jobs.department"),
+	"education_level": stringField("jobs.education_level"),
+	"travel_required": stringField("jobs.travel_required"),
+
+	"work_mode": {kind: filterEnum, enum: enumParser(constant.ParseWorkMode),
+		match: func(values []interface{}) (string, []interface{}) {
+			return "(jobs.work_mode IN ? OR jobs.id IN (SELECT job_locations.job_id FROM job_locations WHERE job_locations.work_mode IN ?))",
+				[]interface{}{values, values}
+		}},
+	"job_type":         enumField("jobs.job_type", enumParser(constant.ParseJobType)),
+	"experience_level": enumField("jobs.experience_level", enumParser(constant.ParseExperienceLevel)),
+
+	"is_remote":         boolField("jobs.is_remote"),
+	"visa_sponsorship":  boolField("jobs.visa_sponsorship"),
+	"health_insurance":  boolField("jobs.health_insurance"),
+	"paid_time_off":     boolField("jobs.paid_time_off"),
+	"flexible_schedule": boolField("jobs.flexible_schedule"),
+	"equity_offered":    boolField("jobs.equity_offered"),
+	"is_urgent":         boolField("jobs.is_urgent"),
+	"security_clearance": {kind: filterBool, match: func(values []interface{}) (string, []interface{}) {
+		if values[0].(bool) {
+			return "(jobs.security_clearance IS NOT NULL AND jobs.security_clearance <> ?)", []interface{}{enrichment.ClearanceNone}
+		}
+		return "(jobs.security_clearance IS NULL OR jobs.security_clearance = ?)", []interface{}{enrichment.ClearanceNone}
+	}},
+
+	// salary matches jobs whose range reaches the value, like min_salary/max_salary;
+	// equality means the value is inside the range
+	"salary": {kind: filterNumber,
+		match: func(values []interface{}) (string, []interface{}) {
+			return "(jobs.salary_min <= ? AND jobs.salary_max >= ?)", []interface{}{values[0], values[0]}
+		},
+		compare: func(operator string, value interface{}) (string, []interface{}) {
+			op := comparisonOperators[operator]
+			return "(jobs.salary_min " + op + " ? OR jobs.salary_max " + op + " ?)", []interface{}{value, value}
+		}},
+	"salary_min":        {kind: filterNumber, match: columnIn("jobs.salary_min"), compare: columnCompare("jobs.salary_min")},
+	"salary_max":        {kind: filterNumber, match: columnIn("jobs.salary_max"), compare: columnCompare("jobs.salary_max")},
+	"contract_duration": {kind: filterNumber, match: columnIn("jobs.contract_months_duration"), compare: columnCompare("jobs.contract_months_duration")},
+
+	// posted_date:7d reads as "posted in the last 7 days", so equality means on or after
+	"posted_date": {kind: filterDate,
+		match: func(values []interface{}) (string, []interface{}) {
+			return "jobs.posted_date >= ?", []interface{}{values[0]}
+		},
+		compare: columnCompare("jobs.posted_date")},
+}
+
+// searchFilterAliases are alternative field names, mostly for the compact query syntax
+var searchFilterAliases = map[string]string{
+	"remote": "is_remote", "skill": "skills", "visa": "visa_sponsorship", "equity": "equity_offered",
+	"urgent": "is_urgent", "clearance": "security_clearance", "type": "job_type", "level": "experience_level",
+	"experience": "experience_level", "lang": "language", "posted": "posted_date",
+}
+
+// IsSearchFilterField reports whether name is a field of the search filter tree or one of
+// its aliases
+func IsSearchFilterField(name string) bool {
+	name = strings.ToLower(name)
+	if _, ok := searchFilterAliases[name]; ok {
+		return true
+	}
+	_, ok := searchFilterFields[name]
+	return ok || name == "text"
+}
+
+func enumParser[T ~int](parse func(string) (T, bool)) func(string) (int, bool) {
+	return func(s string) (int, bool) {
+		value, ok := parse(s)
+		return int(value), ok
+	}
+}
+
+// ValidateSearchFilter checks that a filter tree only uses known fields, operators
+// and values, errors wrap ErrInvalidFilter
+func ValidateSearchFilter(filter *dtos.SearchFilter) error {
+	_, _, err := compileSearchFilter(filter, nil)
+	return err
+}
+
+// compileSearchFilter turns a filter tree into a SQL condition and its arguments
+func compileSearchFilter(filter *dtos.SearchFilter, languages []string) (string, []interface{}, error) {
+	compiler := &filterCompiler{languages: languages}
+	return compiler.compile(filter, "filter", 1)
+}
+
+type filterCompiler struct {
+	languages []string
+	nodes     int
+}
+
+func (c *filterCompiler) compile(node *dtos.SearchFilter, path string, depth int) (string, []interface{}, error) {
+	if depth > maxFilterDepth {
+		return "", nil, fmt.Errorf("%w: %s: nested deeper than %d levels", ErrInvalidFilter, path, maxFilterDepth)
+	}
+	if c.nodes++; c.nodes > maxFilterNodes {
+		return "", nil, fmt.Errorf("%w: more than %d conditions", ErrInvalidFilter, maxFilterNodes)
+	}
+
+	kinds := 0
+	for _, set := range []bool{node.And != nil, node.Or != nil, node.Not != nil, node.Field != ""} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return "", nil, fmt.Errorf("%w: %s: needs exactly one of and, or, not or field", ErrInvalidFilter, path)
+	}
+
+	switch {
+	case node.And != nil:
+		return c.compileGroup(node.And, " AND ", path+".and", depth)
+	case node.Or != nil:
+		return c.compileGroup(node.Or, " OR ", path+".or", depth)
+	case node.Not != nil:
+		sql, args, err := c.compile(node.Not, path+".not", depth+1)
+		if err != nil {
+			return "", nil, err
+		}
+		// A NULL column would make the condition unknown and drop the row from both sides
+		return "NOT COALESCE(" + sql + ", FALSE)", args, nil
+	}
+	return c.compileCondition(node, path)
+}
+
+func (c *filterCompiler) compileGroup(nodes []dtos.SearchFilter, join, path string, depth int) (string, []interface{}, error) {
+	if len(nodes) == 0 {
+		return "", nil, fmt.Errorf("%w: %s: empty group", ErrInvalidFilter, path)
+	}
+	conditions := make([]string, len(nodes))
+	var args []interface{}
+	for i := range nodes {
+		sql, nodeArgs, err := c.compile(&nodes[i], fmt.Sprintf("%s[%d]", path, i), depth+1)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions[i] = sql
+		args = append(args, nodeArgs...)
+	}
+	return "(" + strings.Join(conditions, join) + ")", args, nil
+}
+
+func (c *filterCompiler) compileCondition(node *dtos.SearchFilter, path string) (string, []interface{}, error) {
+	name := strings.ToLower(node.Field)
+	if alias, ok := searchFilterAliases[name]; ok {
+		name = alias
+	}
+	path += "." + name
+	operator := strings.ToLower(node.Op)
+	if operator == "" {
+		operator = dtos.FilterOpEq
+	}
+
+	raw, err := filterValues(node.Value)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %s: %v", ErrInvalidFilter, path, err)
+	}
+
+	if name == "text" {
+		if operator != dtos.FilterOpEq && operator != dtos.FilterOpIn {
+			return "", nil, fmt.Errorf("%w: %s: only supports eq and in", ErrInvalidFilter, path)
+		}
+		conditions := make([]string, len(raw))
+		var args []interface{}
+		for i, text := range raw {
+			sql, textArgs := textSearchCondition(text, c.languages)
+			conditions[i] = sql
+			args = append(args, textArgs...)
+		}
+		return "(" + strings.Join(conditions, " OR ") + ")", args, nil
+	}
+
+	field, ok := searchFilterFields[name]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s: unknown field %q", ErrInvalidFilter, path, node.Field)
+	}
+	values := make([]interface{}, len(raw))
+	for i, value := range raw {
+		if values[i], err = field.parse(value); err != nil {
+			return "", nil, fmt.Errorf("%w: %s: %v", ErrInvalidFilter, path, err)
+		}
+	}
+
+	switch operator {
+	case dtos.FilterOpEq, dtos.FilterOpIn:
+		if len(values) > 1 && field.kind != filterString && field.kind != filterEnum {
+			return "", nil, fmt.Errorf("%w: %s: takes a single value", ErrInvalidFilter, path)
+		}
+		sql, args := field.match(values)
+		return sql, args, nil
+	case dtos.FilterOpGt, dtos.FilterOpGte, dtos.FilterOpLt, dtos.FilterOpLte:
+		if field.compare == nil {
+			return "", nil, fmt.Errorf("%w: %s: doesn't support %s", ErrInvalidFilter, path, operator)
+		}
+		if len(values) != 1 {
+			return "", nil, fmt.Errorf("%w: %s: %s takes a single value", ErrInvalidFilter, path, operator)
+		}
+		sql, args := field.compare(operator, values[0])
+		return sql, args, nil
+	case dtos.FilterOpBetween:
+		if field.compare == nil {
+			return "", nil, fmt.Errorf("%w: %s: doesn't support between", ErrInvalidFilter, path)
+		}
+		if len(values) != 2 {
+			return "", nil, fmt.Errorf("%w: %s: between takes [low, high]", ErrInvalidFilter, path)
+		}
+		low, lowArgs := field.compare(dtos.FilterOpGte, values[0])
+		high, highArgs := field.compare(dtos.FilterOpLte, values[1])
+		return "(" + low + " AND " + high + ")", append(lowArgs, highArgs...), nil
+	}
+	return "", nil, fmt.Errorf("%w: %s: unknown operator %q", ErrInvalidFilter, path, node.Op)
+}
+
+// parse converts a value to the field's type
+func (f filterField) parse(value string) (interface{}, error) {
+	switch f.kind {
+	case filterEnum:
+		if number, ok := f.enum(value); ok {
+			return number, nil
+		}
+		return nil, fmt.Errorf("unknown value %q", value)
+	case filterBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q isn't true or false", value)
+		}
+		return b, nil
+	case filterNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q isn't a number", value)
+		}
+		return number, nil
+	case filterDate:
+		t, ok := utils.ParseDateOrAge(value)
+		if !ok {
+			return nil, fmt.Errorf("%q isn't a date (2024-01-31), an RFC 3339 time or an age such as 7d", value)
+		}
+		return t, nil
+	}
+	return value, nil
+}
+
+// filterValues flattens a JSON or query syntax value into strings
+func filterValues(value interface{}) ([]string, error) {
+	var items []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		items = v
+	case []string:
+		for _, s := range v {
+			items = append(items, s)
+		}
+	default:
+		items = []interface{}{value}
+	}
+	if len(items) == 0 || len(items) > maxFilterValues {
+		return nil, fmt.Errorf("needs 1 to %d values", maxFilterValues)
+	}
+
+	values := make([]string, len(items))
+	for i, item := range items {
+		switch v := item.(type) {
+		case string:
+			values[i] = strings.TrimSpace(v)
+		case float64:
+			values[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case int:
+			values[i] = strconv.Itoa(v)
+		case bool:
+			values[i] = strconv.FormatBool(v)
+		case nil:
+			return nil, errors.New("value is required")
+		default:
+			return nil, fmt.Errorf("unsupported value %v", v)
+		}
+		if values[i] == "" {
+			return nil, errors.New("value is required")
+		}
+	}
+	return values, nil
+}
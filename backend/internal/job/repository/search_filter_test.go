@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/constant"
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	"github.com/bhati00/workova/backend/pkg/enrichment"
+	"github.com/bhati00/workova/backend/pkg/utils"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const skillsIn = `jobs.id IN (SELECT job_skills.job_id FROM job_skills
+		JOIN skills ON skills.id = job_skills.skill_id WHERE LOWER(skills.name) IN ?)`
+
+func TestCompileSearchFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   dtos.SearchFilter
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "eq lower-cases list field values",
+			filter:   dtos.SearchFilter{Field: "skills", Value: "Go"},
+			wantSQL:  skillsIn,
+			wantArgs: []interface{}{[]interface{}{"go"}},
+		},
+		{
+			name:     "in binds the list once per placeholder",
+			filter:   dtos.SearchFilter{Field: "country", Op: "in", Value: []interface{}{"DE", "France"}},
+			wantSQL:  searchFilterFields["country"].matchSQL(),
+			wantArgs: []interface{}{[]interface{}{"de", "france"}, []interface{}{"de", "france"}},
+		},
+		{
+			name:     "column field keeps values as sent",
+			filter:   dtos.SearchFilter{Field: "source", Value: []string{"LinkedIn", "indeed"}},
+			wantSQL:  "jobs.source IN ?",
+			wantArgs: []interface{}{[]interface{}{"LinkedIn", "indeed"}},
+		},
+		{
+			name:     "currency is upper-cased",
+			filter:   dtos.SearchFilter{Field: "currency", Value: "eur"},
+			wantSQL:  "jobs.salary_currency IN ?",
+			wantArgs: []interface{}{[]interface{}{"EUR"}},
+		},
+		{
+			name:     "enum names and numbers",
+			filter:   dtos.SearchFilter{Field: "type", Value: []interface{}{"contract", 1.0}},
+			wantSQL:  "jobs.job_type IN ?",
+			wantArgs: []interface{}{[]interface{}{int(constant.JobTypeContract), int(constant.JobTypeFullTime)}},
+		},
+		{
+			name:     "bool alias",
+			filter:   dtos.SearchFilter{Field: "remote", Value: "true"},
+			wantSQL:  "jobs.is_remote = ?",
+			wantArgs: []interface{}{true},
+		},
+		{
+			name:     "clearance",
+			filter:   dtos.SearchFilter{Field: "clearance", Value: false},
+			wantSQL:  "(jobs.security_clearance IS NULL OR jobs.security_clearance = ?)",
+			wantArgs: []interface{}{enrichment.ClearanceNone},
+		},
+		{
+			name:     "salary eq is inside the range",
+			filter:   dtos.SearchFilter{Field: "salary", Value: 90000.0},
+			wantSQL:  "(jobs.salary_min <= ? AND jobs.salary_max >= ?)",
+			wantArgs: []interface{}{90000.0, 90000.0},
+		},
+		{
+			name:     "gt",
+			filter:   dtos.SearchFilter{Field: "salary_min", Op: "gt", Value: "100000"},
+			wantSQL:  "jobs.salary_min > ?",
+			wantArgs: []interface{}{100000.0},
+		},
+		{
+			name:     "gte",
+			filter:   dtos.SearchFilter{Field: "salary", Op: "GTE", Value: 100000},
+			wantSQL:  "(jobs.salary_min >= ? OR jobs.salary_max >= ?)",
+			wantArgs: []interface{}{100000.0, 100000.0},
+		},
+		{
+			name:     "lt",
+			filter:   dtos.SearchFilter{Field: "contract_duration", Op: "lt", Value: 6.0},
+			wantSQL:  "jobs.contract_months_duration < ?",
+			wantArgs: []interface{}{6.0},
+		},
+		{
+			name:     "lte",
+			filter:   dtos.SearchFilter{Field: "posted", Op: "lte", Value: "2024-01-31"},
+			wantSQL:  "jobs.posted_date <= ?",
+			wantArgs: []interface{}{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "between",
+			filter:   dtos.SearchFilter{Field: "salary_max", Op: "between", Value: []interface{}{"50000", 90000.0}},
+			wantSQL:  "(jobs.salary_max >= ? AND jobs.salary_max <= ?)",
+			wantArgs: []interface{}{50000.0, 90000.0},
+		},
+		{
+			name:     "not",
+			filter:   dtos.SearchFilter{Not: &dtos.SearchFilter{Field: "is_remote", Value: true}},
+			wantSQL:  "NOT COALESCE(jobs.is_remote = ?, FALSE)",
+			wantArgs: []interface{}{true},
+		},
+		{
+			name: "or inside and keeps argument order",
+			filter: dtos.SearchFilter{And: []dtos.SearchFilter{
+				{Or: []dtos.SearchFilter{
+					{Field: "source", Value: "linkedin"},
+					{Field: "salary_min", Op: "gte", Value: 80000.0},
+				}},
+				{Not: &dtos.SearchFilter{Or: []dtos.SearchFilter{
+					{Field: "is_urgent", Value: true},
+					{Field: "job_type", Value: "internship"},
+				}}},
+			}},
+			wantSQL:  "((jobs.source IN ? OR jobs.salary_min >= ?) AND NOT COALESCE((jobs.is_urgent = ? OR jobs.job_type IN ?), FALSE))",
+			wantArgs: []interface{}{[]interface{}{"linkedin"}, 80000.0, true, []interface{}{int(constant.JobTypeInternship)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := compileSearchFilter(&tt.filter, nil)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, sql)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+// matchSQL returns the condition of a field for a single value, to compare against
+func (f filterField) matchSQL() string {
+	sql, _ := f.match([]interface{}{""})
+	return sql
+}
+
+func TestCompileSearchFilter_Text(t *testing.T) {
+	sql, args, err := compileSearchFilter(&dtos.SearchFilter{Field: "text", Value: []interface{}{"Go", "Rust"}}, nil)
+
+	require.NoError(t, err)
+	goSQL, goArgs := textSearchCondition("Go", nil)
+	rustSQL, rustArgs := textSearchCondition("Rust", nil)
+	assert.Equal(t, "("+goSQL+" OR "+rustSQL+")", sql)
+	assert.Equal(t, append(goArgs, rustArgs...), args)
+}
+
+func TestCompileSearchFilter_Limits(t *testing.T) {
+	nested := func(levels int) dtos.SearchFilter {
+		filter := dtos.SearchFilter{Field: "skills", Value: "go"}
+		for i := 1; i < levels; i++ {
+			inner := filter
+			filter = dtos.SearchFilter{Not: &inner}
+		}
+		return filter
+	}
+	conditions := func(n int) dtos.SearchFilter {
+		filter := dtos.SearchFilter{Or: make([]dtos.SearchFilter, n)}
+		for i := range filter.Or {
+			filter.Or[i] = dtos.SearchFilter{Field: "is_remote", Value: true}
+		}
+		return filter
+	}
+	values := func(n int) []interface{} {
+		list := make([]interface{}, n)
+		for i := range list {
+			list[i] = "go"
+		}
+		return list
+	}
+
+	tests := []struct {
+		name    string
+		filter  dtos.SearchFilter
+		wantErr string
+	}{
+		{"deepest allowed nesting", nested(maxFilterDepth), ""},
+		{"nested too deep", nested(maxFilterDepth + 1), "filter.not.not.not.not.not.not.not.not: nested deeper than 8 levels"},
+		{"most conditions allowed", conditions(maxFilterNodes - 1), ""},
+		{"too many conditions", conditions(maxFilterNodes), "more than 100 conditions"},
+		{"most values allowed", dtos.SearchFilter{Field: "skills", Value: values(maxFilterValues)}, ""},
+		{"too many values", dtos.SearchFilter{Field: "skills", Value: values(maxFilterValues + 1)}, "filter.skills: needs 1 to 100 values"},
+		{"empty list", dtos.SearchFilter{Field: "skills", Value: []interface{}{}}, "filter.skills: needs 1 to 100 values"},
+		{"several values on a single value field", dtos.SearchFilter{Field: "salary", Value: []interface{}{1.0, 2.0}}, "filter.salary: takes a single value"},
+		{"range on text", dtos.SearchFilter{Field: "text", Op: "gt", Value: "go"}, "filter.text: only supports eq and in"},
+		{"range with a list", dtos.SearchFilter{Field: "salary", Op: "lt", Value: []interface{}{1.0, 2.0}}, "filter.salary: lt takes a single value"},
+		{"unsupported value", dtos.SearchFilter{Field: "skills", Value: map[string]interface{}{}}, "filter.skills: unsupported value map[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := compileSearchFilter(&tt.filter, nil)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+			assert.EqualError(t, err, "invalid filter: "+tt.wantErr)
+		})
+	}
+}
+
+// newTestDB returns a database with the job migrations applied
+func newTestDB(t *testing.T) *gorm.DB {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	m, err := migrate.New("file://../migrations", "sqlite://"+path)
+	require.NoError(t, err)
+	require.NoError(t, m.Up())
+	sourceErr, dbErr := m.Close()
+	require.NoError(t, sourceErr)
+	require.NoError(t, dbErr)
+
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	return db
+}
+
+func TestCompileSearchFilter_SQLite(t *testing.T) {
+	db := newTestDB(t)
+	jobs := []model.Job{
+		{Title: "Go Engineer", CompanyName: "Acme", Source: "linkedin", IsRemote: utils.Bool(true),
+			SalaryMin: utils.Int(80000), SalaryMax: utils.Int(120000), JobType: constant.JobTypeFullTime},
+		{Title: "Rust Engineer", CompanyName: "Globex", Source: "indeed", IsRemote: utils.Bool(false),
+			SalaryMin: utils.Int(50000), SalaryMax: utils.Int(70000), JobType: constant.JobTypeContract},
+		{Title: "Intern", CompanyName: "Initech", Source: "linkedin", IsRemote: utils.Bool(false), JobType: constant.JobTypeInternship},
+	}
+	require.NoError(t, db.Create(&jobs).Error)
+
+	tests := []struct {
+		name   string
+		filter dtos.SearchFilter
+		want   []uint
+	}{
+		{"bool", dtos.SearchFilter{Field: "remote", Value: true}, []uint{1}},
+		{"not over a NULL salary", dtos.SearchFilter{Not: &dtos.SearchFilter{Field: "salary", Op: "gte", Value: 100000.0}}, []uint{2, 3}},
+		{"between", dtos.SearchFilter{Field: "salary", Op: "between", Value: []interface{}{60000.0, 90000.0}}, []uint{1, 2}},
+		{"enum list", dtos.SearchFilter{Field: "job_type", Value: []interface{}{"contract", "internship"}}, []uint{2, 3}},
+		{"text", dtos.SearchFilter{Field: "text", Value: "engineer"}, []uint{1, 2}},
+		{"or and not", dtos.SearchFilter{And: []dtos.SearchFilter{
+			{Or: []dtos.SearchFilter{{Field: "source", Value: "linkedin"}, {Field: "salary_max", Op: "lt", Value: 80000.0}}},
+			{Not: &dtos.SearchFilter{Field: "type", Value: "internship"}},
+		}}, []uint{1, 2}},
+		{"joined tables", dtos.SearchFilter{Or: []dtos.SearchFilter{
+			{Field: "skills", Value: "go"}, {Field: "company", Value: "acme"}, {Field: "category", Value: "engineering"},
+			{Field: "location", Value: "berlin"}, {Field: "country", Value: "DE"}, {Field: "work_mode", Value: "hybrid"},
+		}}, []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := compileSearchFilter(&tt.filter, nil)
+			require.NoError(t, err)
+
+			ids := []uint{}
+			require.NoError(t, db.Model(&model.Job{}).Where(sql, args...).Order("jobs.id").Pluck("jobs.id", &ids).Error)
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
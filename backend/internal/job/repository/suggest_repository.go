@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/pkg/suggest"
+	"gorm.io/gorm"
+)
+
+// suggestTitleLimit caps the popular titles loaded, titles with a single posting are
+// rarely worth suggesting
+const suggestTitleLimit = 5000
+
+type SuggestRepository interface {
+	// GetSuggestionEntries returns every skill, company, category, country, city and popular
+	// title with open jobs, with the number of open jobs for each
+	GetSuggestionEntries() ([]suggest.Entry, error)
+}
+
+type suggestRepository struct {
+	db *gorm.DB
+}
+
+func NewSuggestRepository(db *gorm.DB) suggestRepository {
+	return suggestRepository{db: db}
+}
+
+type suggestionRow struct {
+	ID    uint
+	Label string
+	Slug  string
+	Count int64
+}
+
+func (r suggestRepository) GetSuggestionEntries() ([]suggest.Entry, error) {
+	openJobs := r.db.Table("jobs").Select("jobs.id").Where(openJobCondition, time.Now())
+
+	queries := []struct {
+		kind  string
+		query *gorm.DB
+	}{
+		{suggest.TypeSkill, r.db.Raw(`SELECT skills.id AS id, skills.name AS label, LOWER(skills.name) AS slug,
+				COUNT(DISTINCT job_skills.job_id) AS count
+			FROM job_skills JOIN skills ON skills.id = job_skills.skill_id
+			WHERE job_skills.job_id IN (?)
+			GROUP BY skills.id`, openJobs)},
+		{suggest.TypeCompany, r.db.Raw(`SELECT companies.id AS id, companies.name AS label, companies.slug AS slug,
+				COUNT(*) AS count
+			FROM jobs JOIN companies ON companies.id = jobs.company_id
+			WHERE jobs.id IN (?)
+			GROUP BY companies.id`, openJobs)},
+		// A category counts the jobs of its subcategories too, like the filter
+		{suggest.TypeCategory, r.db.Raw(`WITH RECURSIVE category_closure(ancestor_id, id) AS (
+				SELECT categories.id, categories.id FROM categories
+				UNION
+				SELECT category_closure.ancestor_id, categories.id FROM categories
+				JOIN category_closure ON categories.parent_id = category_closure.id
+			)
+			SELECT categories.id AS id, categories.name AS label, COALESCE(categories.slug, categories.name) AS slug,
+				COUNT(DISTINCT job_categories.job_id) AS count
+			FROM category_closure
+			JOIN job_categories ON job_categories.category_id = category_closure.id
+			JOIN categories ON categories.id = category_closure.ancestor_id
+			WHERE job_categories.job_id IN (?)
+			GROUP BY categories.id`, openJobs)},
+		{suggest.TypeLocation, r.db.Raw(`SELECT countries.id AS id, countries.name AS label, countries.iso AS slug,
+				COUNT(DISTINCT job_locations.job_id) AS count
+			FROM job_locations JOIN countries ON countries.id = job_locations.country_id
+			WHERE job_locations.job_id IN (?)
+			GROUP BY countries.id`, openJobs)},
+		// Cities have no row, the slug is the location filter value
+		{suggest.TypeLocation, r.db.Raw(`SELECT 0 AS id, MIN(job_locations.city) AS label, LOWER(job_locations.city) AS slug,
+				COUNT(DISTINCT job_locations.job_id) AS count
+			FROM job_locations
+			WHERE job_locations.city IS NOT NULL AND job_locations.city <> '' AND job_locations.job_id IN (?)
+			GROUP BY LOWER(job_locations.city)`, openJobs)},
+		{suggest.TypeTitle, r.db.Raw(`SELECT 0 AS id, MIN(jobs.title) AS label, MIN(jobs.title) AS slug, COUNT(*) AS count
+			FROM jobs
+			WHERE jobs.id IN (?)
+			GROUP BY LOWER(jobs.title)
+			ORDER BY count DESC
+			LIMIT ?`, openJobs, suggestTitleLimit)},
+	}
+
+	var entries []suggest.Entry
+	for _, q := range queries {
+		var rows []suggestionRow
+		if err := q.query.Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			entries = append(entries, suggest.Entry{Type: q.kind, Label: row.Label, ID: row.ID, Slug: row.Slug, Count: row.Count})
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,307 @@
+package job
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bhati00/workova/backend/constant"
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/pkg/utils"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// maxHighlightTagLength bounds highlight_pre_tag and highlight_post_tag
+	maxHighlightTagLength = 32
+)
+
+// searchSortFields are the values accepted by sort_by
+var searchSortFields = []string{"created_at", "posted_date", "updated_at", "salary_max", "salary_min", "company_name", "title", "distance"}
+
+// ParseSearchParams binds every job search filter from a query string. Lists are
+// comma-separated or repeated (skills=go,rust or skills=go&skills=rust), enums accept
+// names or numbers (work_mode=remote or work_mode=1). Every invalid parameter is
+// reported, not just the first one.
+func ParseSearchParams(query url.Values) (*dtos.JobSearchParams, []dtos.FieldError) {
+	p := &searchParamParser{query: query}
+	params := &dtos.JobSearchParams{
+		Query:            p.text("query"),
+		Skills:           p.list("skills"),
+		Company:          p.list("company"),
+		Category:         p.list("category"),
+		Source:           p.list("source"),
+		Location:         p.list("location"),
+		CompanySize:      p.list("company_size"),
+		Industry:         p.list("industry"),
+		Department:       p.list("department"),
+		EducationLevel:   p.list("education_level"),
+		TravelRequired:   p.list("travel_required"),
+		SalaryPeriod:     p.list("salary_period"),
+		WorkMode:         parseEnumList(p, "work_mode", constant.ParseWorkMode, "remote, onsite, hybrid"),
+		ExperienceLevel:  parseEnumList(p, "experience_level", constant.ParseExperienceLevel, "entry, mid, senior, lead, executive"),
+		MinSalary:        p.integer("min_salary", 0, 100_000_000),
+		MaxSalary:        p.integer("max_salary", 0, 100_000_000),
+		IsRemote:         p.boolean("is_remote"),
+		VisaSponsorship:  p.boolean("visa_sponsorship"),
+		HealthInsurance:  p.boolean("health_insurance"),
+		PaidTimeOff:      p.boolean("paid_time_off"),
+		FlexibleSchedule: p.boolean("flexible_schedule"),
+		EquityOffered:    p.boolean("equity_offered"),
+		Clearance:        p.boolean("security_clearance"),
+		IsUrgent:         p.boolean("is_urgent"),
+		ContractDuration: p.integer("contract_duration", 1, 120),
+		PostedAfter:      p.date("posted_after"),
+		PostedBefore:     p.date("posted_before"),
+		RemoteEligibleIn: p.text("remote_eligible_in"),
+	}
+
+	// query uses the compact filter syntax when a term names a known field, see ParseFilterQuery
+	if filter, err := ParseFilterQuery(params.Query); err != nil {
+		p.fail("query", params.Query, "invalid filter syntax: %v", err)
+	} else if filter != nil {
+		params.Query, params.Filter = "", filter
+	}
+
+	// work_type is the old name of job_type
+	jobTypes := "full_time, part_time, contract, internship, temporary"
+	params.JobType = parseEnumList(p, "job_type", constant.ParseJobType, jobTypes)
+	params.JobType = append(params.JobType, parseEnumList(p, "work_type", constant.ParseJobType, jobTypes)...)
+
+	if params.MinSalary != nil && params.MaxSalary != nil && *params.MinSalary > *params.MaxSalary {
+		p.fail("min_salary", p.query.Get("min_salary"), "min_salary can't be greater than max_salary")
+	}
+	if params.PostedAfter != nil && params.PostedBefore != nil && params.PostedAfter.After(*params.PostedBefore) {
+		p.fail("posted_after", p.query.Get("posted_after"), "posted_after can't be later than posted_before")
+	}
+
+	if currency := p.text("currency"); currency != "" {
+		if !isCurrencyCode(currency) {
+			p.fail("currency", currency, "currency must be a three-letter ISO 4217 code such as USD")
+		}
+		params.Currency = strings.ToUpper(currency)
+	}
+
+	for _, code := range p.list("language") {
+		code = strings.ToLower(code)
+		if !isLanguageCode(code) {
+			p.fail("language", code, "language must be ISO 639-1 codes such as en or de")
+			continue
+		}
+		params.Language = append(params.Language, code)
+	}
+
+	p.geo(params)
+	p.paging(params)
+	p.highlight(params)
+	if autoCorrect := p.boolean("auto_correct"); autoCorrect != nil {
+		params.AutoCorrect = *autoCorrect
+	}
+
+	for _, facet := range p.list("facets") {
+		if _, ok := facetFilters[facet]; !ok {
+			p.fail("facets", facet, "facets must be among work_mode, job_type, experience_level, source, country, skills, category, salary, posted_date")
+			continue
+		}
+		params.Facets = append(params.Facets, facet)
+	}
+
+	return params, p.errors
+}
+
+// searchParamParser reads typed values from a query string, collecting the errors
+type searchParamParser struct {
+	query  url.Values
+	errors []dtos.FieldError
+}
+
+func (p *searchParamParser) fail(field, value, format string, args ...interface{}) {
+	p.errors = append(p.errors, dtos.FieldError{Field: field, Value: value, Message: fmt.Sprintf(format, args...)})
+}
+
+// text returns the trimmed value of name, "" when omitted
+func (p *searchParamParser) text(name string) string {
+	return strings.TrimSpace(p.query.Get(name))
+}
+
+// list returns the comma-separated values of every occurrence of name, skipping empty items
+func (p *searchParamParser) list(name string) []string {
+	var values []string
+	for _, raw := range p.query[name] {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func (p *searchParamParser) boolean(name string) *bool {
+	raw := p.text(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		p.fail(name, raw, "%s must be true or false", name)
+		return nil
+	}
+	return &value
+}
+
+func (p *searchParamParser) integer(name string, min, max int) *int {
+	raw := p.text(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < min || value > max {
+		p.fail(name, raw, "%s must be an integer between %d and %d", name, min, max)
+		return nil
+	}
+	return &value
+}
+
+func (p *searchParamParser) number(name string, min, max float64) *float64 {
+	raw := p.text(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value < min || value > max {
+		p.fail(name, raw, "%s must be a number between %g and %g", name, min, max)
+		return nil
+	}
+	return &value
+}
+
+// date reads an RFC 3339 time, a YYYY-MM-DD date or an age such as 7d or 12h, which
+// is counted back from now like the posted_date facet
+func (p *searchParamParser) date(name string) *time.Time {
+	raw := p.text(name)
+	if raw == "" {
+		return nil
+	}
+	if t, ok := utils.ParseDateOrAge(raw); ok {
+		return &t
+	}
+	p.fail(name, raw, "%s must be a date (2024-01-31), an RFC 3339 time or an age such as 7d or 12h", name)
+	return nil
+}
+
+// geo reads lat, lng, radius_km, near, tz and tz_overlap_hours
+func (p *searchParamParser) geo(params *dtos.JobSearchParams) {
+	params.Latitude = p.number("lat", -90, 90)
+	params.Longitude = p.number("lng", -180, 180)
+	if (p.text("lat") == "") != (p.text("lng") == "") {
+		p.fail("lat", p.text("lat"), "lat and lng must be provided together")
+	}
+	params.RadiusKm = p.number("radius_km", 0.1, 20000)
+
+	params.Near = p.text("near")
+	if params.Near != "" && p.text("lat") != "" {
+		p.fail("near", params.Near, "use either near or lat/lng, not both")
+	}
+
+	params.Timezone = p.text("tz")
+	params.TimezoneOverlap = p.integer("tz_overlap_hours", 0, 8)
+	if params.TimezoneOverlap != nil && params.Timezone == "" {
+		p.fail("tz_overlap_hours", p.text("tz_overlap_hours"), "tz_overlap_hours requires tz")
+	}
+}
+
+// paging reads page, page_size, cursor, sort_by and sort_order
+func (p *searchParamParser) paging(params *dtos.JobSearchParams) {
+	page := 1
+	if value := p.integer("page", 1, 1_000_000); value != nil {
+		page = *value
+	}
+	params.Limit = defaultPageSize
+	if value := p.integer("page_size", 1, maxPageSize); value != nil {
+		params.Limit = *value
+	}
+	params.Offset = (page - 1) * params.Limit
+
+	params.Cursor = p.text("cursor")
+	if params.Cursor != "" && p.text("page") != "" {
+		p.fail("cursor", params.Cursor, "use either page or cursor, not both")
+	}
+
+	if sortBy := p.text("sort_by"); sortBy != "" {
+		if !contains(searchSortFields, sortBy) {
+			p.fail("sort_by", sortBy, "sort_by must be one of %s", strings.Join(searchSortFields, ", "))
+		}
+		params.SortBy = sortBy
+	}
+	if sortOrder := strings.ToLower(p.text("sort_order")); sortOrder != "" {
+		if sortOrder != "asc" && sortOrder != "desc" {
+			p.fail("sort_order", sortOrder, "sort_order must be asc or desc")
+		}
+		params.SortOrder = sortOrder
+	}
+}
+
+// highlight reads the highlight options. The tags and fragment size are validated but
+// only apply with highlight=true.
+func (p *searchParamParser) highlight(params *dtos.JobSearchParams) {
+	enabled := p.boolean("highlight")
+	preTag, postTag := p.query.Get("highlight_pre_tag"), p.query.Get("highlight_post_tag")
+	if (preTag == "") != (postTag == "") {
+		p.fail("highlight_pre_tag", preTag, "highlight_pre_tag and highlight_post_tag must be set together")
+	}
+	for _, name := range []string{"highlight_pre_tag", "highlight_post_tag"} {
+		if tag := p.query.Get(name); len(tag) > maxHighlightTagLength {
+			p.fail(name, tag, "%s must be at most %d characters", name, maxHighlightTagLength)
+		}
+	}
+	fragmentSize := p.integer("highlight_fragment_size", 40, 1000)
+
+	if enabled == nil || !*enabled {
+		return
+	}
+	params.Highlight = &dtos.HighlightOptions{PreTag: preTag, PostTag: postTag}
+	if fragmentSize != nil {
+		params.Highlight.FragmentSize = *fragmentSize
+	}
+}
+
+// parseEnumList reads a list of enum names or numbers with parse, names lists the valid
+// names for the error message
+func parseEnumList[T any](p *searchParamParser, name string, parse func(string) (T, bool), names string) []T {
+	var values []T
+	for _, raw := range p.list(name) {
+		value, ok := parse(raw)
+		if !ok {
+			p.fail(name, raw, "%s must be among %s or their numbers", name, names)
+			continue
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/repository"
+)
+
+// The compact filter syntax of the query parameter:
+//
+//	skills:go AND (remote:true OR country:DE) -company:acme
+//
+// Terms are field:value, with value a single value, a list (skills:go,rust), a range
+// (salary:100000..150000) or a comparison (salary:>=100000, posted_date:>7d). Terms are
+// combined with AND (the default between terms), OR and parentheses, and negated with
+// NOT or a leading -. Words without a field and "quoted phrases" search the text.
+//
+// A query is only read this way when one of its terms names a known field. Otherwise it
+// is all text, so URLs, "Engineer:" and pasted titles with parentheses or AND/OR keep
+// searching as typed.
+
+type queryTokenKind int
+
+const (
+	tokenWord queryTokenKind = iota
+	tokenOpen
+	tokenClose
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	// phrase is set for "quoted words", literal for field:"quoted values"; neither is
+	// read as syntax
+	phrase  bool
+	literal bool
+}
+
+// ParseFilterQuery parses the compact filter syntax into a filter tree. It returns nil
+// when no term names a known field, so plain keywords keep searching as one phrase.
+func ParseFilterQuery(query string) (*dtos.SearchFilter, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if !hasFilterSyntax(tokens) {
+		return nil, nil
+	}
+
+	parser := &queryParser{tokens: tokens}
+	filter, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(tokens) {
+		return nil, errors.New("unexpected )")
+	}
+	return &filter, nil
+}
+
+func tokenizeQuery(query string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n':
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenOpen, text: "("})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenClose, text: ")"})
+			i++
+			continue
+		case r == '-' && i+1 < len(runes) && !strings.ContainsRune(" \t\n)", runes[i+1]):
+			tokens = append(tokens, queryToken{kind: tokenNot, text: "-"})
+			i++
+			continue
+		}
+
+		token := queryToken{kind: tokenWord, phrase: r == '"'}
+		var word strings.Builder
+		for i < len(runes) && !strings.ContainsRune(" \t\n()", runes[i]) {
+			if runes[i] != '"' {
+				word.WriteRune(runes[i])
+				i++
+				continue
+			}
+			if strings.Contains(word.String(), ":") {
+				token.literal = true
+			}
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.New("unterminated quote")
+			}
+			word.WriteString(string(runes[i+1 : end]))
+			i = end + 1
+		}
+		token.text = word.String()
+
+		if !token.phrase {
+			switch token.text {
+			case "AND", "&&":
+				token.kind = tokenAnd
+			case "OR", "||":
+				token.kind = tokenOr
+			case "NOT":
+				token.kind = tokenNot
+			}
+		}
+		if token.kind != tokenWord || token.text != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// hasFilterSyntax reports whether a term of tokens names a known field
+func hasFilterSyntax(tokens []queryToken) bool {
+	for _, token := range tokens {
+		if token.kind != tokenWord {
+			continue
+		}
+		if _, _, ok := splitFieldTerm(token); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// splitFieldTerm splits field:value, field being a known filter field or alias
+func splitFieldTerm(token queryToken) (string, string, bool) {
+	if token.phrase {
+		return "", "", false
+	}
+	i := strings.Index(token.text, ":")
+	if i <= 0 {
+		return "", "", false
+	}
+	if !repository.IsSearchFilterField(token.text[:i]) {
+		return "", "", false
+	}
+	return strings.ToLower(token.text[:i]), token.text[i+1:], true
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() *queryToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+// parseOr reads terms joined by OR, which binds looser than AND
+func (p *queryParser) parseOr() (dtos.SearchFilter, error) {
+	var terms []dtos.SearchFilter
+	for {
+		term, err := p.parseAnd()
+		if err != nil {
+			return dtos.SearchFilter{}, err
+		}
+		terms = append(terms, term)
+		if token := p.peek(); token == nil || token.kind != tokenOr {
+			break
+		}
+		p.pos++
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return dtos.SearchFilter{Or: terms}, nil
+}
+
+// parseAnd reads terms joined by AND or just whitespace
+func (p *queryParser) parseAnd() (dtos.SearchFilter, error) {
+	var terms []dtos.SearchFilter
+	for {
+		term, err := p.parseUnary()
+		if err != nil {
+			return dtos.SearchFilter{}, err
+		}
+		terms = append(terms, term)
+
+		token := p.peek()
+		if token == nil || token.kind == tokenOr || token.kind == tokenClose {
+			break
+		}
+		if token.kind == tokenAnd {
+			p.pos++
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return dtos.SearchFilter{And: terms}, nil
+}
+
+func (p *queryParser) parseUnary() (dtos.SearchFilter, error) {
+	token := p.peek()
+	if token == nil {
+		return dtos.SearchFilter{}, errors.New("unexpected end of query")
+	}
+	p.pos++
+
+	switch token.kind {
+	case tokenNot:
+		term, err := p.parseUnary()
+		if err != nil {
+			return dtos.SearchFilter{}, err
+		}
+		return dtos.SearchFilter{Not: &term}, nil
+	case tokenOpen:
+		group, err := p.parseOr()
+		if err != nil {
+			return dtos.SearchFilter{}, err
+		}
+		if closing := p.peek(); closing == nil || closing.kind != tokenClose {
+			return dtos.SearchFilter{}, errors.New("missing )")
+		}
+		p.pos++
+		return group, nil
+	case tokenWord:
+		return parseQueryTerm(*token)
+	}
+	return dtos.SearchFilter{}, fmt.Errorf("unexpected %s", token.text)
+}
+
+// parseQueryTerm turns field:value into a condition and other words into a text search
+func parseQueryTerm(token queryToken) (dtos.SearchFilter, error) {
+	field, value, ok := splitFieldTerm(token)
+	if !ok {
+		return dtos.SearchFilter{Field: "text", Value: token.text}, nil
+	}
+	if value == "" {
+		return dtos.SearchFilter{}, fmt.Errorf("%s: needs a value", field)
+	}
+	if token.literal {
+		return dtos.SearchFilter{Field: field, Value: value}, nil
+	}
+
+	for _, comparison := range []struct{ prefix, op string }{
+		{">=", dtos.FilterOpGte}, {"<=", dtos.FilterOpLte}, {">", dtos.FilterOpGt}, {"<", dtos.FilterOpLt},
+	} {
+		if strings.HasPrefix(value, comparison.prefix) {
+			return dtos.SearchFilter{Field: field, Op: comparison.op, Value: strings.TrimPrefix(value, comparison.prefix)}, nil
+		}
+	}
+	if low, high, ok := strings.Cut(value, ".."); ok {
+		return dtos.SearchFilter{Field: field, Op: dtos.FilterOpBetween, Value: []interface{}{low, high}}, nil
+	}
+	if strings.Contains(value, ",") {
+		var values []interface{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return dtos.SearchFilter{Field: field, Op: dtos.FilterOpIn, Value: values}, nil
+	}
+	return dtos.SearchFilter{Field: field, Value: value}, nil
+}
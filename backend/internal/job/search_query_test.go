@@ -0,0 +1,168 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilterQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  *dtos.SearchFilter
+	}{
+		{
+			name:  "plain keywords are no filter",
+			query: "senior go developer",
+			want:  nil,
+		},
+		{
+			name:  "words ending in a colon",
+			query: "Engineer: backend",
+			want:  nil,
+		},
+		{
+			name:  "URL",
+			query: "https://example.com/jobs",
+			want:  nil,
+		},
+		{
+			name:  "pasted title with operators",
+			query: "Senior Engineer (Go AND Rust) OR Lead -remote",
+			want:  nil,
+		},
+		{
+			name:  "unknown fields are text next to known ones",
+			query: "Engineer: https://example.com Skill:Go",
+			want: &dtos.SearchFilter{And: []dtos.SearchFilter{
+				{Field: "text", Value: "Engineer:"},
+				{Field: "text", Value: "https://example.com"},
+				{Field: "skill", Value: "Go"},
+			}},
+		},
+		{
+			name:  "single term",
+			query: "skills:go",
+			want:  &dtos.SearchFilter{Field: "skills", Value: "go"},
+		},
+		{
+			name:  "example from the docs",
+			query: "skills:go AND (remote:true OR country:DE) -company:acme",
+			want: &dtos.SearchFilter{And: []dtos.SearchFilter{
+				{Field: "skills", Value: "go"},
+				{Or: []dtos.SearchFilter{{Field: "remote", Value: "true"}, {Field: "country", Value: "DE"}}},
+				{Not: &dtos.SearchFilter{Field: "company", Value: "acme"}},
+			}},
+		},
+		{
+			name:  "OR binds looser than implicit AND",
+			query: "golang remote:true OR rust",
+			want: &dtos.SearchFilter{Or: []dtos.SearchFilter{
+				{And: []dtos.SearchFilter{{Field: "text", Value: "golang"}, {Field: "remote", Value: "true"}}},
+				{Field: "text", Value: "rust"},
+			}},
+		},
+		{
+			name:  "lists, ranges and comparisons",
+			query: "skills:go,rust salary:100000..150000 posted_date:>=7d",
+			want: &dtos.SearchFilter{And: []dtos.SearchFilter{
+				{Field: "skills", Op: dtos.FilterOpIn, Value: []interface{}{"go", "rust"}},
+				{Field: "salary", Op: dtos.FilterOpBetween, Value: []interface{}{"100000", "150000"}},
+				{Field: "posted_date", Op: dtos.FilterOpGte, Value: "7d"},
+			}},
+		},
+		{
+			name:  "quoted phrase and quoted value",
+			query: `"machine learning" NOT location:"New York, NY"`,
+			want: &dtos.SearchFilter{And: []dtos.SearchFilter{
+				{Field: "text", Value: "machine learning"},
+				{Not: &dtos.SearchFilter{Field: "location", Value: "New York, NY"}},
+			}},
+		},
+		{
+			name:  "hyphenated words aren't negated",
+			query: "full-time work_mode:hybrid",
+			want: &dtos.SearchFilter{And: []dtos.SearchFilter{
+				{Field: "text", Value: "full-time"},
+				{Field: "work_mode", Value: "hybrid"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseFilterQuery(tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, filter)
+			if filter != nil {
+				assert.NoError(t, repository.ValidateSearchFilter(filter))
+			}
+		})
+	}
+}
+
+func TestParseFilterQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{"unclosed group", "(skills:go OR skills:rust", "missing )"},
+		{"stray closing paren", "skills:go)", "unexpected )"},
+		{"dangling operator", "skills:go AND", "unexpected end of query"},
+		{"missing value", "skills: go", "skills: needs a value"},
+		{"unterminated quote", `company:"acme`, "unterminated quote"},
+		{"leading OR", "OR skills:go", "unexpected OR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFilterQuery(tt.query)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateSearchFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  dtos.SearchFilter
+		wantErr string
+	}{
+		{"valid enum by number", dtos.SearchFilter{Field: "work_mode", Value: []interface{}{1.0, "hybrid"}}, ""},
+		{"valid between", dtos.SearchFilter{Field: "salary", Op: "between", Value: []interface{}{50000.0, 90000.0}}, ""},
+		{"unknown field", dtos.SearchFilter{Field: "colour", Value: "red"}, `filter.colour: unknown field "colour"`},
+		{"unknown operator", dtos.SearchFilter{Field: "salary", Op: "like", Value: 1.0}, `filter.salary: unknown operator "like"`},
+		{"range on a list field", dtos.SearchFilter{Field: "skills", Op: "gt", Value: "go"}, "filter.skills: doesn't support gt"},
+		{"bad enum", dtos.SearchFilter{Field: "job_type", Value: "gig"}, `filter.job_type: unknown value "gig"`},
+		{"bad number", dtos.SearchFilter{Field: "salary", Op: "gte", Value: "lots"}, `filter.salary: "lots" isn't a number`},
+		{"between needs two values", dtos.SearchFilter{Field: "salary", Op: "between", Value: 1.0}, "filter.salary: between takes [low, high]"},
+		{"missing value", dtos.SearchFilter{Field: "skills"}, "filter.skills: value is required"},
+		{"empty group", dtos.SearchFilter{And: []dtos.SearchFilter{}}, "filter.and: empty group"},
+		{"field and group", dtos.SearchFilter{Field: "skills", Value: "go", Not: &dtos.SearchFilter{Field: "skills", Value: "rust"}}, "filter: needs exactly one of and, or, not or field"},
+		{"nested error path", dtos.SearchFilter{Or: []dtos.SearchFilter{{Field: "skills", Value: "go"}, {Not: &dtos.SearchFilter{Field: "is_remote", Value: "maybe"}}}},
+			`filter.or[1].not.is_remote: "maybe" isn't true or false`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repository.ValidateSearchFilter(&tt.filter)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, repository.ErrInvalidFilter)
+			assert.EqualError(t, err, "invalid filter: "+tt.wantErr)
+		})
+	}
+
+	deep := dtos.SearchFilter{Field: "skills", Value: "go"}
+	for i := 0; i < 10; i++ {
+		inner := deep
+		deep = dtos.SearchFilter{Not: &inner}
+	}
+	assert.ErrorIs(t, repository.ValidateSearchFilter(&deep), repository.ErrInvalidFilter)
+}
@@ -0,0 +1,214 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bhati00/workova/backend/constant"
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/model"
+	companyname "github.com/bhati00/workova/backend/pkg/company_name"
+	"github.com/bhati00/workova/backend/pkg/language"
+	"github.com/bhati00/workova/backend/pkg/similarity"
+	"github.com/bhati00/workova/backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// ErrJobNotFound is returned when no job has the requested ID
+var ErrJobNotFound = errors.New("job not found")
+
+const (
+	// similarCandidateLimit is the number of candidates fetched per kind (terms, skills,
+	// categories) before scoring
+	similarCandidateLimit = 200
+	// titleTermRepeat weighs title terms above description terms in the vector
+	titleTermRepeat = 3
+)
+
+// Weights of the similarity signals, summing to 1
+const (
+	similarityWeightText      = 0.45
+	similarityWeightSkills    = 0.25
+	similarityWeightCategory  = 0.10
+	similarityWeightLocation  = 0.10
+	similarityWeightSeniority = 0.10
+)
+
+// indexTermVector stores the TF-IDF vector of the job's title and description. IDF comes
+// from the postings indexed so far, so early vectors weigh common terms a bit higher.
+func (s *jobService) indexTermVector(job *model.Job) {
+	lang := utils.StringValue(job.Language)
+	text := utils.StringValue(job.DescriptionText)
+	if text == "" {
+		text = utils.StringValue(job.Summary)
+	}
+	titleTerms := language.Terms(job.Title, lang)
+	terms := language.Terms(text, lang)
+	for i := 0; i < titleTermRepeat; i++ {
+		terms = append(terms, titleTerms...)
+	}
+	if len(terms) == 0 {
+		return
+	}
+
+	frequencies := similarity.TermFrequencies(terms)
+	distinct := make([]string, 0, len(frequencies))
+	for term := range frequencies {
+		distinct = append(distinct, term)
+	}
+	sort.Strings(distinct)
+
+	documentFrequencies, documents, err := s.jobRepo.GetDocumentFrequencies(distinct)
+	if err != nil {
+		log.Printf("Failed to get document frequencies for job (ID: %d): %v", job.ID, err)
+		return
+	}
+	vector := similarity.NewVector(frequencies, documentFrequencies, documents+1, similarity.VectorSize)
+	if err := s.jobRepo.SaveTermVector(job.ID, distinct, vector); err != nil {
+		log.Printf("Failed to save term vector for job (ID: %d): %v", job.ID, err)
+	}
+}
+
+// GetSimilarJobs returns up to limit open jobs related to the job, most similar first.
+// Reposts of the job by its own company and repeated postings among the results are left out.
+func (s *jobService) GetSimilarJobs(id uint, limit int) ([]dtos.SimilarJob, error) {
+	source, err := s.jobRepo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	candidates, err := s.jobRepo.GetSimilarCandidates(id, similarCandidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get similar jobs: %w", err)
+	}
+	if len(candidates) == 0 {
+		return []dtos.SimilarJob{}, nil
+	}
+	ids := make([]uint, 0, len(candidates))
+	for candidateID := range candidates {
+		ids = append(ids, candidateID)
+	}
+	jobs, err := s.jobRepo.GetOpenByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get similar jobs: %w", err)
+	}
+
+	sourceSkills := jobSkillNames(source)
+	results := make([]dtos.SimilarJob, 0, len(jobs))
+	for _, job := range jobs {
+		if postingKey(&job) == postingKey(source) {
+			continue
+		}
+		skills := jobSkillNames(&job)
+		score := similarityWeightText*candidates[job.ID] +
+			similarityWeightSkills*similarity.Jaccard(sourceSkills, skills) +
+			similarityWeightCategory*similarity.Jaccard(jobCategoryIDs(source), jobCategoryIDs(&job)) +
+			similarityWeightLocation*locationSimilarity(source, &job) +
+			similarityWeightSeniority*senioritySimilarity(source, &job)
+
+		var shared []string
+		for _, skill := range skills {
+			if contains(sourceSkills, skill) {
+				shared = append(shared, skill)
+			}
+		}
+		results = append(results, dtos.SimilarJob{Job: job, Similarity: score, SharedSkills: shared})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Similarity != results[j].Similarity {
+			return results[i].Similarity > results[j].Similarity
+		}
+		return results[i].ID > results[j].ID
+	})
+
+	// Keep the most similar of postings repeated by the same company
+	seen := make(map[string]bool, len(results))
+	similar := make([]dtos.SimilarJob, 0, limit)
+	for _, result := range results {
+		key := postingKey(&result.Job)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		similar = append(similar, result)
+		if len(similar) == limit {
+			break
+		}
+	}
+	return similar, nil
+}
+
+// postingKey identifies repeated postings: the same title at the same company
+func postingKey(job *model.Job) string {
+	company := companyname.Normalize(job.CompanyName)
+	if job.CompanyID != nil {
+		company = strconv.FormatUint(uint64(*job.CompanyID), 10)
+	}
+	return company + "\x00" + strings.Join(strings.Fields(strings.ToLower(job.Title)), " ")
+}
+
+func jobSkillNames(job *model.Job) []string {
+	names := make([]string, 0, len(job.JobSkills))
+	for _, jobSkill := range job.JobSkills {
+		names = append(names, strings.ToLower(jobSkill.Skill.Name))
+	}
+	return names
+}
+
+func jobCategoryIDs(job *model.Job) []uint {
+	ids := make([]uint, 0, len(job.JobCategories))
+	for _, jobCategory := range job.JobCategories {
+		ids = append(ids, jobCategory.CategoryID)
+	}
+	return ids
+}
+
+// locationSimilarity is 1 for jobs sharing a city, 0.6 for jobs sharing a country and 0.5
+// for two remote jobs elsewhere
+func locationSimilarity(a, b *model.Job) float64 {
+	score := 0.0
+	for _, la := range a.JobLocations {
+		for _, lb := range b.JobLocations {
+			if la.City != nil && lb.City != nil && strings.EqualFold(*la.City, *lb.City) && la.CountryID == lb.CountryID {
+				return 1
+			}
+			if la.CountryID == lb.CountryID {
+				score = 0.6
+			}
+		}
+	}
+	if score == 0 && isRemoteJob(a) && isRemoteJob(b) {
+		score = 0.5
+	}
+	return score
+}
+
+// senioritySimilarity is 1 for the same experience level, 0.5 for adjacent levels and 0
+// otherwise or when a level is unknown
+func senioritySimilarity(a, b *model.Job) float64 {
+	la, lb := experienceLevel(a), experienceLevel(b)
+	if la == nil || lb == nil {
+		return 0
+	}
+	switch diff := int(*la) - int(*lb); {
+	case diff == 0:
+		return 1
+	case diff == 1 || diff == -1:
+		return 0.5
+	}
+	return 0
+}
+
+func experienceLevel(job *model.Job) *constant.ExperienceLevel {
+	if job.ExperienceLevel != nil {
+		return job.ExperienceLevel
+	}
+	return job.InferredExperienceLevel
+}
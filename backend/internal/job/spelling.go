@@ -0,0 +1,123 @@
+package job
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bhati00/workova/backend/dtos"
+)
+
+// lowHitThreshold is the number of results below which a search gets a spelling correction
+const lowHitThreshold = 3
+
+// correctSearch sets DidYouMean on a search with few or no results when correcting its
+// free text finds more jobs: the query and the text conditions of the filter tree, which
+// is where compact syntax queries like "golang remote:true" end up. DidYouMean is the
+// corrected free text. With AutoCorrect, a search that found nothing is replaced by the
+// search for the correction.
+func (s *jobService) correctSearch(params *dtos.JobSearchParams, response *dtos.PaginatedJobsResponse) error {
+	text := strings.TrimSpace(searchText(params))
+	if s.queryCorrector == nil || text == "" || response.TotalCount >= lowHitThreshold {
+		return nil
+	}
+
+	changed := false
+	correct := func(query string) (string, error) {
+		corrected, err := s.queryCorrector.CorrectQuery(query)
+		if err != nil || corrected == "" {
+			return query, err
+		}
+		changed = true
+		return corrected, nil
+	}
+	correctedParams := *params
+	var err error
+	if params.Query != "" {
+		correctedParams.Query, err = correct(params.Query)
+	}
+	if err == nil && params.Filter != nil {
+		correctedParams.Filter, err = correctFilterText(params.Filter, correct)
+	}
+	if err != nil {
+		// The search itself worked, it only goes without a suggestion
+		log.Printf("Failed to correct query %q: %v", text, err)
+		return nil
+	}
+	if !changed {
+		return nil
+	}
+	corrected := strings.TrimSpace(searchText(&correctedParams))
+
+	if response.TotalCount == 0 && params.AutoCorrect {
+		correctedResponse, err := s.runSearch(&correctedParams)
+		if err != nil {
+			return err
+		}
+		if correctedResponse.TotalCount > 0 {
+			*response = *correctedResponse
+			response.DidYouMean, response.AutoCorrected = corrected, true
+		}
+		return nil
+	}
+
+	correctedParams.Offset, correctedParams.Limit, correctedParams.Keyset = 0, 1, nil
+	correctedParams.Facets, correctedParams.Highlight = nil, nil
+	_, count, err := s.jobRepo.SearchJobs(&correctedParams)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+	if count > response.TotalCount {
+		response.DidYouMean = corrected
+	}
+	return nil
+}
+
+// correctFilterText returns a copy of filter with the values of its text conditions
+// corrected. Negated conditions are left alone, like in searchText.
+func correctFilterText(filter *dtos.SearchFilter, correct func(string) (string, error)) (*dtos.SearchFilter, error) {
+	corrected := *filter
+	correctAll := func(filters []dtos.SearchFilter) ([]dtos.SearchFilter, error) {
+		if filters == nil {
+			return nil, nil
+		}
+		list := make([]dtos.SearchFilter, len(filters))
+		for i := range filters {
+			child, err := correctFilterText(&filters[i], correct)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = *child
+		}
+		return list, nil
+	}
+
+	var err error
+	if corrected.And, err = correctAll(filter.And); err != nil {
+		return nil, err
+	}
+	if corrected.Or, err = correctAll(filter.Or); err != nil {
+		return nil, err
+	}
+	if filter.Field != "text" {
+		return &corrected, nil
+	}
+	switch value := filter.Value.(type) {
+	case string:
+		if corrected.Value, err = correct(value); err != nil {
+			return nil, err
+		}
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, v := range value {
+			list[i] = v
+			if text, ok := v.(string); ok {
+				if list[i], err = correct(text); err != nil {
+					return nil, err
+				}
+			}
+		}
+		corrected.Value = list
+	}
+	return &corrected, nil
+}
@@ -0,0 +1,88 @@
+package job
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user"
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/bhati00/workova/backend/pkg/suggest"
+	"github.com/gin-gonic/gin"
+)
+
+// maxSuggestQueryLength bounds q, longer input is not a typeahead prefix
+const maxSuggestQueryLength = 100
+
+// SuggestHandler handles HTTP requests for typeahead suggestions
+type SuggestHandler struct {
+	suggestService SuggestService
+}
+
+// NewSuggestHandler creates a new suggest handler instance
+func NewSuggestHandler(suggestService SuggestService) *SuggestHandler {
+	return &SuggestHandler{suggestService: suggestService}
+}
+
+// Suggest godoc
+// @Summary Typeahead suggestions
+// @Description Completes a partial query with skills, companies, popular titles, locations and categories of open jobs, most jobs first. A suggestion matches when its label, or a later word of it, starts with q. slug is the value for the matching search filter (skills, company, query, location or category).
+// @Tags Suggest
+// @Produce json
+// @Param q query string true "Partial query"
+// @Param types query string false "Comma-separated suggestion types: skill, company, title, location, category (default all)"
+// @Param limit query int false "Maximum suggestions, 1-50 (default 10)"
+// @Success 200 {object} dtos.APIResponse{data=[]dtos.Suggestion}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /suggest [get]
+func (h *SuggestHandler) Suggest(c *gin.Context) {
+	p := &searchParamParser{query: c.Request.URL.Query()}
+
+	query := p.text("q")
+	if query == "" {
+		p.fail("q", query, "q is required")
+	} else if len(query) > maxSuggestQueryLength {
+		p.fail("q", query, "q must be at most %d characters", maxSuggestQueryLength)
+	}
+	var types []string
+	for _, t := range p.list("types") {
+		if t = strings.ToLower(t); !contains(suggest.Types, t) {
+			p.fail("types", t, "types must be among %s", strings.Join(suggest.Types, ", "))
+			continue
+		}
+		types = append(types, t)
+	}
+	limit := 10
+	if value := p.integer("limit", 1, 50); value != nil {
+		limit = *value
+	}
+
+	if len(p.errors) > 0 {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid suggest parameters",
+			Errors:  p.errors,
+		})
+		return
+	}
+
+	suggestions, err := h.suggestService.Suggest(query, types, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get suggestions: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    suggestions,
+	})
+}
+
+// RegisterSuggestRoutes registers the typeahead route
+func (h *SuggestHandler) RegisterSuggestRoutes(router *gin.RouterGroup) {
+	router.GET("/suggest", user.RequireScope(usermodel.ScopeJobsRead), h.Suggest)
+}
@@ -0,0 +1,143 @@
+package job
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/repository"
+	"github.com/bhati00/workova/backend/pkg/language"
+	"github.com/bhati00/workova/backend/pkg/spell"
+	"github.com/bhati00/workova/backend/pkg/suggest"
+	"github.com/bhati00/workova/backend/pkg/synonym"
+)
+
+// suggestIndexTTL is how long a suggestion index is served before it is rebuilt in the
+// background. New jobs only show up in suggestions after a rebuild.
+const suggestIndexTTL = 5 * time.Minute
+
+// SuggestService completes partial queries from the skills, companies, titles, locations
+// and categories of open jobs, and corrects misspelled queries against the same words
+type SuggestService interface {
+	// Suggest returns up to limit completions of query among types (all when empty), most jobs first
+	Suggest(query string, types []string, limit int) ([]dtos.Suggestion, error)
+	// CorrectQuery returns query with misspelled words replaced, "" when there is nothing to correct
+	CorrectQuery(query string) (string, error)
+	// Refresh rebuilds the index from the database
+	Refresh() error
+}
+
+// suggestService keeps an in-memory index and vocabulary, built on first use and rebuilt
+// in the background once they are older than ttl. Requests keep using the old ones meanwhile.
+type suggestService struct {
+	suggestRepo repository.SuggestRepository
+	ttl         time.Duration
+
+	mu         sync.RWMutex
+	index      *suggest.Index
+	vocabulary *spell.Vocabulary
+	builtAt    time.Time
+	refreshing bool
+}
+
+// NewSuggestService creates a new suggest service instance
+func NewSuggestService(suggestRepo repository.SuggestRepository) SuggestService {
+	return &suggestService{suggestRepo: suggestRepo, ttl: suggestIndexTTL}
+}
+
+func (s *suggestService) Suggest(query string, types []string, limit int) ([]dtos.Suggestion, error) {
+	index, _, err := s.currentIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := index.Search(query, types, limit)
+	suggestions := make([]dtos.Suggestion, len(matches))
+	for i, match := range matches {
+		suggestions[i] = dtos.Suggestion{
+			Type:     match.Type,
+			Label:    match.Label,
+			Slug:     match.Slug,
+			JobCount: match.Count,
+			Match:    match.Match,
+		}
+		if match.ID != 0 {
+			id := match.ID
+			suggestions[i].ID = &id
+		}
+	}
+	return suggestions, nil
+}
+
+func (s *suggestService) Refresh() error {
+	entries, err := s.suggestRepo.GetSuggestionEntries()
+	if err != nil {
+		return fmt.Errorf("failed to load suggestions: %w", err)
+	}
+	index := suggest.NewIndex(entries)
+	vocabulary := spell.NewVocabulary()
+	for _, entry := range entries {
+		vocabulary.Add(entry.Label, entry.Count)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index, s.vocabulary, s.builtAt = index, vocabulary, time.Now()
+	return nil
+}
+
+// CorrectQuery leaves alone words the synonym dictionary knows, so "swe" isn't corrected
+// to a word that happens to be indexed
+func (s *suggestService) CorrectQuery(query string) (string, error) {
+	_, vocabulary, err := s.currentIndex()
+	if err != nil {
+		return "", err
+	}
+
+	synonyms := make(map[string]bool)
+	for _, phrases := range synonym.Default().Expand(query) {
+		if len(phrases) > 1 {
+			for _, word := range language.Words(phrases[0]) {
+				synonyms[word] = true
+			}
+		}
+	}
+	return vocabulary.CorrectQuery(query, func(word string) bool { return synonyms[word] }), nil
+}
+
+// currentIndex returns the index and vocabulary, building them when there are none yet
+// and starting a background rebuild when they are stale
+func (s *suggestService) currentIndex() (*suggest.Index, *spell.Vocabulary, error) {
+	s.mu.RLock()
+	index, vocabulary, stale := s.index, s.vocabulary, time.Since(s.builtAt) > s.ttl
+	s.mu.RUnlock()
+
+	if index == nil {
+		if err := s.Refresh(); err != nil {
+			return nil, nil, err
+		}
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.index, s.vocabulary, nil
+	}
+
+	if stale {
+		s.mu.Lock()
+		start := !s.refreshing
+		s.refreshing = true
+		s.mu.Unlock()
+		if start {
+			go func() {
+				if err := s.Refresh(); err != nil {
+					log.Printf("Failed to refresh suggestions: %v", err)
+				}
+				s.mu.Lock()
+				s.refreshing = false
+				s.mu.Unlock()
+			}()
+		}
+	}
+	return index, vocabulary, nil
+}
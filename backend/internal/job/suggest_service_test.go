@@ -0,0 +1,150 @@
+package job
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/job/mocks"
+	"github.com/bhati00/workova/backend/pkg/suggest"
+	"github.com/bhati00/workova/backend/pkg/synonym"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var suggestEntries = []suggest.Entry{
+	{Type: suggest.TypeSkill, Label: "Go", Slug: "go", ID: 1, Count: 40},
+	{Type: suggest.TypeCompany, Label: "Google", Slug: "google", ID: 7, Count: 12},
+	{Type: suggest.TypeTitle, Label: "Senior Go Engineer", Slug: "Senior Go Engineer", Count: 5},
+	{Type: suggest.TypeLocation, Label: "Goa", Slug: "goa", Count: 2},
+}
+
+func TestSuggestService_Suggest(t *testing.T) {
+	repo := new(mocks.MockSuggestRepository)
+	repo.On("GetSuggestionEntries").Return(suggestEntries, nil).Once()
+	service := NewSuggestService(repo)
+
+	suggestions, err := service.Suggest("go", nil, 10)
+	assert.NoError(t, err)
+	id1, id7 := uint(1), uint(7)
+	assert.Equal(t, []dtos.Suggestion{
+		{Type: "skill", Label: "Go", ID: &id1, Slug: "go", JobCount: 40, Match: "prefix"},
+		{Type: "company", Label: "Google", ID: &id7, Slug: "google", JobCount: 12, Match: "prefix"},
+		{Type: "title", Label: "Senior Go Engineer", Slug: "Senior Go Engineer", JobCount: 5, Match: "word"},
+		{Type: "location", Label: "Goa", Slug: "goa", JobCount: 2, Match: "prefix"},
+	}, suggestions)
+
+	// The index is built once and reused
+	suggestions, err = service.Suggest("go", []string{"title"}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+	repo.AssertNumberOfCalls(t, "GetSuggestionEntries", 1)
+}
+
+func TestSuggestService_StaleIndex(t *testing.T) {
+	repo := new(mocks.MockSuggestRepository)
+	repo.On("GetSuggestionEntries").Return(suggestEntries[:1], nil).Once()
+	repo.On("GetSuggestionEntries").Return(suggestEntries, nil)
+	service := NewSuggestService(repo).(*suggestService)
+	assert.NoError(t, service.Refresh())
+	service.ttl = 0
+
+	// A stale index is still served while it is rebuilt in the background
+	suggestions, err := service.Suggest("goo", nil, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, suggestions)
+	assert.Eventually(t, func() bool {
+		suggestions, _ := service.Suggest("goo", nil, 10)
+		return len(suggestions) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSuggestService_CorrectQuery(t *testing.T) {
+	repo := new(mocks.MockSuggestRepository)
+	repo.On("GetSuggestionEntries").Return(append(suggestEntries,
+		suggest.Entry{Type: suggest.TypeSkill, Label: "Kubernetes", Slug: "kubernetes", Count: 30},
+		suggest.Entry{Type: suggest.TypeTitle, Label: "Sweeper", Slug: "Sweeper", Count: 1},
+	), nil).Once()
+	service := NewSuggestService(repo)
+
+	corrected, err := service.CorrectQuery("senoir kubernets engineer")
+	assert.NoError(t, err)
+	assert.Equal(t, "senior kubernetes engineer", corrected)
+
+	corrected, err = service.CorrectQuery("Google")
+	assert.NoError(t, err)
+	assert.Empty(t, corrected)
+
+	// Words with synonyms are left alone
+	previous := synonym.Default()
+	defer synonym.SetDefault(previous)
+	synonym.SetDefault(synonym.NewDictionary([]synonym.Rule{{Terms: []string{"sweepr"}, Expansions: []string{"cleaner"}}}))
+	corrected, err = service.CorrectQuery("sweepr")
+	assert.NoError(t, err)
+	assert.Empty(t, corrected)
+	repo.AssertNumberOfCalls(t, "GetSuggestionEntries", 1)
+}
+
+func TestSuggestService_LoadError(t *testing.T) {
+	repo := new(mocks.MockSuggestRepository)
+	repo.On("GetSuggestionEntries").Return(nil, errors.New("database is locked"))
+
+	_, err := NewSuggestService(repo).Suggest("go", nil, 10)
+	assert.ErrorContains(t, err, "database is locked")
+}
+
+func TestSuggestHandler_Suggest(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantLabels []string
+		wantFields []string
+	}{
+		{"all types", "q=go", http.StatusOK, []string{"Go", "Google", "Senior Go Engineer", "Goa"}, nil},
+		{"types and limit", "q=go&types=company,Location&limit=1", http.StatusOK, []string{"Google"}, nil},
+		{"no match", "q=rust", http.StatusOK, []string{}, nil},
+		{"missing q", "types=skill", http.StatusBadRequest, nil, []string{"q"}},
+		{"unknown type", "q=go&types=skill,colour", http.StatusBadRequest, nil, []string{"types"}},
+		{"limit out of range", "q=go&limit=500", http.StatusBadRequest, nil, []string{"limit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockSuggestRepository)
+			repo.On("GetSuggestionEntries").Return(suggestEntries, nil)
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			NewSuggestHandler(NewSuggestService(repo)).RegisterSuggestRoutes(router.Group("/api"))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/suggest?"+tt.query, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var response struct {
+				dtos.APIResponse
+				Data []dtos.Suggestion `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.wantStatus != http.StatusOK {
+				fields := make([]string, len(response.Errors))
+				for i, fieldError := range response.Errors {
+					fields[i] = fieldError.Field
+				}
+				assert.Equal(t, tt.wantFields, fields)
+				repo.AssertNotCalled(t, "GetSuggestionEntries", mock.Anything)
+				return
+			}
+			labels := make([]string, len(response.Data))
+			for i, suggestion := range response.Data {
+				labels[i] = suggestion.Label
+			}
+			assert.Equal(t, tt.wantLabels, labels)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package job
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user"
+	"github.com/bhati00/workova/backend/pkg/synonym"
+	"github.com/gin-gonic/gin"
+)
+
+// SynonymHandler handles HTTP requests for the search synonym dictionary
+type SynonymHandler struct {
+	synonymService SynonymService
+}
+
+// NewSynonymHandler creates a new synonym handler instance
+func NewSynonymHandler(synonymService SynonymService) *SynonymHandler {
+	return &SynonymHandler{synonymService: synonymService}
+}
+
+// GetSynonymRules godoc
+// @Summary List search synonyms
+// @Description Returns the rules job search queries are expanded with, in file order
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.APIResponse{data=[]dtos.SynonymRule}
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Router /admin/synonyms [get]
+func (h *SynonymHandler) GetSynonymRules(c *gin.Context) {
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    h.synonymService.GetRules(),
+	})
+}
+
+// AddSynonymRule godoc
+// @Summary Add a search synonym rule
+// @Description Adds a rule to the synonyms file. Without expansions the terms are equivalent, e.g. {"terms": ["frontend", "front-end"]}. With expansions searching a term also finds its expansions but not the reverse, e.g. {"terms": ["swe"], "expansions": ["software engineer"]}.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body dtos.SynonymRuleRequest true "Rule"
+// @Success 201 {object} dtos.APIResponse{data=dtos.SynonymRule}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/synonyms [post]
+func (h *SynonymHandler) AddSynonymRule(c *gin.Context) {
+	var request dtos.SynonymRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.synonymService.AddRule(request)
+	if err != nil {
+		c.JSON(synonymErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to add synonym rule: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dtos.APIResponse{
+		Success: true,
+		Message: "Synonym rule added successfully",
+		Data:    rule,
+	})
+}
+
+// UpdateSynonymRule godoc
+// @Summary Replace a search synonym rule
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Rule ID"
+// @Param rule body dtos.SynonymRuleRequest true "Rule"
+// @Success 200 {object} dtos.APIResponse{data=dtos.SynonymRule}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/synonyms/{id} [put]
+func (h *SynonymHandler) UpdateSynonymRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid rule ID",
+		})
+		return
+	}
+	var request dtos.SynonymRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.synonymService.UpdateRule(id, request)
+	if err != nil {
+		c.JSON(synonymErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to update synonym rule: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "Synonym rule updated successfully",
+		Data:    rule,
+	})
+}
+
+// DeleteSynonymRule godoc
+// @Summary Delete a search synonym rule
+// @Description Deletes the rule, the IDs of the rules after it shift down by one
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Rule ID"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/synonyms/{id} [delete]
+func (h *SynonymHandler) DeleteSynonymRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid rule ID",
+		})
+		return
+	}
+
+	if err := h.synonymService.DeleteRule(id); err != nil {
+		c.JSON(synonymErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to delete synonym rule: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "Synonym rule deleted successfully",
+	})
+}
+
+// ReloadSynonyms godoc
+// @Summary Reload search synonyms
+// @Description Reads the synonyms file again after it was edited by hand
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.APIResponse{data=[]dtos.SynonymRule}
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/synonyms/reload [post]
+func (h *SynonymHandler) ReloadSynonyms(c *gin.Context) {
+	if err := h.synonymService.Load(); err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to reload synonyms: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "Synonyms reloaded successfully",
+		Data:    h.synonymService.GetRules(),
+	})
+}
+
+// ExpandQuery godoc
+// @Summary Preview a query expansion
+// @Description Returns the groups of alternative phrases a job search for query matches. A job matches when it contains one phrase of every group.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param query query string true "Search query"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Router /admin/synonyms/expand [get]
+func (h *SynonymHandler) ExpandQuery(c *gin.Context) {
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    h.synonymService.Expand(c.Query("query")),
+	})
+}
+
+// synonymErrorStatus maps synonym service errors to HTTP status codes
+func synonymErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrSynonymRuleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, synonym.ErrInvalidRule):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// RegisterSynonymAdminRoutes registers the synonym dictionary routes
+func (h *SynonymHandler) RegisterSynonymAdminRoutes(router *gin.RouterGroup) {
+	synonyms := router.Group("/admin/synonyms", user.RequirePermission(user.PermissionManageSearch))
+	{
+		synonyms.GET("", h.GetSynonymRules)
+		synonyms.POST("", h.AddSynonymRule)
+		synonyms.GET("/expand", h.ExpandQuery)
+		synonyms.POST("/reload", h.ReloadSynonyms)
+		synonyms.PUT("/:id", h.UpdateSynonymRule)
+		synonyms.DELETE("/:id", h.DeleteSynonymRule)
+	}
+}
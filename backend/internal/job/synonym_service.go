@@ -0,0 +1,187 @@
+package job
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/pkg/synonym"
+)
+
+// ErrSynonymRuleNotFound is returned when no synonym rule has the requested ID
+var ErrSynonymRuleNotFound = errors.New("synonym rule not found")
+
+// synonymsFileHeader starts every synonyms file the service writes
+const synonymsFileHeader = `# Search synonyms, one rule per line. Edited through /api/admin/synonyms, which
+# rewrites this file without comments.
+#   frontend, front-end, front end    equivalent phrases, each matches all the others
+#   swe, sde => software engineer     searching swe or sde also finds software engineer
+`
+
+// SynonymService manages the synonym dictionary job searches are expanded with. Rules are
+// kept in a synonyms file and every change is written back to it.
+type SynonymService interface {
+	// Load reads the synonyms file and makes its rules the dictionary of searches. A missing
+	// file loads no rules.
+	Load() error
+	GetRules() []dtos.SynonymRule
+	AddRule(request dtos.SynonymRuleRequest) (*dtos.SynonymRule, error)
+	UpdateRule(id int, request dtos.SynonymRuleRequest) (*dtos.SynonymRule, error)
+	DeleteRule(id int) error
+	// Expand returns the groups of alternative phrases a search for query matches
+	Expand(query string) [][]string
+}
+
+// synonymService implements SynonymService interface
+type synonymService struct {
+	path string
+
+	mu    sync.Mutex
+	rules []synonym.Rule
+}
+
+// NewSynonymService creates a synonym service backed by the synonyms file at path
+func NewSynonymService(path string) SynonymService {
+	return &synonymService{path: path}
+}
+
+func (s *synonymService) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = nil, nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read synonyms: %w", err)
+	}
+	rules, err := synonym.Parse(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	s.apply(rules)
+	return nil
+}
+
+func (s *synonymService) GetRules() []dtos.SynonymRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules := make([]dtos.SynonymRule, len(s.rules))
+	for i, rule := range s.rules {
+		rules[i] = synonymRuleResponse(i+1, rule)
+	}
+	return rules
+}
+
+func (s *synonymService) AddRule(request dtos.SynonymRuleRequest) (*dtos.SynonymRule, error) {
+	rule, err := synonymRule(request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.save(append(append([]synonym.Rule{}, s.rules...), rule)); err != nil {
+		return nil, err
+	}
+	response := synonymRuleResponse(len(s.rules), rule)
+	return &response, nil
+}
+
+func (s *synonymService) UpdateRule(id int, request dtos.SynonymRuleRequest) (*dtos.SynonymRule, error) {
+	rule, err := synonymRule(request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id < 1 || id > len(s.rules) {
+		return nil, ErrSynonymRuleNotFound
+	}
+	rules := append([]synonym.Rule{}, s.rules...)
+	rules[id-1] = rule
+	if err := s.save(rules); err != nil {
+		return nil, err
+	}
+	response := synonymRuleResponse(id, rule)
+	return &response, nil
+}
+
+func (s *synonymService) DeleteRule(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id < 1 || id > len(s.rules) {
+		return ErrSynonymRuleNotFound
+	}
+	rules := append(append([]synonym.Rule{}, s.rules[:id-1]...), s.rules[id:]...)
+	return s.save(rules)
+}
+
+func (s *synonymService) Expand(query string) [][]string {
+	return synonym.Default().Expand(query)
+}
+
+// save writes rules to the synonyms file and applies them once written. The file is
+// replaced in one rename so a crash never leaves it half written.
+func (s *synonymService) save(rules []synonym.Rule) error {
+	var buf bytes.Buffer
+	buf.WriteString(synonymsFileHeader)
+	if err := synonym.Write(&buf, rules); err != nil {
+		return fmt.Errorf("failed to write synonyms: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to write synonyms: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write synonyms: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write synonyms: %w", err)
+	}
+	s.apply(rules)
+	return nil
+}
+
+func (s *synonymService) apply(rules []synonym.Rule) {
+	s.rules = rules
+	synonym.SetDefault(synonym.NewDictionary(rules))
+}
+
+// synonymRule validates a request and turns it into a rule, whitespace trimmed
+func synonymRule(request dtos.SynonymRuleRequest) (synonym.Rule, error) {
+	rule := synonym.Rule{Terms: trimPhrases(request.Terms), Expansions: trimPhrases(request.Expansions)}
+	if err := rule.Validate(); err != nil {
+		return synonym.Rule{}, err
+	}
+	return rule, nil
+}
+
+func trimPhrases(phrases []string) []string {
+	var trimmed []string
+	for _, phrase := range phrases {
+		if phrase = strings.Join(strings.Fields(phrase), " "); phrase != "" {
+			trimmed = append(trimmed, phrase)
+		}
+	}
+	return trimmed
+}
+
+func synonymRuleResponse(id int, rule synonym.Rule) dtos.SynonymRule {
+	return dtos.SynonymRule{
+		ID:            id,
+		Terms:         rule.Terms,
+		Expansions:    rule.Expansions,
+		Bidirectional: rule.Bidirectional(),
+	}
+}
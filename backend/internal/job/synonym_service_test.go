@@ -0,0 +1,123 @@
+package job
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user"
+	usermodel "github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/bhati00/workova/backend/pkg/synonym"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSynonymService(t *testing.T, content string) (SynonymService, string) {
+	previous := synonym.Default()
+	t.Cleanup(func() { synonym.SetDefault(previous) })
+
+	path := filepath.Join(t.TempDir(), "synonyms.txt")
+	if content != "" {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	service := NewSynonymService(path)
+	require.NoError(t, service.Load())
+	return service, path
+}
+
+func TestSynonymService_Load(t *testing.T) {
+	service, path := newTestSynonymService(t, "# comment\nswe => software engineer\nfrontend, front-end\n")
+
+	assert.Equal(t, []dtos.SynonymRule{
+		{ID: 1, Terms: []string{"swe"}, Expansions: []string{"software engineer"}},
+		{ID: 2, Terms: []string{"frontend", "front-end"}, Bidirectional: true},
+	}, service.GetRules())
+	assert.Equal(t, [][]string{{"SWE", "software engineer"}, {"Berlin"}}, service.Expand("SWE Berlin"))
+	assert.Equal(t, [][]string{{"front-end", "frontend"}}, synonym.Default().Expand("front-end"), "searches use the loaded rules")
+
+	t.Run("missing file loads no rules", func(t *testing.T) {
+		service, _ := newTestSynonymService(t, "")
+		assert.Empty(t, service.GetRules())
+	})
+
+	t.Run("invalid file keeps the rules", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("swe =>\n"), 0o644))
+		assert.ErrorContains(t, service.Load(), "line 1")
+		assert.Len(t, service.GetRules(), 2)
+		assert.Equal(t, [][]string{{"swe", "software engineer"}}, synonym.Default().Expand("swe"))
+	})
+}
+
+func TestSynonymService_Edit(t *testing.T) {
+	service, path := newTestSynonymService(t, "swe => software engineer\n")
+
+	rule, err := service.AddRule(dtos.SynonymRuleRequest{Terms: []string{" ML "}, Expansions: []string{"machine   learning"}})
+	require.NoError(t, err)
+	assert.Equal(t, &dtos.SynonymRule{ID: 2, Terms: []string{"ML"}, Expansions: []string{"machine learning"}}, rule)
+	assert.Equal(t, [][]string{{"ml", "machine learning"}}, synonym.Default().Expand("ml"))
+
+	rule, err = service.UpdateRule(1, dtos.SynonymRuleRequest{Terms: []string{"swe", "software engineer"}})
+	require.NoError(t, err)
+	assert.True(t, rule.Bidirectional)
+	assert.Equal(t, [][]string{{"software engineer", "swe"}}, synonym.Default().Expand("software engineer"))
+
+	require.NoError(t, service.DeleteRule(2))
+	assert.Equal(t, [][]string{{"ml"}}, synonym.Default().Expand("ml"))
+
+	// Edits survive a restart
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), "\nswe, software engineer\n"), string(data))
+	reloaded, _ := newTestSynonymService(t, string(data))
+	assert.Equal(t, service.GetRules(), reloaded.GetRules())
+
+	_, err = service.AddRule(dtos.SynonymRuleRequest{Terms: []string{"swe"}})
+	assert.ErrorIs(t, err, synonym.ErrInvalidRule)
+	_, err = service.UpdateRule(5, dtos.SynonymRuleRequest{Terms: []string{"a", "b"}})
+	assert.ErrorIs(t, err, ErrSynonymRuleNotFound)
+	assert.ErrorIs(t, service.DeleteRule(0), ErrSynonymRuleNotFound)
+}
+
+func TestSynonymHandler(t *testing.T) {
+	service, _ := newTestSynonymService(t, "swe => software engineer\n")
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(user.Authenticate(roleUserService{}))
+	NewSynonymHandler(service).RegisterSynonymAdminRoutes(router.Group("/api"))
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/api/admin/synonyms", "", http.StatusOK},
+		{http.MethodPost, "/api/admin/synonyms", `{"terms": ["k8s", "kubernetes"]}`, http.StatusCreated},
+		{http.MethodPost, "/api/admin/synonyms", `{"terms": ["k8s"]}`, http.StatusBadRequest},
+		{http.MethodPost, "/api/admin/synonyms", `{"terms": "k8s"}`, http.StatusBadRequest},
+		{http.MethodPut, "/api/admin/synonyms/1", `{"terms": ["swe"], "expansions": ["software developer"]}`, http.StatusOK},
+		{http.MethodPut, "/api/admin/synonyms/9", `{"terms": ["a", "b"]}`, http.StatusNotFound},
+		{http.MethodDelete, "/api/admin/synonyms/x", "", http.StatusBadRequest},
+		{http.MethodDelete, "/api/admin/synonyms/2", "", http.StatusOK},
+		{http.MethodPost, "/api/admin/synonyms/reload", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		authenticateAs(req, usermodel.RoleAdmin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.status, w.Code, "%s %s: %s", tt.method, tt.path, w.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/synonyms/expand?query=swe+go", nil)
+	authenticateAs(req, usermodel.RoleAdmin)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.JSONEq(t, `{"success": true, "data": [["swe", "software developer"], ["go"]]}`, w.Body.String())
+}
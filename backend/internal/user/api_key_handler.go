@@ -0,0 +1,241 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler handles HTTP requests for managing partner API keys
+type APIKeyHandler struct {
+	apiKeyService APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler instance
+func NewAPIKeyHandler(apiKeyService APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// CreateAPIKey godoc
+// @Summary Issue an API key
+// @Description Issues a key for the X-API-Key header. The key is only shown in this response. Scopes are jobs:read, jobs:write and export.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key body dtos.CreateAPIKeyRequest true "Key"
+// @Success 201 {object} dtos.APIResponse{data=dtos.APIKeyResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var request dtos.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	var createdByID *uint
+	if user := CurrentUser(c); user != nil {
+		createdByID = &user.ID
+	}
+	key, err := h.apiKeyService.CreateKey(request, createdByID)
+	if err != nil {
+		c.JSON(apiKeyErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to create API key: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dtos.APIResponse{
+		Success: true,
+		Message: "API key created, store it now as it won't be shown again",
+		Data:    key,
+	})
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description Returns paginated API keys with their usage today, newest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} dtos.APIResponse{data=dtos.PaginatedAPIKeysResponse}
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.apiKeyService.ListKeys(page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get API keys: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// GetAPIKey godoc
+// @Summary Inspect an API key
+// @Description Returns an API key with its requests per day of the last 30 days
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 200 {object} dtos.APIResponse{data=dtos.APIKeyResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/api-keys/{id} [get]
+func (h *APIKeyHandler) GetAPIKey(c *gin.Context) {
+	id, ok := apiKeyID(c)
+	if !ok {
+		return
+	}
+
+	key, err := h.apiKeyService.GetKey(id)
+	if err != nil {
+		c.JSON(apiKeyErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get API key: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    key,
+	})
+}
+
+// RotateAPIKey godoc
+// @Summary Rotate an API key
+// @Description Replaces the secret of an API key, keeping its scopes, quota and usage. The old key stops working right away, the new one is only shown in this response.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 200 {object} dtos.APIResponse{data=dtos.APIKeyResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 409 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/api-keys/{id}/rotate [post]
+func (h *APIKeyHandler) RotateAPIKey(c *gin.Context) {
+	id, ok := apiKeyID(c)
+	if !ok {
+		return
+	}
+
+	key, err := h.apiKeyService.RotateKey(id)
+	if err != nil {
+		c.JSON(apiKeyErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to rotate API key: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "API key rotated, store it now as it won't be shown again",
+		Data:    key,
+	})
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description The key stops working right away. Revoked keys stay listed with their usage.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, ok := apiKeyID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.apiKeyService.RevokeKey(id); err != nil {
+		c.JSON(apiKeyErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to revoke API key: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "API key revoked successfully",
+	})
+}
+
+// apiKeyID parses the id path parameter, responding with 400 when it is malformed
+func apiKeyID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid API key ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// apiKeyErrorStatus maps API key service errors to HTTP status codes
+func apiKeyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidAPIKeyRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrAPIKeyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrAPIKeyRevoked):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// RegisterAPIKeyAdminRoutes registers the API key management routes, for admins only
+func (h *APIKeyHandler) RegisterAPIKeyAdminRoutes(router *gin.RouterGroup) {
+	keys := router.Group("/admin/api-keys", RequirePermission(PermissionManageAPIKeys))
+	{
+		keys.GET("", h.ListAPIKeys)
+		keys.POST("", h.CreateAPIKey)
+		keys.GET("/:id", h.GetAPIKey)
+		keys.POST("/:id/rotate", h.RotateAPIKey)
+		keys.DELETE("/:id", h.RevokeAPIKey)
+	}
+}
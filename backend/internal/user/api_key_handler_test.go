@@ -0,0 +1,287 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bhati00/workova/backend/internal/user/mocks"
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newAPIKeyRouter serves the API key admin routes behind both authentication middlewares,
+// along with GET /api/read guarded by the jobs:read scope and POST /api/post guarded by
+// the permission to create jobs
+func newAPIKeyRouter(userRepo *mocks.MockUserRepository, apiKeyRepo *mocks.MockAPIKeyRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	apiKeyService := newTestAPIKeyService(apiKeyRepo)
+	router := gin.New()
+	router.Use(Authenticate(newTestUserService(userRepo)), AuthenticateAPIKey(apiKeyService))
+	api := router.Group("/api")
+	NewAPIKeyHandler(apiKeyService).RegisterAPIKeyAdminRoutes(api)
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	api.GET("/read", RequireScope(model.ScopeJobsRead), ok)
+	api.POST("/post", RequirePermission(PermissionCreateJobs), ok)
+	return router
+}
+
+func TestAuthenticateAPIKeyMiddleware(t *testing.T) {
+	withScopes := func(scopes string) *model.APIKey {
+		return &model.APIKey{ID: 4, Prefix: "0123456789ab", KeyHash: hashToken(testAPIKey), Scopes: scopes, DailyQuota: 100}
+	}
+	tests := []struct {
+		name              string
+		method            string
+		path              string
+		key               string
+		setupMocks        func(*mocks.MockAPIKeyRepository)
+		expectedStatus    int
+		expectedRemaining string
+	}{
+		{
+			name:           "anonymous_read",
+			method:         http.MethodGet,
+			path:           "/api/read",
+			setupMocks:     func(*mocks.MockAPIKeyRepository) {},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "read_with_scope",
+			method: http.MethodGet,
+			path:   "/api/read",
+			key:    testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(withScopes("jobs:read"), nil)
+				apiKeyRepo.On("IncrementUsage", uint(4), "2025-03-01", testNow).Return(int64(1), nil)
+			},
+			expectedStatus:    http.StatusNoContent,
+			expectedRemaining: "99",
+		},
+		{
+			name:   "read_without_scope",
+			method: http.MethodGet,
+			path:   "/api/read",
+			key:    testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(withScopes("export"), nil)
+				apiKeyRepo.On("IncrementUsage", uint(4), "2025-03-01", testNow).Return(int64(2), nil)
+			},
+			expectedStatus:    http.StatusForbidden,
+			expectedRemaining: "98",
+		},
+		{
+			name:   "write_with_scope",
+			method: http.MethodPost,
+			path:   "/api/post",
+			key:    testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(withScopes("jobs:read,jobs:write"), nil)
+				apiKeyRepo.On("IncrementUsage", uint(4), "2025-03-01", testNow).Return(int64(3), nil)
+			},
+			expectedStatus:    http.StatusNoContent,
+			expectedRemaining: "97",
+		},
+		{
+			name:   "write_without_scope",
+			method: http.MethodPost,
+			path:   "/api/post",
+			key:    testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(withScopes("jobs:read"), nil)
+				apiKeyRepo.On("IncrementUsage", uint(4), "2025-03-01", testNow).Return(int64(3), nil)
+			},
+			expectedStatus:    http.StatusForbidden,
+			expectedRemaining: "97",
+		},
+		{
+			name:           "anonymous_write",
+			method:         http.MethodPost,
+			path:           "/api/post",
+			setupMocks:     func(*mocks.MockAPIKeyRepository) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "over_quota",
+			method: http.MethodGet,
+			path:   "/api/read",
+			key:    testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(withScopes("jobs:read"), nil)
+				apiKeyRepo.On("IncrementUsage", uint(4), "2025-03-01", testNow).Return(int64(101), nil)
+			},
+			expectedStatus:    http.StatusTooManyRequests,
+			expectedRemaining: "0",
+		},
+		{
+			name:   "invalid_key",
+			method: http.MethodGet,
+			path:   "/api/read",
+			key:    testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "keys_cannot_manage_keys",
+			method: http.MethodGet,
+			path:   "/api/admin/api-keys",
+			key:    testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(withScopes("jobs:read,jobs:write,export"), nil)
+				apiKeyRepo.On("IncrementUsage", uint(4), "2025-03-01", testNow).Return(int64(5), nil)
+			},
+			expectedStatus:    http.StatusForbidden,
+			expectedRemaining: "95",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyRepo := &mocks.MockAPIKeyRepository{}
+			tt.setupMocks(mockAPIKeyRepo)
+			router := newAPIKeyRouter(&mocks.MockUserRepository{}, mockAPIKeyRepo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Equal(t, tt.expectedRemaining, w.Header().Get("X-RateLimit-Remaining"))
+			if tt.expectedRemaining != "" {
+				assert.Equal(t, "100", w.Header().Get("X-RateLimit-Limit"))
+				assert.Equal(t, "1740873600", w.Header().Get("X-RateLimit-Reset"))
+			}
+			if tt.expectedStatus == http.StatusTooManyRequests {
+				assert.NotEmpty(t, w.Header().Get("Retry-After"))
+			}
+			mockAPIKeyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeyHandler_AdminRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           model.Role
+		method         string
+		path           string
+		body           string
+		setupMocks     func(*mocks.MockAPIKeyRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "moderator_cannot_create_keys",
+			role:           model.RoleModerator,
+			method:         http.MethodPost,
+			path:           "/api/admin/api-keys",
+			body:           `{"name": "Partner", "scopes": ["jobs:read"]}`,
+			setupMocks:     func(*mocks.MockAPIKeyRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "admin_creates_key",
+			role:   model.RoleAdmin,
+			method: http.MethodPost,
+			path:   "/api/admin/api-keys",
+			body:   `{"name": "Partner", "scopes": ["jobs:read"], "daily_quota": 500}`,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("Create", mock.MatchedBy(func(key *model.APIKey) bool {
+					return key.DailyQuota == 500 && *key.CreatedByID == 7
+				})).Return(&model.APIKey{ID: 4}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "admin_creates_key_with_unknown_scope",
+			role:           model.RoleAdmin,
+			method:         http.MethodPost,
+			path:           "/api/admin/api-keys",
+			body:           `{"name": "Partner", "scopes": ["admin"]}`,
+			setupMocks:     func(*mocks.MockAPIKeyRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "admin_lists_keys",
+			role:   model.RoleAdmin,
+			method: http.MethodGet,
+			path:   "/api/admin/api-keys?page=2&page_size=10",
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("List", 10, 10).Return([]model.APIKey{{ID: 4}}, int64(11), nil)
+				apiKeyRepo.On("CountUsage", []uint{4}, "2025-03-01").Return(map[uint]int64{4: 8}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "admin_inspects_unknown_key",
+			role:   model.RoleAdmin,
+			method: http.MethodGet,
+			path:   "/api/admin/api-keys/4",
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByID", uint(4)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "admin_inspects_malformed_id",
+			role:           model.RoleAdmin,
+			method:         http.MethodGet,
+			path:           "/api/admin/api-keys/abc",
+			setupMocks:     func(*mocks.MockAPIKeyRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "admin_rotates_revoked_key",
+			role:   model.RoleAdmin,
+			method: http.MethodPost,
+			path:   "/api/admin/api-keys/4/rotate",
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				revokedAt := testNow
+				apiKeyRepo.On("GetByID", uint(4)).Return(&model.APIKey{ID: 4, RevokedAt: &revokedAt}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "admin_revokes_key",
+			role:   model.RoleAdmin,
+			method: http.MethodDelete,
+			path:   "/api/admin/api-keys/4",
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByID", uint(4)).Return(&model.APIKey{ID: 4}, nil)
+				apiKeyRepo.On("Revoke", uint(4), testNow).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			mockUserRepo.On("GetSession", uint(3)).Return(&model.Session{ID: 3, UserID: 7}, nil)
+			mockUserRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7, Role: tt.role}, nil)
+			mockAPIKeyRepo := &mocks.MockAPIKeyRepository{}
+			tt.setupMocks(mockAPIKeyRepo)
+			token, err := signAccessToken(testSecret, 7, 3, testNow, DefaultAccessTokenTTL)
+			require.NoError(t, err)
+			router := newAPIKeyRouter(mockUserRepo, mockAPIKeyRepo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			mockAPIKeyRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/bhati00/workova/backend/internal/user/repository"
+	"gorm.io/gorm"
+)
+
+// APIKeyService defines business logic operations for partner API keys
+type APIKeyService interface {
+	// CreateKey issues a key, the response is the only place its secret is shown
+	CreateKey(request dtos.CreateAPIKeyRequest, createdByID *uint) (*dtos.APIKeyResponse, error)
+	ListKeys(page, pageSize int) (*dtos.PaginatedAPIKeysResponse, error)
+	// GetKey returns a key with its usage of the last 30 days
+	GetKey(id uint) (*dtos.APIKeyResponse, error)
+	// RotateKey replaces the secret of a key, the old one stops working right away
+	RotateKey(id uint) (*dtos.APIKeyResponse, error)
+	RevokeKey(id uint) error
+
+	// Authenticate resolves a key and counts the request against its daily quota. Over
+	// the quota, it returns the key and limit along with ErrQuotaExceeded.
+	Authenticate(key string) (*model.APIKey, *dtos.RateLimit, error)
+}
+
+var (
+	// ErrInvalidAPIKeyRequest is returned for unusable key names, scopes, quotas or expiries
+	ErrInvalidAPIKeyRequest = errors.New("invalid api key request")
+	// ErrInvalidAPIKey is returned for unknown, expired or revoked keys
+	ErrInvalidAPIKey = errors.New("invalid, expired or revoked api key")
+	// ErrQuotaExceeded is returned once a key made its daily quota of requests
+	ErrQuotaExceeded = errors.New("daily quota exceeded")
+	// ErrAPIKeyNotFound is returned when no key has the requested ID
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrAPIKeyRevoked is returned when rotating a revoked key
+	ErrAPIKeyRevoked = errors.New("api key is revoked")
+)
+
+const (
+	// DefaultDailyQuota is the quota of keys created without one
+	DefaultDailyQuota = 10_000
+	maxDailyQuota     = 10_000_000
+	maxKeyNameLength  = 100
+	// usageHistoryDays is how many days of usage GetKey returns
+	usageHistoryDays = 30
+
+	// Keys look like wk_<12 hex prefix>_<43 base64url secret>
+	apiKeyMarker       = "wk_"
+	apiKeyPrefixLength = 12
+	usageDayFormat     = "2006-01-02"
+)
+
+// apiKeyService implements APIKeyService interface
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	now        func() time.Time
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository) APIKeyService {
+	return &apiKeyService{apiKeyRepo: apiKeyRepo, now: time.Now}
+}
+
+func (s *apiKeyService) CreateKey(request dtos.CreateAPIKeyRequest, createdByID *uint) (*dtos.APIKeyResponse, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" || len(name) > maxKeyNameLength {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidAPIKeyRequest, maxKeyNameLength)
+	}
+	scopes, err := normalizeScopes(request.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	quota := request.DailyQuota
+	if quota == 0 {
+		quota = DefaultDailyQuota
+	}
+	if quota < 0 || quota > maxDailyQuota {
+		return nil, fmt.Errorf("%w: daily_quota must be 1 to %d", ErrInvalidAPIKeyRequest, maxDailyQuota)
+	}
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(s.now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidAPIKeyRequest)
+	}
+
+	secret, prefix, err := newAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key, err := s.apiKeyRepo.Create(&model.APIKey{
+		Name:        name,
+		Prefix:      prefix,
+		KeyHash:     hashToken(secret),
+		Scopes:      joinScopes(scopes),
+		DailyQuota:  quota,
+		ExpiresAt:   request.ExpiresAt,
+		CreatedByID: createdByID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	response := toAPIKeyResponse(key, 0)
+	response.Key = secret
+	return response, nil
+}
+
+func (s *apiKeyService) ListKeys(page, pageSize int) (*dtos.PaginatedAPIKeysResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	keys, totalCount, err := s.apiKeyRepo.List((page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	ids := make([]uint, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID
+	}
+	usage, err := s.apiKeyRepo.CountUsage(ids, s.now().UTC().Format(usageDayFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count api key usage: %w", err)
+	}
+
+	responses := make([]dtos.APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = *toAPIKeyResponse(&keys[i], usage[keys[i].ID])
+	}
+	return &dtos.PaginatedAPIKeysResponse{
+		APIKeys:     responses,
+		TotalCount:  totalCount,
+		CurrentPage: page,
+		PageSize:    pageSize,
+		TotalPages:  int((totalCount + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+func (s *apiKeyService) GetKey(id uint) (*dtos.APIKeyResponse, error) {
+	key, err := s.getKey(id)
+	if err != nil {
+		return nil, err
+	}
+	today := s.now().UTC()
+	usage, err := s.apiKeyRepo.GetUsage(id, today.AddDate(0, 0, 1-usageHistoryDays).Format(usageDayFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key usage: %w", err)
+	}
+
+	var usageToday int64
+	if len(usage) > 0 && usage[len(usage)-1].Day == today.Format(usageDayFormat) {
+		usageToday = usage[len(usage)-1].Count
+	}
+	response := toAPIKeyResponse(key, usageToday)
+	response.Usage = usage
+	return response, nil
+}
+
+func (s *apiKeyService) RotateKey(id uint) (*dtos.APIKeyResponse, error) {
+	key, err := s.getKey(id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrAPIKeyRevoked
+	}
+
+	secret, prefix, err := newAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	now := s.now()
+	err = s.apiKeyRepo.Rotate(id, prefix, hashToken(secret), now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAPIKeyRevoked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	key.Prefix, key.UpdatedAt = prefix, now
+	usage, err := s.apiKeyRepo.CountUsage([]uint{id}, now.UTC().Format(usageDayFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count api key usage: %w", err)
+	}
+	response := toAPIKeyResponse(key, usage[id])
+	response.Key = secret
+	return response, nil
+}
+
+func (s *apiKeyService) RevokeKey(id uint) error {
+	if _, err := s.getKey(id); err != nil {
+		return err
+	}
+	if err := s.apiKeyRepo.Revoke(id, s.now()); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
+
+func (s *apiKeyService) Authenticate(secret string) (*model.APIKey, *dtos.RateLimit, error) {
+	prefix, ok := parseAPIKey(secret)
+	if !ok {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	key, err := s.apiKeyRepo.GetByPrefix(prefix)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	now := s.now()
+	if subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(key.KeyHash)) != 1 || !key.Active(now) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	day := now.UTC()
+	count, err := s.apiKeyRepo.IncrementUsage(key.ID, day.Format(usageDayFormat), now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count api key usage: %w", err)
+	}
+	limit := &dtos.RateLimit{
+		Limit:     key.DailyQuota,
+		Remaining: max(key.DailyQuota-int(count), 0),
+		Reset:     time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, time.UTC),
+	}
+	if count > int64(key.DailyQuota) {
+		return key, limit, ErrQuotaExceeded
+	}
+	return key, limit, nil
+}
+
+func (s *apiKeyService) getKey(id uint) (*model.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return key, nil
+}
+
+// newAPIKey returns a random key and its prefix
+func newAPIKey() (string, string, error) {
+	b := make([]byte, apiKeyPrefixLength/2+32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix := hex.EncodeToString(b[:apiKeyPrefixLength/2])
+	return apiKeyMarker + prefix + "_" + base64.RawURLEncoding.EncodeToString(b[apiKeyPrefixLength/2:]), prefix, nil
+}
+
+// parseAPIKey returns the prefix of a well-formed key
+func parseAPIKey(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, apiKeyMarker)
+	if !ok || len(rest) < apiKeyPrefixLength+2 || rest[apiKeyPrefixLength] != '_' {
+		return "", false
+	}
+	return rest[:apiKeyPrefixLength], true
+}
+
+// normalizeScopes validates scopes and orders them like model.Scopes, without duplicates
+func normalizeScopes(requested []model.Scope) ([]model.Scope, error) {
+	if len(requested) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidAPIKeyRequest)
+	}
+	for _, scope := range requested {
+		if !scope.Valid() {
+			return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidAPIKeyRequest, scope)
+		}
+	}
+	var scopes []model.Scope
+	for _, scope := range model.Scopes {
+		for _, r := range requested {
+			if r == scope {
+				scopes = append(scopes, scope)
+				break
+			}
+		}
+	}
+	return scopes, nil
+}
+
+func joinScopes(scopes []model.Scope) string {
+	values := make([]string, len(scopes))
+	for i, scope := range scopes {
+		values[i] = string(scope)
+	}
+	return strings.Join(values, ",")
+}
+
+func toAPIKeyResponse(key *model.APIKey, usageToday int64) *dtos.APIKeyResponse {
+	return &dtos.APIKeyResponse{
+		ID:          key.ID,
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		Scopes:      key.ScopeList(),
+		DailyQuota:  key.DailyQuota,
+		UsageToday:  usageToday,
+		ExpiresAt:   key.ExpiresAt,
+		RevokedAt:   key.RevokedAt,
+		LastUsedAt:  key.LastUsedAt,
+		CreatedByID: key.CreatedByID,
+		CreatedAt:   key.CreatedAt,
+	}
+}
@@ -0,0 +1,311 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user/mocks"
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// testAPIKey is a well-formed key with the prefix "0123456789ab"
+const testAPIKey = "wk_0123456789ab_c2VjcmV0IHNlY3JldCBzZWNyZXQgc2VjcmV0IHNlY3JldA"
+
+// newTestAPIKeyService returns an API key service with a fixed clock
+func newTestAPIKeyService(apiKeyRepo *mocks.MockAPIKeyRepository) *apiKeyService {
+	s := NewAPIKeyService(apiKeyRepo).(*apiKeyService)
+	s.now = func() time.Time { return testNow }
+	return s
+}
+
+func TestAPIKeyService_CreateKey(t *testing.T) {
+	past := testNow.Add(-time.Hour)
+	tests := []struct {
+		name          string
+		request       dtos.CreateAPIKeyRequest
+		setupMocks    func(*mocks.MockAPIKeyRepository)
+		expectedError error
+	}{
+		{
+			name:    "creates_key_with_default_quota",
+			request: dtos.CreateAPIKeyRequest{Name: " Partner ", Scopes: []model.Scope{model.ScopeExport, model.ScopeJobsRead, model.ScopeExport}},
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("Create", mock.MatchedBy(func(key *model.APIKey) bool {
+					return key.Name == "Partner" && key.Scopes == "jobs:read,export" && key.DailyQuota == DefaultDailyQuota &&
+						len(key.Prefix) == apiKeyPrefixLength && len(key.KeyHash) == 64 && *key.CreatedByID == 7
+				})).Run(func(args mock.Arguments) {
+					args.Get(0).(*model.APIKey).ID = 4
+				}).Return(&model.APIKey{ID: 4, Name: "Partner", Scopes: "jobs:read,export", DailyQuota: DefaultDailyQuota}, nil)
+			},
+		},
+		{
+			name:          "missing_name",
+			request:       dtos.CreateAPIKeyRequest{Name: " ", Scopes: []model.Scope{model.ScopeJobsRead}},
+			setupMocks:    func(*mocks.MockAPIKeyRepository) {},
+			expectedError: ErrInvalidAPIKeyRequest,
+		},
+		{
+			name:          "missing_scopes",
+			request:       dtos.CreateAPIKeyRequest{Name: "Partner"},
+			setupMocks:    func(*mocks.MockAPIKeyRepository) {},
+			expectedError: ErrInvalidAPIKeyRequest,
+		},
+		{
+			name:          "unknown_scope",
+			request:       dtos.CreateAPIKeyRequest{Name: "Partner", Scopes: []model.Scope{"jobs:delete"}},
+			setupMocks:    func(*mocks.MockAPIKeyRepository) {},
+			expectedError: ErrInvalidAPIKeyRequest,
+		},
+		{
+			name:          "negative_quota",
+			request:       dtos.CreateAPIKeyRequest{Name: "Partner", Scopes: []model.Scope{model.ScopeJobsRead}, DailyQuota: -1},
+			setupMocks:    func(*mocks.MockAPIKeyRepository) {},
+			expectedError: ErrInvalidAPIKeyRequest,
+		},
+		{
+			name:          "expiry_in_the_past",
+			request:       dtos.CreateAPIKeyRequest{Name: "Partner", Scopes: []model.Scope{model.ScopeJobsRead}, ExpiresAt: &past},
+			setupMocks:    func(*mocks.MockAPIKeyRepository) {},
+			expectedError: ErrInvalidAPIKeyRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyRepo := &mocks.MockAPIKeyRepository{}
+			tt.setupMocks(mockAPIKeyRepo)
+			createdByID := uint(7)
+
+			key, err := newTestAPIKeyService(mockAPIKeyRepo).CreateKey(tt.request, &createdByID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, key)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, uint(4), key.ID)
+				assert.Equal(t, []model.Scope{model.ScopeJobsRead, model.ScopeExport}, key.Scopes)
+				prefix, ok := parseAPIKey(key.Key)
+				assert.True(t, ok, key.Key)
+				assert.Len(t, prefix, apiKeyPrefixLength)
+			}
+			mockAPIKeyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	active := func() *model.APIKey {
+		return &model.APIKey{ID: 4, Prefix: "0123456789ab", KeyHash: hashToken(testAPIKey), Scopes: "jobs:read", DailyQuota: 100}
+	}
+	reset := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		key           string
+		setupMocks    func(*mocks.MockAPIKeyRepository)
+		expectedLimit *dtos.RateLimit
+		expectedError error
+	}{
+		{
+			name: "counts_request",
+			key:  testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(active(), nil)
+				apiKeyRepo.On("IncrementUsage", uint(4), "2025-03-01", testNow).Return(int64(40), nil)
+			},
+			expectedLimit: &dtos.RateLimit{Limit: 100, Remaining: 60, Reset: reset},
+		},
+		{
+			name: "last_request_of_the_quota",
+			key:  testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(active(), nil)
+				apiKeyRepo.On("IncrementUsage", uint(4), "2025-03-01", testNow).Return(int64(100), nil)
+			},
+			expectedLimit: &dtos.RateLimit{Limit: 100, Remaining: 0, Reset: reset},
+		},
+		{
+			name: "over_quota",
+			key:  testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(active(), nil)
+				apiKeyRepo.On("IncrementUsage", uint(4), "2025-03-01", testNow).Return(int64(101), nil)
+			},
+			expectedLimit: &dtos.RateLimit{Limit: 100, Remaining: 0, Reset: reset},
+			expectedError: ErrQuotaExceeded,
+		},
+		{
+			name:          "malformed_key",
+			key:           "0123456789ab",
+			setupMocks:    func(*mocks.MockAPIKeyRepository) {},
+			expectedError: ErrInvalidAPIKey,
+		},
+		{
+			name: "unknown_prefix",
+			key:  testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrInvalidAPIKey,
+		},
+		{
+			name: "wrong_secret",
+			key:  strings.TrimSuffix(testAPIKey, "A") + "B",
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(active(), nil)
+			},
+			expectedError: ErrInvalidAPIKey,
+		},
+		{
+			name: "revoked_key",
+			key:  testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				key := active()
+				revokedAt := testNow.Add(-time.Minute)
+				key.RevokedAt = &revokedAt
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(key, nil)
+			},
+			expectedError: ErrInvalidAPIKey,
+		},
+		{
+			name: "expired_key",
+			key:  testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				key := active()
+				expiresAt := testNow
+				key.ExpiresAt = &expiresAt
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(key, nil)
+			},
+			expectedError: ErrInvalidAPIKey,
+		},
+		{
+			name: "database_error",
+			key:  testAPIKey,
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByPrefix", "0123456789ab").Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("failed to get api key: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyRepo := &mocks.MockAPIKeyRepository{}
+			tt.setupMocks(mockAPIKeyRepo)
+
+			key, limit, err := newTestAPIKeyService(mockAPIKeyRepo).Authenticate(tt.key)
+
+			switch {
+			case tt.expectedError == nil:
+				require.NoError(t, err)
+				assert.Equal(t, uint(4), key.ID)
+			case errors.Is(tt.expectedError, ErrQuotaExceeded) || errors.Is(tt.expectedError, ErrInvalidAPIKey):
+				assert.ErrorIs(t, err, tt.expectedError)
+			default:
+				assert.EqualError(t, err, tt.expectedError.Error())
+			}
+			assert.Equal(t, tt.expectedLimit, limit)
+			mockAPIKeyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeyService_RotateKey(t *testing.T) {
+	revokedAt := testNow.Add(-time.Hour)
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.MockAPIKeyRepository)
+		expectedError error
+	}{
+		{
+			name: "replaces_secret",
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByID", uint(4)).Return(&model.APIKey{ID: 4, Prefix: "0123456789ab", Scopes: "jobs:read"}, nil)
+				apiKeyRepo.On("Rotate", uint(4), mock.MatchedBy(func(prefix string) bool {
+					return len(prefix) == apiKeyPrefixLength && prefix != "0123456789ab"
+				}), mock.AnythingOfType("string"), testNow).Return(nil)
+				apiKeyRepo.On("CountUsage", []uint{4}, "2025-03-01").Return(map[uint]int64{4: 12}, nil)
+			},
+		},
+		{
+			name: "unknown_key",
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByID", uint(4)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrAPIKeyNotFound,
+		},
+		{
+			name: "revoked_key",
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByID", uint(4)).Return(&model.APIKey{ID: 4, RevokedAt: &revokedAt}, nil)
+			},
+			expectedError: ErrAPIKeyRevoked,
+		},
+		{
+			name: "revoked_meanwhile",
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository) {
+				apiKeyRepo.On("GetByID", uint(4)).Return(&model.APIKey{ID: 4}, nil)
+				apiKeyRepo.On("Rotate", uint(4), mock.Anything, mock.Anything, testNow).Return(gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrAPIKeyRevoked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyRepo := &mocks.MockAPIKeyRepository{}
+			tt.setupMocks(mockAPIKeyRepo)
+
+			key, err := newTestAPIKeyService(mockAPIKeyRepo).RotateKey(4)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, key)
+			} else {
+				require.NoError(t, err)
+				prefix, ok := parseAPIKey(key.Key)
+				assert.True(t, ok)
+				assert.Equal(t, prefix, key.Prefix)
+				assert.Equal(t, int64(12), key.UsageToday)
+				mockAPIKeyRepo.AssertCalled(t, "Rotate", uint(4), prefix, hashToken(key.Key), testNow)
+			}
+			mockAPIKeyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeyService_GetKey(t *testing.T) {
+	mockAPIKeyRepo := &mocks.MockAPIKeyRepository{}
+	mockAPIKeyRepo.On("GetByID", uint(4)).Return(&model.APIKey{ID: 4, Scopes: "jobs:read,export"}, nil)
+	mockAPIKeyRepo.On("GetUsage", uint(4), "2025-01-31").Return([]model.APIKeyUsage{
+		{APIKeyID: 4, Day: "2025-02-27", Count: 3},
+		{APIKeyID: 4, Day: "2025-03-01", Count: 9},
+	}, nil)
+
+	key, err := newTestAPIKeyService(mockAPIKeyRepo).GetKey(4)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), key.UsageToday)
+	assert.Len(t, key.Usage, 2)
+	assert.Empty(t, key.Key)
+	mockAPIKeyRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_RevokeKey(t *testing.T) {
+	mockAPIKeyRepo := &mocks.MockAPIKeyRepository{}
+	mockAPIKeyRepo.On("GetByID", uint(4)).Return(&model.APIKey{ID: 4}, nil)
+	mockAPIKeyRepo.On("Revoke", uint(4), testNow).Return(nil)
+	mockAPIKeyRepo.On("GetByID", uint(5)).Return(nil, gorm.ErrRecordNotFound)
+	service := newTestAPIKeyService(mockAPIKeyRepo)
+
+	assert.NoError(t, service.RevokeKey(4))
+	assert.ErrorIs(t, service.RevokeKey(5), ErrAPIKeyNotFound)
+	mockAPIKeyRepo.AssertExpectations(t)
+}
@@ -0,0 +1,147 @@
+package user
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/gin-gonic/gin"
+)
+
+// Context keys of the authenticated user, session and API key
+const (
+	currentUserKey    = "user.current"
+	currentSessionKey = "user.session"
+	currentAPIKeyKey  = "user.api_key"
+)
+
+// APIKeyHeader is the request header partners send their API key in
+const APIKeyHeader = "X-API-Key"
+
+// Authenticate populates the current user of requests with an "Authorization: Bearer"
+// access token, see CurrentUser. Requests without one go through anonymously, requests
+// with an invalid one are rejected with 401.
+func Authenticate(userService UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			abortUnauthorized(c, "Authorization header must be \"Bearer <access token>\"")
+			return
+		}
+
+		user, session, err := userService.Authenticate(strings.TrimSpace(token))
+		if errors.Is(err, ErrInvalidToken) {
+			abortUnauthorized(c, "Invalid or expired access token")
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to authenticate request: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, dtos.APIResponse{
+				Success: false,
+				Error:   "Failed to authenticate request",
+			})
+			return
+		}
+		c.Set(currentUserKey, user)
+		c.Set(currentSessionKey, session)
+		c.Next()
+	}
+}
+
+// AuthenticateAPIKey identifies requests with an API key in the X-API-Key header, see
+// CurrentAPIKey. Each counts against the daily quota of its key, which responses report in
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (Unix seconds). Requests
+// over the quota are rejected with 429, requests with an invalid key with 401.
+func AuthenticateAPIKey(apiKeyService APIKeyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := c.GetHeader(APIKeyHeader)
+		if secret == "" {
+			c.Next()
+			return
+		}
+
+		key, limit, err := apiKeyService.Authenticate(secret)
+		if limit != nil {
+			c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Limit))
+			c.Header("X-RateLimit-Remaining", strconv.Itoa(limit.Remaining))
+			c.Header("X-RateLimit-Reset", strconv.FormatInt(limit.Reset.Unix(), 10))
+		}
+		switch {
+		case errors.Is(err, ErrInvalidAPIKey):
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dtos.APIResponse{
+				Success: false,
+				Error:   "Invalid, expired or revoked API key",
+			})
+			return
+		case errors.Is(err, ErrQuotaExceeded):
+			retryAfter := max(int(math.Ceil(time.Until(limit.Reset).Seconds())), 1)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, dtos.APIResponse{
+				Success: false,
+				Error:   "Daily quota of the API key exceeded",
+			})
+			return
+		case err != nil:
+			log.Printf("Failed to authenticate API key: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, dtos.APIResponse{
+				Success: false,
+				Error:   "Failed to authenticate request",
+			})
+			return
+		}
+		c.Set(currentAPIKeyKey, key)
+		c.Next()
+	}
+}
+
+// RequireUser rejects requests without a current user with 401
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if CurrentUser(c) == nil {
+			abortUnauthorized(c, "Authentication required")
+			return
+		}
+		c.Next()
+	}
+}
+
+// CurrentUser returns the user that made the request, nil for anonymous requests
+func CurrentUser(c *gin.Context) *model.User {
+	user, _ := c.Get(currentUserKey)
+	u, _ := user.(*model.User)
+	return u
+}
+
+// CurrentSession returns the session the request was authenticated with, nil for
+// anonymous requests
+func CurrentSession(c *gin.Context) *model.Session {
+	session, _ := c.Get(currentSessionKey)
+	s, _ := session.(*model.Session)
+	return s
+}
+
+// CurrentAPIKey returns the API key the request was made with, nil without one
+func CurrentAPIKey(c *gin.Context) *model.APIKey {
+	key, _ := c.Get(currentAPIKeyKey)
+	k, _ := key.(*model.APIKey)
+	return k
+}
+
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="workova"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, dtos.APIResponse{
+		Success: false,
+		Error:   message,
+	})
+}
@@ -0,0 +1,33 @@
+CREATE TABLE api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(12) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes VARCHAR(255) NOT NULL,
+    daily_quota INTEGER NOT NULL,
+    expires_at DATETIME,
+    revoked_at DATETIME,
+    last_used_at DATETIME,
+    created_by_id INTEGER,
+
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_created_by
+        FOREIGN KEY (created_by_id) REFERENCES users(id)
+        ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_api_keys_prefix ON api_keys(prefix);
+CREATE INDEX idx_api_keys_created_by_id ON api_keys(created_by_id);
+
+CREATE TABLE api_key_usages (
+    api_key_id INTEGER NOT NULL,
+    day VARCHAR(10) NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (api_key_id, day),
+    CONSTRAINT fk_api_key
+        FOREIGN KEY (api_key_id) REFERENCES api_keys(id)
+        ON DELETE CASCADE
+);
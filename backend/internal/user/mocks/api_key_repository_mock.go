@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(key *model.APIKey) (*model.APIKey, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByID(id uint) (*model.APIKey, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByPrefix(prefix string) (*model.APIKey, error) {
+	args := m.Called(prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(offset, limit int) ([]model.APIKey, int64, error) {
+	args := m.Called(offset, limit)
+	return args.Get(0).([]model.APIKey), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAPIKeyRepository) Rotate(id uint, prefix, keyHash string, now time.Time) error {
+	args := m.Called(id, prefix, keyHash, now)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Revoke(id uint, now time.Time) error {
+	args := m.Called(id, now)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) IncrementUsage(id uint, day string, now time.Time) (int64, error) {
+	args := m.Called(id, day, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetUsage(id uint, since string) ([]model.APIKeyUsage, error) {
+	args := m.Called(id, since)
+	return args.Get(0).([]model.APIKeyUsage), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) CountUsage(ids []uint, day string) (map[uint]int64, error) {
+	args := m.Called(ids, day)
+	return args.Get(0).(map[uint]int64), args.Error(1)
+}
@@ -0,0 +1,108 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(user *model.User) (*model.User, error) {
+	args := m.Called(user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(user *model.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetByID(id uint) (*model.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmail(email string) (*model.User, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) List(query string, role model.Role, offset, limit int) ([]model.User, int64, error) {
+	args := m.Called(query, role, offset, limit)
+	return args.Get(0).([]model.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) CountByRole(role model.Role) (int64, error) {
+	args := m.Called(role)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateRole(id uint, role model.Role) error {
+	args := m.Called(id, role)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateSession(session *model.Session) (*model.Session, error) {
+	args := m.Called(session)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Session), args.Error(1)
+}
+
+func (m *MockUserRepository) GetSession(id uint) (*model.Session, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Session), args.Error(1)
+}
+
+func (m *MockUserRepository) GetSessionByRefreshTokenHash(hash string) (*model.Session, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Session), args.Error(1)
+}
+
+func (m *MockUserRepository) RotateRefreshToken(sessionID uint, oldHash, newHash string, expiresAt, now time.Time) error {
+	args := m.Called(sessionID, oldHash, newHash, expiresAt, now)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RevokeSession(sessionID uint, now time.Time) error {
+	args := m.Called(sessionID, now)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreatePasswordResetToken(token *model.PasswordResetToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetPasswordResetToken(hash string) (*model.PasswordResetToken, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockUserRepository) ResetPassword(token *model.PasswordResetToken, passwordHash string, now time.Time) error {
+	args := m.Called(token, passwordHash, now)
+	return args.Error(0)
+}
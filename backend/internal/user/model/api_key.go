@@ -0,0 +1,92 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Scope is something an API key may be used for
+type Scope string
+
+const (
+	// ScopeJobsRead allows reading jobs, companies and suggestions
+	ScopeJobsRead Scope = "jobs:read"
+	// ScopeJobsWrite allows posting jobs
+	ScopeJobsWrite Scope = "jobs:write"
+	// ScopeExport allows bulk exports of jobs
+	ScopeExport Scope = "export"
+)
+
+// Scopes lists every scope
+var Scopes = []Scope{ScopeJobsRead, ScopeJobsWrite, ScopeExport}
+
+// Valid reports whether s is one of Scopes
+func (s Scope) Valid() bool {
+	for _, scope := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey lets a partner call the API without a user account. The key itself is only
+// stored as a SHA-256 hash, its prefix identifies it in listings and logs.
+type APIKey struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string     `gorm:"size:100;not null" json:"name"`
+	Prefix      string     `gorm:"size:12;not null;uniqueIndex:idx_api_keys_prefix" json:"prefix"`
+	KeyHash     string     `gorm:"size:64;not null" json:"-"`
+	Scopes      string     `gorm:"size:255;not null" json:"-"`  // Comma-separated, see ScopeList
+	DailyQuota  int        `gorm:"not null" json:"daily_quota"` // Requests per UTC day
+	ExpiresAt   *time.Time `json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	CreatedByID *uint      `gorm:"index:idx_api_keys_created_by_id" json:"created_by_id"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the APIKey model
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList returns the scopes of the key
+func (k *APIKey) ScopeList() []Scope {
+	var scopes []Scope
+	for _, scope := range strings.Split(k.Scopes, ",") {
+		if scope != "" {
+			scopes = append(scopes, Scope(scope))
+		}
+	}
+	return scopes
+}
+
+// HasScope reports whether the key may be used for scope
+func (k *APIKey) HasScope(scope Scope) bool {
+	for _, s := range k.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Active reports whether the key can still authenticate at now
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// APIKeyUsage counts the requests made with a key on a UTC day
+type APIKeyUsage struct {
+	APIKeyID uint   `gorm:"primaryKey" json:"-"`
+	Day      string `gorm:"primaryKey;size:10" json:"day"` // YYYY-MM-DD
+	Count    int64  `gorm:"not null" json:"count"`
+}
+
+// TableName specifies the table name for the APIKeyUsage model
+func (APIKeyUsage) TableName() string {
+	return "api_key_usages"
+}
@@ -0,0 +1,28 @@
+package model
+
+// Role decides what a user may do, see the permission matrix of the user package
+type Role string
+
+const (
+	// RoleReader browses and searches jobs, every signup starts as one
+	RoleReader Role = "reader"
+	// RoleEmployer also posts jobs
+	RoleEmployer Role = "employer"
+	// RoleModerator also takes down jobs and curates companies
+	RoleModerator Role = "moderator"
+	// RoleAdmin may do everything, including managing users and search settings
+	RoleAdmin Role = "admin"
+)
+
+// Roles lists every role, from least to most privileged
+var Roles = []Role{RoleReader, RoleEmployer, RoleModerator, RoleAdmin}
+
+// Valid reports whether r is one of Roles
+func (r Role) Valid() bool {
+	for _, role := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// User is a registered account. Email is stored lower-cased and is the login name.
+type User struct {
+	ID           uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Email        string     `gorm:"size:255;not null;uniqueIndex:idx_users_email" json:"email"`
+	Name         string     `gorm:"size:255" json:"name"`
+	PasswordHash string     `gorm:"size:255;not null" json:"-"`
+	Role         Role       `gorm:"size:20;not null;default:reader;index:idx_users_role" json:"role"`
+	LastLoginAt  *time.Time `json:"last_login_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the User model
+func (User) TableName() string {
+	return "users"
+}
+
+// Session is a login of a user. Its refresh token is only stored as a SHA-256 hash and
+// changes on every refresh, access tokens carry the session ID so logout revokes them too.
+type Session struct {
+	ID               uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID           uint       `gorm:"not null;index:idx_user_sessions_user_id" json:"user_id"`
+	RefreshTokenHash string     `gorm:"size:64;not null;uniqueIndex:idx_user_sessions_refresh_token_hash" json:"-"`
+	UserAgent        *string    `gorm:"size:255" json:"user_agent"`
+	IPAddress        *string    `gorm:"size:45" json:"ip_address"`
+	ExpiresAt        time.Time  `gorm:"not null" json:"expires_at"` // When the refresh token stops working
+	RevokedAt        *time.Time `json:"revoked_at"`                 // Set on logout and password reset
+	LastUsedAt       *time.Time `json:"last_used_at"`               // Last refresh
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the Session model
+func (Session) TableName() string {
+	return "user_sessions"
+}
+
+// Active reports whether the session can still authenticate at now
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// PasswordResetToken lets a user who forgot their password set a new one, once
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uint       `gorm:"not null;index:idx_password_reset_tokens_user_id" json:"user_id"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex:idx_password_reset_tokens_token_hash" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the PasswordResetToken model
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
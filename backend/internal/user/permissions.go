@@ -0,0 +1,107 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/gin-gonic/gin"
+)
+
+// Permission is an action that only some roles may take. Reading jobs, companies and
+// suggestions needs none.
+type Permission string
+
+const (
+	// PermissionCreateJobs allows posting jobs
+	PermissionCreateJobs Permission = "jobs:create"
+	// PermissionModerateJobs allows deleting and deactivating single jobs
+	PermissionModerateJobs Permission = "jobs:moderate"
+	// PermissionBatchDeleteJobs allows deleting many jobs at once
+	PermissionBatchDeleteJobs Permission = "jobs:batch_delete"
+	// PermissionExportJobs allows bulk exports of jobs
+	PermissionExportJobs Permission = "jobs:export"
+	// PermissionCurateCompanies allows merging companies and editing their aliases
+	PermissionCurateCompanies Permission = "companies:curate"
+	// PermissionManageSearch allows editing the search synonyms
+	PermissionManageSearch Permission = "search:manage"
+	// PermissionManageUsers allows listing users and changing their roles
+	PermissionManageUsers Permission = "users:manage"
+	// PermissionManageAPIKeys allows issuing, rotating and revoking API keys
+	PermissionManageAPIKeys Permission = "api_keys:manage"
+)
+
+// rolePermissions is the permission matrix. Admins are granted everything in HasPermission.
+var rolePermissions = map[model.Role][]Permission{
+	model.RoleReader:    {},
+	model.RoleEmployer:  {PermissionCreateJobs},
+	model.RoleModerator: {PermissionCreateJobs, PermissionModerateJobs, PermissionCurateCompanies},
+}
+
+// scopePermissions are the permissions API keys get from their scopes. jobs:read grants
+// none, reads are open to everyone and only keys are held to the scope, see RequireScope.
+var scopePermissions = map[model.Scope][]Permission{
+	model.ScopeJobsWrite: {PermissionCreateJobs},
+	model.ScopeExport:    {PermissionExportJobs},
+}
+
+// HasPermission reports whether users with the role may take the action
+func HasPermission(role model.Role, permission Permission) bool {
+	if role == model.RoleAdmin {
+		return true
+	}
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyHasPermission reports whether one of the scopes of the API key grants the permission
+func KeyHasPermission(key *model.APIKey, permission Permission) bool {
+	for _, scope := range key.ScopeList() {
+		for _, p := range scopePermissions[scope] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RequirePermission rejects anonymous requests with 401, and requests whose user role and
+// API key scopes both lack the permission with 403. It runs after Authenticate and
+// AuthenticateAPIKey.
+func RequirePermission(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, key := CurrentUser(c), CurrentAPIKey(c)
+		if user == nil && key == nil {
+			abortUnauthorized(c, "Authentication required")
+			return
+		}
+		if (user == nil || !HasPermission(user.Role, permission)) && (key == nil || !KeyHasPermission(key, permission)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, dtos.APIResponse{
+				Success: false,
+				Error:   "Not allowed to " + string(permission),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireScope rejects requests made with an API key that lacks the scope with 403. Other
+// requests go through, it doesn't require a key.
+func RequireScope(scope model.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := CurrentAPIKey(c); key != nil && !key.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, dtos.APIResponse{
+				Success: false,
+				Error:   "The API key lacks the " + string(scope) + " scope",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type APIKeyRepository interface {
+	Create(key *model.APIKey) (*model.APIKey, error)
+	GetByID(id uint) (*model.APIKey, error)
+	GetByPrefix(prefix string) (*model.APIKey, error)
+	// List returns keys newest first
+	List(offset, limit int) ([]model.APIKey, int64, error)
+	// Rotate replaces the prefix and hash of a key that isn't revoked, returning
+	// gorm.ErrRecordNotFound when it is
+	Rotate(id uint, prefix, keyHash string, now time.Time) error
+	Revoke(id uint, now time.Time) error
+
+	// Usage
+	// IncrementUsage counts a request on day and returns the count of the day so far
+	IncrementUsage(id uint, day string, now time.Time) (int64, error)
+	// GetUsage returns the counts of the days from since on, oldest first
+	GetUsage(id uint, since string) ([]model.APIKeyUsage, error)
+	// CountUsage returns the count of day for each key that was used on it
+	CountUsage(ids []uint, day string) (map[uint]int64, error)
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) apiKeyRepository {
+	return apiKeyRepository{db: db}
+}
+
+func (r apiKeyRepository) Create(key *model.APIKey) (*model.APIKey, error) {
+	if err := r.db.Create(key).Error; err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (r apiKeyRepository) GetByID(id uint) (*model.APIKey, error) {
+	var key model.APIKey
+	if err := r.db.First(&key, id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r apiKeyRepository) GetByPrefix(prefix string) (*model.APIKey, error) {
+	var key model.APIKey
+	if err := r.db.Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r apiKeyRepository) List(offset, limit int) ([]model.APIKey, int64, error) {
+	var keys []model.APIKey
+	var total int64
+
+	if err := r.db.Model(&model.APIKey{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := r.db.Order("id DESC").Offset(offset).Limit(limit).Find(&keys).Error; err != nil {
+		return nil, 0, err
+	}
+	return keys, total, nil
+}
+
+func (r apiKeyRepository) Rotate(id uint, prefix, keyHash string, now time.Time) error {
+	result := r.db.Model(&model.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"prefix": prefix, "key_hash": keyHash, "updated_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r apiKeyRepository) Revoke(id uint, now time.Time) error {
+	return r.db.Model(&model.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now}).Error
+}
+
+func (r apiKeyRepository) IncrementUsage(id uint, day string, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		usage := model.APIKeyUsage{APIKeyID: id, Day: day, Count: 1}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "api_key_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("api_key_usages.count + 1")}),
+		}).Create(&usage).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&model.APIKeyUsage{}).Where("api_key_id = ? AND day = ?", id, day).
+			Pluck("count", &count).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", now).Error
+	})
+	return count, err
+}
+
+func (r apiKeyRepository) GetUsage(id uint, since string) ([]model.APIKeyUsage, error) {
+	var usage []model.APIKeyUsage
+	err := r.db.Where("api_key_id = ? AND day >= ?", id, since).Order("day ASC").Find(&usage).Error
+	return usage, err
+}
+
+func (r apiKeyRepository) CountUsage(ids []uint, day string) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+	var usage []model.APIKeyUsage
+	if err := r.db.Where("api_key_id IN ? AND day = ?", ids, day).Find(&usage).Error; err != nil {
+		return nil, err
+	}
+	for _, u := range usage {
+		counts[u.APIKeyID] = u.Count
+	}
+	return counts, nil
+}
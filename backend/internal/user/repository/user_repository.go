@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"gorm.io/gorm"
+)
+
+type UserRepository interface {
+	Create(user *model.User) (*model.User, error)
+	Update(user *model.User) error
+	GetByID(id uint) (*model.User, error)
+	// GetByEmail matches the lower-cased email
+	GetByEmail(email string) (*model.User, error)
+	// List returns users whose email or name contains query, with any role when role is empty
+	List(query string, role model.Role, offset, limit int) ([]model.User, int64, error)
+	CountByRole(role model.Role) (int64, error)
+	UpdateRole(id uint, role model.Role) error
+
+	// Sessions
+	CreateSession(session *model.Session) (*model.Session, error)
+	GetSession(id uint) (*model.Session, error)
+	GetSessionByRefreshTokenHash(hash string) (*model.Session, error)
+	// RotateRefreshToken replaces the refresh token of a session that still has oldHash,
+	// returning gorm.ErrRecordNotFound when another refresh got there first
+	RotateRefreshToken(sessionID uint, oldHash, newHash string, expiresAt, now time.Time) error
+	RevokeSession(sessionID uint, now time.Time) error
+
+	// Password resets
+	CreatePasswordResetToken(token *model.PasswordResetToken) error
+	GetPasswordResetToken(hash string) (*model.PasswordResetToken, error)
+	// ResetPassword sets the password, uses up the token and revokes every session of the
+	// user, in one transaction. It returns gorm.ErrRecordNotFound when the token was used.
+	ResetPassword(token *model.PasswordResetToken, passwordHash string, now time.Time) error
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) userRepository {
+	return userRepository{db: db}
+}
+
+func (r userRepository) Create(user *model.User) (*model.User, error) {
+	if err := r.db.Create(user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r userRepository) Update(user *model.User) error {
+	return r.db.Save(user).Error
+}
+
+func (r userRepository) GetByID(id uint) (*model.User, error) {
+	var user model.User
+	if err := r.db.First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r userRepository) GetByEmail(email string) (*model.User, error) {
+	var user model.User
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r userRepository) List(query string, role model.Role, offset, limit int) ([]model.User, int64, error) {
+	var users []model.User
+	var total int64
+
+	db := r.db.Model(&model.User{})
+	if query = strings.TrimSpace(strings.ToLower(query)); query != "" {
+		db = db.Where("email LIKE ? OR LOWER(name) LIKE ?", "%"+query+"%", "%"+query+"%")
+	}
+	if role != "" {
+		db = db.Where("role = ?", role)
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := db.Order("id ASC").Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (r userRepository) CountByRole(role model.Role) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.User{}).Where("role = ?", role).Count(&count).Error
+	return count, err
+}
+
+func (r userRepository) UpdateRole(id uint, role model.Role) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"role": role, "updated_at": time.Now()}).Error
+}
+
+func (r userRepository) CreateSession(session *model.Session) (*model.Session, error) {
+	if err := r.db.Create(session).Error; err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r userRepository) GetSession(id uint) (*model.Session, error) {
+	var session model.Session
+	if err := r.db.First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r userRepository) GetSessionByRefreshTokenHash(hash string) (*model.Session, error) {
+	var session model.Session
+	if err := r.db.Where("refresh_token_hash = ?", hash).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r userRepository) RotateRefreshToken(sessionID uint, oldHash, newHash string, expiresAt, now time.Time) error {
+	result := r.db.Model(&model.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", sessionID, oldHash).
+		Updates(map[string]interface{}{"refresh_token_hash": newHash, "expires_at": expiresAt, "last_used_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r userRepository) RevokeSession(sessionID uint, now time.Time) error {
+	return r.db.Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", now).Error
+}
+
+func (r userRepository) CreatePasswordResetToken(token *model.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r userRepository) GetPasswordResetToken(hash string) (*model.PasswordResetToken, error) {
+	var token model.PasswordResetToken
+	if err := r.db.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r userRepository) ResetPassword(token *model.PasswordResetToken, passwordHash string, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Model(&model.User{}).Where("id = ?", token.UserID).
+			Updates(map[string]interface{}{"password_hash": passwordHash, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", token.UserID).
+			Update("revoked_at", now).Error
+	})
+}
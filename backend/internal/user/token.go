@@ -0,0 +1,74 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenIssuer is the iss claim of access tokens
+const tokenIssuer = "workova"
+
+// accessClaims are the claims of an access token. The subject is the user ID.
+type accessClaims struct {
+	SessionID uint `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// signAccessToken issues an HS256 access token for the user's session
+func signAccessToken(secret []byte, userID, sessionID uint, now time.Time, ttl time.Duration) (string, error) {
+	claims := accessClaims{
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// parseAccessToken verifies an access token and returns its user and session IDs
+func parseAccessToken(secret []byte, token string, now time.Time) (uint, uint, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil || claims.SessionID == 0 {
+		return 0, 0, fmt.Errorf("malformed claims")
+	}
+	return uint(userID), claims.SessionID, nil
+}
+
+// newOpaqueToken returns a random URL-safe token and the hash it is stored by
+func newOpaqueToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+// hashToken is the SHA-256 of a refresh or reset token. The tokens are random, so unlike
+// passwords they need no salt or slow hash.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,378 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler handles HTTP requests for accounts and sessions
+type UserHandler struct {
+	userService UserService
+}
+
+// NewUserHandler creates a new user handler instance
+func NewUserHandler(userService UserService) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+// Signup godoc
+// @Summary Create an account
+// @Description Registers a user and logs them in. Passwords are 8 to 72 bytes.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param account body dtos.SignupRequest true "Account"
+// @Success 201 {object} dtos.APIResponse{data=dtos.AuthResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 409 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /auth/signup [post]
+func (h *UserHandler) Signup(c *gin.Context) {
+	var request dtos.SignupRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	auth, err := h.userService.Signup(request, requestClient(c))
+	if err != nil {
+		c.JSON(userErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to sign up: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dtos.APIResponse{
+		Success: true,
+		Message: "Account created successfully",
+		Data:    auth,
+	})
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Starts a session and returns an access token for the Authorization header and a refresh token
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body dtos.LoginRequest true "Credentials"
+// @Success 200 {object} dtos.APIResponse{data=dtos.AuthResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /auth/login [post]
+func (h *UserHandler) Login(c *gin.Context) {
+	var request dtos.LoginRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	auth, err := h.userService.Login(request, requestClient(c))
+	if err != nil {
+		c.JSON(userErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to log in: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    auth,
+	})
+}
+
+// Refresh godoc
+// @Summary Refresh the access token
+// @Description Trades a refresh token for a new access and refresh token. The old refresh token stops working.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token body dtos.RefreshRequest true "Refresh token"
+// @Success 200 {object} dtos.APIResponse{data=dtos.AuthResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /auth/refresh [post]
+func (h *UserHandler) Refresh(c *gin.Context) {
+	var request dtos.RefreshRequest
+	if err := c.ShouldBindJSON(&request); err != nil || request.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: refresh_token is required",
+		})
+		return
+	}
+
+	auth, err := h.userService.Refresh(request.RefreshToken)
+	if err != nil {
+		c.JSON(userErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to refresh: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    auth,
+	})
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Ends the session of the access token. Its access and refresh tokens stop working.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /auth/logout [post]
+func (h *UserHandler) Logout(c *gin.Context) {
+	if err := h.userService.Logout(CurrentSession(c).ID); err != nil {
+		c.JSON(http.StatusInternalServerError, dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to log out: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "Logged out successfully",
+	})
+}
+
+// GetCurrentUser godoc
+// @Summary Get the current user
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Router /auth/me [get]
+func (h *UserHandler) GetCurrentUser(c *gin.Context) {
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    CurrentUser(c),
+	})
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Sends a password reset token to the account with the email. Succeeds for unknown emails too.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dtos.ForgotPasswordRequest true "Email"
+// @Success 202 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Failure 503 {object} dtos.APIResponse
+// @Router /auth/password/forgot [post]
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var request dtos.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil || request.Email == "" {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: email is required",
+		})
+		return
+	}
+
+	if err := h.userService.RequestPasswordReset(request.Email); err != nil {
+		c.JSON(userErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to request password reset: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dtos.APIResponse{
+		Success: true,
+		Message: "If the email has an account, a reset token is on its way",
+	})
+}
+
+// ResetPassword godoc
+// @Summary Reset a password
+// @Description Sets a new password with a password reset token and ends every session of the user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dtos.ResetPasswordRequest true "Token and new password"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /auth/password/reset [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var request dtos.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil || request.Token == "" {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: token is required",
+		})
+		return
+	}
+
+	if err := h.userService.ResetPassword(request); err != nil {
+		c.JSON(userErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to reset password: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "Password reset successfully",
+	})
+}
+
+// ListUsers godoc
+// @Summary List users
+// @Description Returns paginated users, oldest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param query query string false "Email or name contains"
+// @Param role query string false "Only users with the role" Enums(reader, employer, moderator, admin)
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} dtos.APIResponse{data=dtos.PaginatedUsersResponse}
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.userService.ListUsers(c.Query("query"), model.Role(c.Query("role")), page, pageSize)
+	if err != nil {
+		c.JSON(userErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to get users: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// UpdateUserRole godoc
+// @Summary Change the role of a user
+// @Description Takes effect on the user's next request. The last admin can't be demoted.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param role body dtos.UpdateUserRoleRequest true "New role"
+// @Success 200 {object} dtos.APIResponse
+// @Failure 400 {object} dtos.APIResponse
+// @Failure 401 {object} dtos.APIResponse
+// @Failure 403 {object} dtos.APIResponse
+// @Failure 404 {object} dtos.APIResponse
+// @Failure 409 {object} dtos.APIResponse
+// @Failure 500 {object} dtos.APIResponse
+// @Router /admin/users/{id}/role [put]
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid user ID",
+		})
+		return
+	}
+
+	var request dtos.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.APIResponse{
+			Success: false,
+			Error:   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.SetRole(uint(id), request.Role)
+	if err != nil {
+		c.JSON(userErrorStatus(err), dtos.APIResponse{
+			Success: false,
+			Error:   "Failed to update role: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.APIResponse{
+		Success: true,
+		Message: "Role updated successfully",
+		Data:    user,
+	})
+}
+
+// requestClient describes the client of a login for its session
+func requestClient(c *gin.Context) Client {
+	return Client{UserAgent: c.Request.UserAgent(), IPAddress: c.ClientIP()}
+}
+
+// userErrorStatus maps user service errors to HTTP status codes
+func userErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidUserRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrInvalidCredentials), errors.Is(err, ErrInvalidToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrEmailTaken), errors.Is(err, ErrLastAdmin), errors.Is(err, ErrAdminExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrPasswordResetUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// RegisterUserRoutes registers the account and session routes
+func (h *UserHandler) RegisterUserRoutes(router *gin.RouterGroup) {
+	auth := router.Group("/auth")
+	{
+		auth.POST("/signup", h.Signup)
+		auth.POST("/login", h.Login)
+		auth.POST("/refresh", h.Refresh)
+		auth.POST("/logout", RequireUser(), h.Logout)
+		auth.GET("/me", RequireUser(), h.GetCurrentUser)
+		auth.POST("/password/forgot", h.ForgotPassword)
+		auth.POST("/password/reset", h.ResetPassword)
+	}
+}
+
+// RegisterUserAdminRoutes registers the user management routes, for admins only
+func (h *UserHandler) RegisterUserAdminRoutes(router *gin.RouterGroup) {
+	users := router.Group("/admin/users", RequirePermission(PermissionManageUsers))
+	{
+		users.GET("", h.ListUsers)
+		users.PUT("/:id/role", h.UpdateUserRole)
+	}
+}
@@ -0,0 +1,365 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user/mocks"
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newAuthRouter serves the auth routes and GET /api/whoami, a public route reporting the
+// current user, behind the Authenticate middleware
+func newAuthRouter(userRepo *mocks.MockUserRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	service := newTestUserService(userRepo)
+	router := gin.New()
+	router.Use(Authenticate(service))
+	api := router.Group("/api")
+	NewUserHandler(service).RegisterUserRoutes(api)
+	api.GET("/whoami", func(c *gin.Context) {
+		if user := CurrentUser(c); user != nil {
+			c.String(http.StatusOK, user.Email)
+			return
+		}
+		c.String(http.StatusOK, "anonymous")
+	})
+	return router
+}
+
+func TestAuthenticateMiddleware(t *testing.T) {
+	validToken, err := signAccessToken(testSecret, 7, 3, testNow, DefaultAccessTokenTTL)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		path           string
+		authorization  string
+		setupMocks     func(*mocks.MockUserRepository)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "anonymous_public_route",
+			path:           "/api/whoami",
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "anonymous",
+		},
+		{
+			name:          "authenticated_public_route",
+			path:          "/api/whoami",
+			authorization: "Bearer " + validToken,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetSession", uint(3)).Return(&model.Session{ID: 3, UserID: 7}, nil)
+				userRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7, Email: "ada@example.com"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ada@example.com",
+		},
+		{
+			name:           "anonymous_protected_route",
+			path:           "/api/auth/me",
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid_token_on_public_route",
+			path:           "/api/whoami",
+			authorization:  "Bearer not.a.jwt",
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong_scheme",
+			path:           "/api/auth/me",
+			authorization:  "Basic " + validToken,
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			tt.setupMocks(mockUserRepo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			newAuthRouter(mockUserRepo).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="workova"`, w.Header().Get("WWW-Authenticate"))
+			} else {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_GetCurrentUser(t *testing.T) {
+	mockUserRepo := &mocks.MockUserRepository{}
+	mockUserRepo.On("GetSession", uint(3)).Return(&model.Session{ID: 3, UserID: 7}, nil)
+	mockUserRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7, Email: "ada@example.com", PasswordHash: "secret hash"}, nil)
+	token, err := signAccessToken(testSecret, 7, 3, testNow, DefaultAccessTokenTTL)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	newAuthRouter(mockUserRepo).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"email":"ada@example.com"`)
+	assert.NotContains(t, w.Body.String(), "secret hash")
+}
+
+func TestUserHandler_Logout(t *testing.T) {
+	mockUserRepo := &mocks.MockUserRepository{}
+	mockUserRepo.On("GetSession", uint(3)).Return(&model.Session{ID: 3, UserID: 7}, nil)
+	mockUserRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7}, nil)
+	mockUserRepo.On("RevokeSession", uint(3), testNow).Return(nil)
+	token, err := signAccessToken(testSecret, 7, 3, testNow, DefaultAccessTokenTTL)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	newAuthRouter(mockUserRepo).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserHandler_ErrorStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		setupMocks     func(*mocks.MockUserRepository)
+		expectedStatus int
+	}{
+		{
+			name: "signup",
+			path: "/api/auth/signup",
+			body: `{"email": "ada@example.com", "password": "correct horse"}`,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByEmail", "ada@example.com").Return(nil, gorm.ErrRecordNotFound)
+				userRepo.On("Create", mock.Anything).Return(&model.User{ID: 7}, nil)
+				userRepo.On("CreateSession", mock.Anything).Return(&model.Session{ID: 3, UserID: 7}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "signup_malformed_body",
+			path:           "/api/auth/signup",
+			body:           `{"email": `,
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "signup_short_password",
+			path:           "/api/auth/signup",
+			body:           `{"email": "ada@example.com", "password": "short"}`,
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "signup_email_taken",
+			path: "/api/auth/signup",
+			body: `{"email": "ada@example.com", "password": "correct horse"}`,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByEmail", "ada@example.com").Return(&model.User{ID: 7}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "login_unknown_email",
+			path: "/api/auth/login",
+			body: `{"email": "bob@example.com", "password": "correct horse"}`,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByEmail", "bob@example.com").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "refresh_missing_token",
+			path:           "/api/auth/refresh",
+			body:           `{}`,
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "refresh_unknown_token",
+			path: "/api/auth/refresh",
+			body: `{"refresh_token": "stolen"}`,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetSessionByRefreshTokenHash", hashToken("stolen")).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "forgot_password_unknown_email",
+			path: "/api/auth/password/forgot",
+			body: `{"email": "bob@example.com"}`,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByEmail", "bob@example.com").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name: "reset_password_unknown_token",
+			path: "/api/auth/password/reset",
+			body: `{"token": "guess", "password": "new password"}`,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetPasswordResetToken", hashToken("guess")).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			tt.setupMocks(mockUserRepo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			newAuthRouter(mockUserRepo).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			var response dtos.APIResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedStatus < 300, response.Success)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_AdminRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           model.Role
+		method         string
+		path           string
+		body           string
+		setupMocks     func(*mocks.MockUserRepository)
+		expectedStatus int
+	}{
+		{
+			name:           "reader_cannot_change_roles",
+			role:           model.RoleReader,
+			method:         http.MethodPut,
+			path:           "/api/admin/users/8/role",
+			body:           `{"role": "admin"}`,
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "moderator_cannot_list_users",
+			role:           model.RoleModerator,
+			method:         http.MethodGet,
+			path:           "/api/admin/users",
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "admin_lists_users",
+			role:   model.RoleAdmin,
+			method: http.MethodGet,
+			path:   "/api/admin/users?role=employer&page=2&page_size=10",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("List", "", model.RoleEmployer, 10, 10).Return([]model.User{{ID: 8}}, int64(11), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "admin_lists_unknown_role",
+			role:           model.RoleAdmin,
+			method:         http.MethodGet,
+			path:           "/api/admin/users?role=owner",
+			setupMocks:     func(*mocks.MockUserRepository) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "admin_promotes_user",
+			role:   model.RoleAdmin,
+			method: http.MethodPut,
+			path:   "/api/admin/users/8/role",
+			body:   `{"role": "employer"}`,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByID", uint(8)).Return(&model.User{ID: 8, Role: model.RoleReader}, nil)
+				userRepo.On("UpdateRole", uint(8), model.RoleEmployer).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "last_admin_cannot_demote_themselves",
+			role:   model.RoleAdmin,
+			method: http.MethodPut,
+			path:   "/api/admin/users/7/role",
+			body:   `{"role": "reader"}`,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("CountByRole", model.RoleAdmin).Return(int64(1), nil)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			mockUserRepo.On("GetSession", uint(3)).Return(&model.Session{ID: 3, UserID: 7}, nil)
+			mockUserRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7, Role: tt.role}, nil)
+			tt.setupMocks(mockUserRepo)
+			token, err := signAccessToken(testSecret, 7, 3, testNow, DefaultAccessTokenTTL)
+			require.NoError(t, err)
+			router := newAuthRouter(mockUserRepo)
+			NewUserHandler(newTestUserService(mockUserRepo)).RegisterUserAdminRoutes(router.Group("/api"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	granted := func(role model.Role) []Permission {
+		var permissions []Permission
+		for _, p := range []Permission{PermissionCreateJobs, PermissionModerateJobs, PermissionBatchDeleteJobs,
+			PermissionExportJobs, PermissionCurateCompanies, PermissionManageSearch, PermissionManageUsers,
+			PermissionManageAPIKeys} {
+			if HasPermission(role, p) {
+				permissions = append(permissions, p)
+			}
+		}
+		return permissions
+	}
+
+	assert.Empty(t, granted(model.RoleReader))
+	assert.Equal(t, []Permission{PermissionCreateJobs}, granted(model.RoleEmployer))
+	assert.Equal(t, []Permission{PermissionCreateJobs, PermissionModerateJobs, PermissionCurateCompanies}, granted(model.RoleModerator))
+	assert.Len(t, granted(model.RoleAdmin), 8)
+	assert.Empty(t, granted("owner"))
+}
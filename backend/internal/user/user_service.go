@@ -0,0 +1,537 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/bhati00/workova/backend/internal/user/repository"
+	"github.com/bhati00/workova/backend/pkg/notify"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// UserService defines business logic operations for accounts and sessions
+type UserService interface {
+	Signup(request dtos.SignupRequest, client Client) (*dtos.AuthResponse, error)
+	Login(request dtos.LoginRequest, client Client) (*dtos.AuthResponse, error)
+	// Refresh trades a refresh token for a new pair, the old refresh token stops working
+	Refresh(refreshToken string) (*dtos.AuthResponse, error)
+	// Logout revokes the session, its access tokens stop working too
+	Logout(sessionID uint) error
+	// Authenticate resolves an access token to its user and active session
+	Authenticate(accessToken string) (*model.User, *model.Session, error)
+	GetUser(id uint) (*model.User, error)
+
+	// RequestPasswordReset sends a reset token to the account with the email, if any
+	RequestPasswordReset(email string) error
+	// ResetPassword sets a new password and logs the user out everywhere
+	ResetPassword(request dtos.ResetPasswordRequest) error
+
+	// Roles
+	ListUsers(query string, role model.Role, page, pageSize int) (*dtos.PaginatedUsersResponse, error)
+	// SetRole changes the role of a user, taking effect on their next request
+	SetRole(id uint, role model.Role) (*model.User, error)
+	// BootstrapAdmin makes the first admin, signing them up unless the email has an account
+	BootstrapAdmin(request dtos.SignupRequest) (*model.User, error)
+}
+
+var (
+	// ErrInvalidUserRequest is returned for unusable signup or password input
+	ErrInvalidUserRequest = errors.New("invalid request")
+	// ErrEmailTaken is returned on signup with the email of an existing account
+	ErrEmailTaken = errors.New("email already registered")
+	// ErrInvalidCredentials is returned for an unknown email or a wrong password
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrInvalidToken is returned for expired, revoked or forged tokens
+	ErrInvalidToken = errors.New("invalid or expired token")
+	// ErrUserNotFound is returned when no user has the requested ID
+	ErrUserNotFound = errors.New("user not found")
+	// ErrLastAdmin is returned when a role change would leave no admin
+	ErrLastAdmin = errors.New("cannot demote the last admin")
+	// ErrAdminExists is returned by BootstrapAdmin once there is an admin
+	ErrAdminExists = errors.New("an admin already exists")
+	// ErrPasswordResetUnavailable is returned for reset requests when no sender is configured
+	ErrPasswordResetUnavailable = errors.New("password reset is not available")
+)
+
+// Password length limits. bcrypt ignores everything past 72 bytes.
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+// Default lifetimes of the tokens
+const (
+	DefaultAccessTokenTTL   = 15 * time.Minute
+	DefaultRefreshTokenTTL  = 30 * 24 * time.Hour
+	DefaultPasswordResetTTL = time.Hour
+)
+
+// Client describes where a login comes from, kept on the session
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
+
+// PasswordResetSender delivers password reset tokens to users
+type PasswordResetSender interface {
+	SendPasswordReset(user *model.User, token string, expiresAt time.Time) error
+}
+
+// notifierResetSender mails reset tokens to the email of the account
+type notifierResetSender struct {
+	notifier notify.Notifier
+}
+
+// NewNotifierResetSender creates a sender delivering reset tokens through notifier. The
+// token is in the message body, so only use notifiers that deliver to the user, not logs.
+func NewNotifierResetSender(notifier notify.Notifier) PasswordResetSender {
+	return notifierResetSender{notifier: notifier}
+}
+
+func (s notifierResetSender) SendPasswordReset(user *model.User, token string, expiresAt time.Time) error {
+	return s.notifier.Notify(notify.Message{
+		To:      user.Email,
+		Subject: "Reset your Workova password",
+		Body: fmt.Sprintf("Someone asked to reset the password of your Workova account.\n\n"+
+			"Use this token to set a new password:\n\n%s\n\n"+
+			"It is valid until %s. If you didn't ask for it, ignore this message.\n",
+			token, expiresAt.UTC().Format(time.RFC1123)),
+	})
+}
+
+// userService implements UserService interface
+type userService struct {
+	userRepo repository.UserRepository
+	secret   []byte
+
+	accessTokenTTL   time.Duration
+	refreshTokenTTL  time.Duration
+	passwordResetTTL time.Duration
+	bcryptCost       int
+	resetSender      PasswordResetSender
+	now              func() time.Time
+
+	// dummyHash is compared against on logins with an unknown email, so they take as long
+	// as logins with a wrong password and don't reveal which emails have accounts
+	dummyHash []byte
+}
+
+// UserServiceOption customizes a user service
+type UserServiceOption func(*userService)
+
+// WithTokenTTLs sets the lifetimes of access, refresh and password reset tokens, zero
+// keeps the default
+func WithTokenTTLs(access, refresh, passwordReset time.Duration) UserServiceOption {
+	return func(s *userService) {
+		if access > 0 {
+			s.accessTokenTTL = access
+		}
+		if refresh > 0 {
+			s.refreshTokenTTL = refresh
+		}
+		if passwordReset > 0 {
+			s.passwordResetTTL = passwordReset
+		}
+	}
+}
+
+// WithBcryptCost sets the bcrypt cost of new password hashes
+func WithBcryptCost(cost int) UserServiceOption {
+	return func(s *userService) {
+		s.bcryptCost = cost
+	}
+}
+
+// WithPasswordResetSender sets how reset tokens reach users. Without one, password reset
+// requests fail with ErrPasswordResetUnavailable.
+func WithPasswordResetSender(sender PasswordResetSender) UserServiceOption {
+	return func(s *userService) {
+		s.resetSender = sender
+	}
+}
+
+// NewUserService creates a new user service signing access tokens with secret
+func NewUserService(userRepo repository.UserRepository, secret []byte, opts ...UserServiceOption) UserService {
+	s := &userService{
+		userRepo:         userRepo,
+		secret:           secret,
+		accessTokenTTL:   DefaultAccessTokenTTL,
+		refreshTokenTTL:  DefaultRefreshTokenTTL,
+		passwordResetTTL: DefaultPasswordResetTTL,
+		bcryptCost:       bcrypt.DefaultCost,
+		now:              time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a password"), s.bcryptCost)
+	return s
+}
+
+func (s *userService) Signup(request dtos.SignupRequest, client Client) (*dtos.AuthResponse, error) {
+	email, err := normalizeEmail(request.Email)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePassword(request.Password); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByEmail(email); err == nil {
+		return nil, ErrEmailTaken
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+
+	now := s.now()
+	user, err := s.createUser(email, request, model.RoleReader, &now)
+	if err != nil {
+		return nil, err
+	}
+	return s.startSession(user, client)
+}
+
+// createUser stores a new account with a validated email
+func (s *userService) createUser(email string, request dtos.SignupRequest, role model.Role, lastLoginAt *time.Time) (*model.User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(request.Password), s.bcryptCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user, err := s.userRepo.Create(&model.User{
+		Email:        email,
+		Name:         strings.TrimSpace(request.Name),
+		PasswordHash: string(hash),
+		Role:         role,
+		LastLoginAt:  lastLoginAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *userService) Login(request dtos.LoginRequest, client Client) (*dtos.AuthResponse, error) {
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+	user, err := s.userRepo.GetByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		bcrypt.CompareHashAndPassword(s.dummyHash, []byte(request.Password))
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	now := s.now()
+	user.LastLoginAt = &now
+	if err := s.userRepo.Update(user); err != nil {
+		log.Printf("Failed to record login of user (ID: %d): %v", user.ID, err)
+	}
+	return s.startSession(user, client)
+}
+
+// startSession creates a session for the user and issues its first token pair
+func (s *userService) startSession(user *model.User, client Client) (*dtos.AuthResponse, error) {
+	refreshToken, refreshHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	now := s.now()
+	session := &model.Session{
+		UserID:           user.ID,
+		RefreshTokenHash: refreshHash,
+		UserAgent:        optionalString(truncate(client.UserAgent, 255)),
+		IPAddress:        optionalString(client.IPAddress),
+		ExpiresAt:        now.Add(s.refreshTokenTTL),
+	}
+	if session, err = s.userRepo.CreateSession(session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return s.authResponse(user, session.ID, refreshToken, session.ExpiresAt)
+}
+
+func (s *userService) Refresh(refreshToken string) (*dtos.AuthResponse, error) {
+	oldHash := hashToken(refreshToken)
+	session, err := s.userRepo.GetSessionByRefreshTokenHash(oldHash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	now := s.now()
+	if !session.Active(now) {
+		return nil, ErrInvalidToken
+	}
+	user, err := s.userRepo.GetByID(session.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	newToken, newHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	expiresAt := now.Add(s.refreshTokenTTL)
+	err = s.userRepo.RotateRefreshToken(session.ID, oldHash, newHash, expiresAt, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	return s.authResponse(user, session.ID, newToken, expiresAt)
+}
+
+func (s *userService) authResponse(user *model.User, sessionID uint, refreshToken string, refreshExpiresAt time.Time) (*dtos.AuthResponse, error) {
+	accessToken, err := signAccessToken(s.secret, user.ID, sessionID, s.now(), s.accessTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return &dtos.AuthResponse{
+		User:             user,
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(s.accessTokenTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+func (s *userService) Logout(sessionID uint) error {
+	if err := s.userRepo.RevokeSession(sessionID, s.now()); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+func (s *userService) Authenticate(accessToken string) (*model.User, *model.Session, error) {
+	now := s.now()
+	userID, sessionID, err := parseAccessToken(s.secret, accessToken, now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	session, err := s.userRepo.GetSession(sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	// Revoked sessions end their access tokens early, expired refresh tokens don't
+	if session.RevokedAt != nil || session.UserID != userID {
+		return nil, nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, session, nil
+}
+
+func (s *userService) GetUser(id uint) (*model.User, error) {
+	user, err := s.userRepo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// RequestPasswordReset succeeds for unknown emails too, so it can't be used to find out
+// which emails have accounts
+func (s *userService) RequestPasswordReset(email string) error {
+	if s.resetSender == nil {
+		return ErrPasswordResetUnavailable
+	}
+	user, err := s.userRepo.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("failed to create reset token: %w", err)
+	}
+	reset := &model.PasswordResetToken{UserID: user.ID, TokenHash: hash, ExpiresAt: s.now().Add(s.passwordResetTTL)}
+	if err := s.userRepo.CreatePasswordResetToken(reset); err != nil {
+		return fmt.Errorf("failed to save reset token: %w", err)
+	}
+	if err := s.resetSender.SendPasswordReset(user, token, reset.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to send reset token: %w", err)
+	}
+	return nil
+}
+
+func (s *userService) ResetPassword(request dtos.ResetPasswordRequest) error {
+	if err := validatePassword(request.Password); err != nil {
+		return err
+	}
+	reset, err := s.userRepo.GetPasswordResetToken(hashToken(request.Token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get reset token: %w", err)
+	}
+	now := s.now()
+	if reset.UsedAt != nil || !now.Before(reset.ExpiresAt) {
+		return ErrInvalidToken
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(request.Password), s.bcryptCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	err = s.userRepo.ResetPassword(reset, string(hash), now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+	return nil
+}
+
+func (s *userService) ListUsers(query string, role model.Role, page, pageSize int) (*dtos.PaginatedUsersResponse, error) {
+	if role != "" && !role.Valid() {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidUserRequest, role)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	users, totalCount, err := s.userRepo.List(query, role, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return &dtos.PaginatedUsersResponse{
+		Users:       users,
+		TotalCount:  totalCount,
+		CurrentPage: page,
+		PageSize:    pageSize,
+		TotalPages:  int((totalCount + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+func (s *userService) SetRole(id uint, role model.Role) (*model.User, error) {
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidUserRequest, role)
+	}
+	user, err := s.GetUser(id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+	if user.Role == model.RoleAdmin {
+		admins, err := s.userRepo.CountByRole(model.RoleAdmin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count admins: %w", err)
+		}
+		if admins <= 1 {
+			return nil, ErrLastAdmin
+		}
+	}
+
+	if err := s.userRepo.UpdateRole(id, role); err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	user.Role = role
+	return user, nil
+}
+
+// BootstrapAdmin only works while there is no admin, later admins are made with SetRole.
+// The password is ignored when the email already has an account.
+func (s *userService) BootstrapAdmin(request dtos.SignupRequest) (*model.User, error) {
+	admins, err := s.userRepo.CountByRole(model.RoleAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count admins: %w", err)
+	}
+	if admins > 0 {
+		return nil, ErrAdminExists
+	}
+	email, err := normalizeEmail(request.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(email)
+	if err == nil {
+		if err := s.userRepo.UpdateRole(user.ID, model.RoleAdmin); err != nil {
+			return nil, fmt.Errorf("failed to update role: %w", err)
+		}
+		user.Role = model.RoleAdmin
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+
+	if err := validatePassword(request.Password); err != nil {
+		return nil, err
+	}
+	return s.createUser(email, request, model.RoleAdmin, nil)
+}
+
+// normalizeEmail validates a bare email address and lower-cases it
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || len(email) > 255 {
+		return "", fmt.Errorf("%w: email must be a valid address", ErrInvalidUserRequest)
+	}
+	return email, nil
+}
+
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return fmt.Errorf("%w: password must be %d to %d bytes long", ErrInvalidUserRequest, minPasswordLength, maxPasswordLength)
+	}
+	return nil
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// truncate cuts s to at most length bytes without splitting a character
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	for length > 0 && !utf8.RuneStart(s[length]) {
+		length--
+	}
+	return s[:length]
+}
@@ -0,0 +1,536 @@
+package user
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/bhati00/workova/backend/dtos"
+	"github.com/bhati00/workova/backend/internal/user/mocks"
+	"github.com/bhati00/workova/backend/internal/user/model"
+	"github.com/bhati00/workova/backend/pkg/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	testSecret = []byte("test secret")
+	testNow    = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+)
+
+// resetSenderFunc adapts a function to PasswordResetSender
+type resetSenderFunc func(user *model.User, token string, expiresAt time.Time) error
+
+func (f resetSenderFunc) SendPasswordReset(user *model.User, token string, expiresAt time.Time) error {
+	return f(user, token, expiresAt)
+}
+
+// newTestUserService returns a user service with cheap password hashes, a fixed clock and a
+// reset sender dropping tokens
+func newTestUserService(userRepo *mocks.MockUserRepository, opts ...UserServiceOption) *userService {
+	discard := resetSenderFunc(func(*model.User, string, time.Time) error { return nil })
+	defaults := []UserServiceOption{WithBcryptCost(bcrypt.MinCost), WithPasswordResetSender(discard)}
+	s := NewUserService(userRepo, testSecret, append(defaults, opts...)...).(*userService)
+	s.now = func() time.Time { return testNow }
+	return s
+}
+
+func hashPassword(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hash)
+}
+
+func TestUserService_Signup(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       dtos.SignupRequest
+		setupMocks    func(*mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name:    "creates_user_and_session",
+			request: dtos.SignupRequest{Email: " Ada@Example.com ", Password: "correct horse", Name: " Ada "},
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByEmail", "ada@example.com").Return(nil, gorm.ErrRecordNotFound)
+				userRepo.On("Create", mock.MatchedBy(func(user *model.User) bool {
+					return user.Email == "ada@example.com" && user.Name == "Ada" && user.Role == model.RoleReader &&
+						bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("correct horse")) == nil
+				})).Run(func(args mock.Arguments) {
+					args.Get(0).(*model.User).ID = 7
+				}).Return(&model.User{ID: 7, Email: "ada@example.com", Name: "Ada"}, nil)
+				userRepo.On("CreateSession", mock.MatchedBy(func(session *model.Session) bool {
+					return session.UserID == 7 && len(session.RefreshTokenHash) == 64 &&
+						session.ExpiresAt.Equal(testNow.Add(DefaultRefreshTokenTTL))
+				})).Run(func(args mock.Arguments) {
+					args.Get(0).(*model.Session).ID = 3
+				}).Return(&model.Session{ID: 3, UserID: 7, ExpiresAt: testNow.Add(DefaultRefreshTokenTTL)}, nil)
+			},
+		},
+		{
+			name:          "invalid_email",
+			request:       dtos.SignupRequest{Email: "Ada <ada@example.com>", Password: "correct horse"},
+			setupMocks:    func(*mocks.MockUserRepository) {},
+			expectedError: ErrInvalidUserRequest,
+		},
+		{
+			name:          "short_password",
+			request:       dtos.SignupRequest{Email: "ada@example.com", Password: "short"},
+			setupMocks:    func(*mocks.MockUserRepository) {},
+			expectedError: ErrInvalidUserRequest,
+		},
+		{
+			name:    "email_taken",
+			request: dtos.SignupRequest{Email: "ada@example.com", Password: "correct horse"},
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByEmail", "ada@example.com").Return(&model.User{ID: 1}, nil)
+			},
+			expectedError: ErrEmailTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			tt.setupMocks(mockUserRepo)
+
+			auth, err := newTestUserService(mockUserRepo).Signup(tt.request, Client{UserAgent: "test"})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, auth)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "Bearer", auth.TokenType)
+				assert.Equal(t, int(DefaultAccessTokenTTL.Seconds()), auth.ExpiresIn)
+				assert.NotEmpty(t, auth.RefreshToken)
+
+				userID, sessionID, err := parseAccessToken(testSecret, auth.AccessToken, testNow)
+				require.NoError(t, err)
+				assert.Equal(t, uint(7), userID)
+				assert.Equal(t, uint(3), sessionID)
+			}
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_Login(t *testing.T) {
+	user := func() *model.User {
+		return &model.User{ID: 7, Email: "ada@example.com", PasswordHash: hashPassword(t, "correct horse")}
+	}
+	tests := []struct {
+		name          string
+		request       dtos.LoginRequest
+		setupMocks    func(*mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name:    "valid_credentials",
+			request: dtos.LoginRequest{Email: "ADA@example.com", Password: "correct horse"},
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByEmail", "ada@example.com").Return(user(), nil)
+				userRepo.On("Update", mock.MatchedBy(func(user *model.User) bool {
+					return user.LastLoginAt != nil && user.LastLoginAt.Equal(testNow)
+				})).Return(nil)
+				userRepo.On("CreateSession", mock.Anything).Return(&model.Session{ID: 3, UserID: 7}, nil)
+			},
+		},
+		{
+			name:    "wrong_password",
+			request: dtos.LoginRequest{Email: "ada@example.com", Password: "wrong horse"},
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByEmail", "ada@example.com").Return(user(), nil)
+			},
+			expectedError: ErrInvalidCredentials,
+		},
+		{
+			name:    "unknown_email",
+			request: dtos.LoginRequest{Email: "bob@example.com", Password: "correct horse"},
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByEmail", "bob@example.com").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			tt.setupMocks(mockUserRepo)
+
+			auth, err := newTestUserService(mockUserRepo).Login(tt.request, Client{})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, auth)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, uint(7), auth.User.ID)
+			}
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_Refresh(t *testing.T) {
+	oldHash := hashToken("old refresh token")
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name: "rotates_refresh_token",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetSessionByRefreshTokenHash", oldHash).Return(&model.Session{ID: 3, UserID: 7, ExpiresAt: testNow.Add(time.Hour)}, nil)
+				userRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7}, nil)
+				userRepo.On("RotateRefreshToken", uint(3), oldHash, mock.MatchedBy(func(newHash string) bool {
+					return newHash != oldHash
+				}), testNow.Add(DefaultRefreshTokenTTL), testNow).Return(nil)
+			},
+		},
+		{
+			name: "unknown_token",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetSessionByRefreshTokenHash", oldHash).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "expired_session",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetSessionByRefreshTokenHash", oldHash).Return(&model.Session{ID: 3, UserID: 7, ExpiresAt: testNow}, nil)
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "revoked_session",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				revokedAt := testNow.Add(-time.Minute)
+				userRepo.On("GetSessionByRefreshTokenHash", oldHash).Return(&model.Session{ID: 3, UserID: 7, ExpiresAt: testNow.Add(time.Hour), RevokedAt: &revokedAt}, nil)
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "concurrent_refresh_wins",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetSessionByRefreshTokenHash", oldHash).Return(&model.Session{ID: 3, UserID: 7, ExpiresAt: testNow.Add(time.Hour)}, nil)
+				userRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7}, nil)
+				userRepo.On("RotateRefreshToken", uint(3), oldHash, mock.Anything, mock.Anything, mock.Anything).Return(gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			tt.setupMocks(mockUserRepo)
+
+			auth, err := newTestUserService(mockUserRepo).Refresh("old refresh token")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, auth)
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, "old refresh token", auth.RefreshToken)
+				assert.Equal(t, testNow.Add(DefaultRefreshTokenTTL), auth.RefreshExpiresAt)
+			}
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_Authenticate(t *testing.T) {
+	validToken, err := signAccessToken(testSecret, 7, 3, testNow, DefaultAccessTokenTTL)
+	require.NoError(t, err)
+	expiredToken, err := signAccessToken(testSecret, 7, 3, testNow.Add(-time.Hour), DefaultAccessTokenTTL)
+	require.NoError(t, err)
+	forgedToken, err := signAccessToken([]byte("other secret"), 7, 3, testNow, DefaultAccessTokenTTL)
+	require.NoError(t, err)
+	revokedAt := testNow.Add(-time.Minute)
+
+	tests := []struct {
+		name          string
+		token         string
+		setupMocks    func(*mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name:  "valid_token",
+			token: validToken,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetSession", uint(3)).Return(&model.Session{ID: 3, UserID: 7}, nil)
+				userRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7}, nil)
+			},
+		},
+		{
+			name:  "revoked_session",
+			token: validToken,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetSession", uint(3)).Return(&model.Session{ID: 3, UserID: 7, RevokedAt: &revokedAt}, nil)
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:  "session_of_another_user",
+			token: validToken,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetSession", uint(3)).Return(&model.Session{ID: 3, UserID: 8}, nil)
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "expired_token",
+			token:         expiredToken,
+			setupMocks:    func(*mocks.MockUserRepository) {},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "forged_token",
+			token:         forgedToken,
+			setupMocks:    func(*mocks.MockUserRepository) {},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "garbage",
+			token:         "not.a.jwt",
+			setupMocks:    func(*mocks.MockUserRepository) {},
+			expectedError: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			tt.setupMocks(mockUserRepo)
+
+			user, session, err := newTestUserService(mockUserRepo).Authenticate(tt.token)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, user)
+				assert.Nil(t, session)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, uint(7), user.ID)
+				assert.Equal(t, uint(3), session.ID)
+			}
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_PasswordReset(t *testing.T) {
+	t.Run("sends_token_that_resets_password", func(t *testing.T) {
+		mockUserRepo := &mocks.MockUserRepository{}
+		var sentToken string
+		sender := resetSenderFunc(func(user *model.User, token string, expiresAt time.Time) error {
+			assert.Equal(t, uint(7), user.ID)
+			assert.Equal(t, testNow.Add(DefaultPasswordResetTTL), expiresAt)
+			sentToken = token
+			return nil
+		})
+		service := newTestUserService(mockUserRepo, WithPasswordResetSender(sender))
+
+		var stored *model.PasswordResetToken
+		mockUserRepo.On("GetByEmail", "ada@example.com").Return(&model.User{ID: 7}, nil)
+		mockUserRepo.On("CreatePasswordResetToken", mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*model.PasswordResetToken)
+		}).Return(nil)
+		require.NoError(t, service.RequestPasswordReset("Ada@example.com"))
+		require.NotEmpty(t, sentToken)
+		assert.Equal(t, hashToken(sentToken), stored.TokenHash)
+
+		mockUserRepo.On("GetPasswordResetToken", stored.TokenHash).Return(stored, nil)
+		mockUserRepo.On("ResetPassword", stored, mock.MatchedBy(func(hash string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte("new password")) == nil
+		}), testNow).Return(nil)
+		assert.NoError(t, service.ResetPassword(dtos.ResetPasswordRequest{Token: sentToken, Password: "new password"}))
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown_email_sends_nothing", func(t *testing.T) {
+		mockUserRepo := &mocks.MockUserRepository{}
+		sender := resetSenderFunc(func(*model.User, string, time.Time) error {
+			t.Fatal("reset token sent for unknown email")
+			return nil
+		})
+		mockUserRepo.On("GetByEmail", "bob@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		assert.NoError(t, newTestUserService(mockUserRepo, WithPasswordResetSender(sender)).RequestPasswordReset("bob@example.com"))
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("without_sender_creates_no_token", func(t *testing.T) {
+		mockUserRepo := &mocks.MockUserRepository{}
+		service := newTestUserService(mockUserRepo, WithPasswordResetSender(nil))
+
+		assert.ErrorIs(t, service.RequestPasswordReset("ada@example.com"), ErrPasswordResetUnavailable)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("mails_token_to_account_email", func(t *testing.T) {
+		var sent bytes.Buffer
+		sender := NewNotifierResetSender(notify.NewWriterNotifier(&sent))
+
+		require.NoError(t, sender.SendPasswordReset(&model.User{ID: 7, Email: "ada@example.com"}, "reset-token", testNow))
+		assert.Contains(t, sent.String(), "To: ada@example.com\n")
+		assert.Contains(t, sent.String(), "reset-token")
+	})
+
+	usedAt := testNow.Add(-time.Minute)
+	for name, token := range map[string]*model.PasswordResetToken{
+		"expired_token": {ID: 1, UserID: 7, ExpiresAt: testNow},
+		"used_token":    {ID: 1, UserID: 7, ExpiresAt: testNow.Add(time.Hour), UsedAt: &usedAt},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			mockUserRepo.On("GetPasswordResetToken", hashToken("reset token")).Return(token, nil)
+
+			err := newTestUserService(mockUserRepo).ResetPassword(dtos.ResetPasswordRequest{Token: "reset token", Password: "new password"})
+
+			assert.ErrorIs(t, err, ErrInvalidToken)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_SetRole(t *testing.T) {
+	tests := []struct {
+		name          string
+		role          model.Role
+		setupMocks    func(*mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name: "promotes_reader",
+			role: model.RoleEmployer,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7, Role: model.RoleReader}, nil)
+				userRepo.On("UpdateRole", uint(7), model.RoleEmployer).Return(nil)
+			},
+		},
+		{
+			name: "demotes_one_of_two_admins",
+			role: model.RoleModerator,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7, Role: model.RoleAdmin}, nil)
+				userRepo.On("CountByRole", model.RoleAdmin).Return(int64(2), nil)
+				userRepo.On("UpdateRole", uint(7), model.RoleModerator).Return(nil)
+			},
+		},
+		{
+			name: "keeps_last_admin",
+			role: model.RoleReader,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByID", uint(7)).Return(&model.User{ID: 7, Role: model.RoleAdmin}, nil)
+				userRepo.On("CountByRole", model.RoleAdmin).Return(int64(1), nil)
+			},
+			expectedError: ErrLastAdmin,
+		},
+		{
+			name:          "unknown_role",
+			role:          "owner",
+			setupMocks:    func(*mocks.MockUserRepository) {},
+			expectedError: ErrInvalidUserRequest,
+		},
+		{
+			name: "unknown_user",
+			role: model.RoleEmployer,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetByID", uint(7)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			tt.setupMocks(mockUserRepo)
+
+			user, err := newTestUserService(mockUserRepo).SetRole(7, tt.role)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, user)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.role, user.Role)
+			}
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_BootstrapAdmin(t *testing.T) {
+	request := dtos.SignupRequest{Email: "Root@example.com", Password: "correct horse"}
+	tests := []struct {
+		name          string
+		request       dtos.SignupRequest
+		setupMocks    func(*mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name:    "creates_admin",
+			request: request,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("CountByRole", model.RoleAdmin).Return(int64(0), nil)
+				userRepo.On("GetByEmail", "root@example.com").Return(nil, gorm.ErrRecordNotFound)
+				userRepo.On("Create", mock.MatchedBy(func(user *model.User) bool {
+					return user.Email == "root@example.com" && user.Role == model.RoleAdmin
+				})).Return(&model.User{ID: 1, Email: "root@example.com", Role: model.RoleAdmin}, nil)
+			},
+		},
+		{
+			name:    "promotes_existing_account",
+			request: dtos.SignupRequest{Email: "root@example.com"},
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("CountByRole", model.RoleAdmin).Return(int64(0), nil)
+				userRepo.On("GetByEmail", "root@example.com").Return(&model.User{ID: 1, Role: model.RoleReader}, nil)
+				userRepo.On("UpdateRole", uint(1), model.RoleAdmin).Return(nil)
+			},
+		},
+		{
+			name:    "admin_exists",
+			request: request,
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("CountByRole", model.RoleAdmin).Return(int64(1), nil)
+			},
+			expectedError: ErrAdminExists,
+		},
+		{
+			name:    "new_account_needs_password",
+			request: dtos.SignupRequest{Email: "root@example.com"},
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("CountByRole", model.RoleAdmin).Return(int64(0), nil)
+				userRepo.On("GetByEmail", "root@example.com").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: ErrInvalidUserRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.MockUserRepository{}
+			tt.setupMocks(mockUserRepo)
+
+			user, err := newTestUserService(mockUserRepo).BootstrapAdmin(tt.request)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, user)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, model.RoleAdmin, user.Role)
+			}
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
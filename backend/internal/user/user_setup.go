@@ -0,0 +1,115 @@
+package user
+
+import (
+	"crypto/rand"
+	"log"
+
+	"github.com/bhati00/workova/backend/config"
+	"github.com/bhati00/workova/backend/internal/user/repository"
+	"github.com/bhati00/workova/backend/pkg/notify"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"gorm.io/gorm"
+)
+
+// UserModule represents the complete user module with all dependencies
+type UserModule struct {
+	Service       UserService
+	Handler       *UserHandler
+	APIKeyService APIKeyService
+	APIKeyHandler *APIKeyHandler
+}
+
+// InitializeUserModule initializes the complete user module
+func InitializeUserModule(db *gorm.DB, config *config.Config) *UserModule {
+	// Run migrations
+	Migrate(config.DBpath)
+
+	userService := NewUserService(repository.NewUserRepository(db), jwtSecret(config),
+		WithPasswordResetSender(passwordResetSender(config)))
+	apiKeyService := NewAPIKeyService(repository.NewAPIKeyRepository(db))
+	return &UserModule{
+		Service:       userService,
+		Handler:       NewUserHandler(userService),
+		APIKeyService: apiKeyService,
+		APIKeyHandler: NewAPIKeyHandler(apiKeyService),
+	}
+}
+
+// jwtSecret returns the configured signing secret, or a random one that logs everybody
+// out on restart
+func jwtSecret(config *config.Config) []byte {
+	if config.JWTSecret != "" {
+		return []byte(config.JWTSecret)
+	}
+	log.Println("JWT_SECRET is not set, using a random secret: sessions end when the server restarts")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Could not generate JWT secret: %v", err)
+	}
+	return secret
+}
+
+// passwordResetSender mails reset tokens through the SMTP server of config. Without one,
+// password resets are disabled: tokens are never written to logs.
+func passwordResetSender(config *config.Config) PasswordResetSender {
+	if config.SMTPAddr == "" {
+		log.Println("SMTP_ADDR is not set, password resets are disabled")
+		return nil
+	}
+	notifier, err := notify.NewSMTPNotifier(notify.SMTPConfig{
+		Addr:     config.SMTPAddr,
+		From:     config.SMTPFrom,
+		Username: config.SMTPUsername,
+		Password: config.SMTPPassword,
+	})
+	if err != nil {
+		log.Fatalf("Could not configure password reset mail: %v", err)
+	}
+	return NewNotifierResetSender(notifier)
+}
+
+// Authenticate returns the middleware populating the current user of requests
+func (um *UserModule) Authenticate() gin.HandlerFunc {
+	return Authenticate(um.Service)
+}
+
+// AuthenticateAPIKey returns the middleware identifying and metering API key requests
+func (um *UserModule) AuthenticateAPIKey() gin.HandlerFunc {
+	return AuthenticateAPIKey(um.APIKeyService)
+}
+
+// RegisterRoutes registers all user routes to the router
+func (um *UserModule) RegisterRoutes(router *gin.Engine) {
+	v1 := router.Group("/api/")
+
+	um.Handler.RegisterUserRoutes(v1)
+	um.Handler.RegisterUserAdminRoutes(v1)
+	um.APIKeyHandler.RegisterAPIKeyAdminRoutes(v1)
+}
+
+// Migrate applies the user migrations. They are tracked in their own table so they don't
+// interfere with the versions of the job migrations.
+func Migrate(dbPath string) {
+	dsn := "sqlite://" + dbPath + "?x-migrations-table=user_schema_migrations"
+
+	m, err := migrate.New(
+		"file://internal/user/migrations",
+		dsn,
+	)
+	if err != nil {
+		log.Fatalf("Could not initialize user migration: %v", err)
+	}
+
+	if err := m.Up(); err != nil {
+		if err == migrate.ErrNoChange {
+			log.Println("No new user migrations to apply")
+		} else {
+			log.Fatalf("User migration failed: %v", err)
+		}
+	} else {
+		log.Println("User migrations applied successfully")
+	}
+}
@@ -0,0 +1,87 @@
+// Package companyname normalizes company names and websites so the same employer seen
+// by different sources maps to one company
+package companyname
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// dropped characters are removed outright so "Inc." and "Inc" or "O'Reilly" and
+// "OReilly" normalize the same; any other punctuation separates words
+const dropped = ".,'’"
+
+// legalSuffixes are the legal-form words stripped from the end of normalized names,
+// "GmbH & Co. KG" included since suffixes are stripped repeatedly
+var legalSuffixes = func() map[string]bool {
+	set := make(map[string]bool)
+	for _, s := range strings.Fields(`inc incorporated llc pllc ltd limited corp corporation co company
+		plc lp llp pty pte gmbh mbh ag kg ug se sa sas sarl srl spa bv nv oy oyj ab as aps kk`) {
+		set[s] = true
+	}
+	return set
+}()
+
+// Normalize lower-cases name, removes punctuation, collapses whitespace and strips legal
+// suffixes, so "Splash Inc.", "Splash, Inc" and "splash" all become "splash". A name made
+// only of legal words keeps its first word.
+func Normalize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case strings.ContainsRune(dropped, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(b.String())
+	for len(words) > 1 && legalSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// Slug turns a company name into a URL-friendly identifier, "company" when nothing is left
+func Slug(name string) string {
+	slug := strings.ReplaceAll(Normalize(name), " ", "-")
+	if slug == "" {
+		return "company"
+	}
+	return slug
+}
+
+// sharedHosts are job boards, ATS and social sites whose domain says nothing about the employer
+var sharedHosts = map[string]bool{
+	"linkedin.com": true, "ycombinator.com": true, "workatastartup.com": true, "wellfound.com": true,
+	"angel.co": true, "greenhouse.io": true, "boards.greenhouse.io": true, "lever.co": true,
+	"jobs.lever.co": true, "ashbyhq.com": true, "jobs.ashbyhq.com": true, "workable.com": true,
+	"apply.workable.com": true, "smartrecruiters.com": true, "github.com": true, "twitter.com": true,
+	"x.com": true, "facebook.com": true, "instagram.com": true, "medium.com": true,
+	"notion.site": true, "google.com": true, "sites.google.com": true,
+}
+
+// Domain extracts the registrable host of a company website ("https://www.acme.io/about"
+// becomes "acme.io"). Returns "" for unparseable URLs and shared hosts such as job boards.
+func Domain(website string) string {
+	website = strings.TrimSpace(website)
+	if website == "" {
+		return ""
+	}
+	if !strings.Contains(website, "://") {
+		website = "https://" + website
+	}
+	u, err := url.Parse(website)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	host = strings.TrimPrefix(host, "www.")
+	if !strings.Contains(host, ".") || sharedHosts[host] {
+		return ""
+	}
+	return host
+}
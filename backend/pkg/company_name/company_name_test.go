@@ -0,0 +1,59 @@
+package companyname
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"case and punctuation", "  Splash, Inc. ", "splash"},
+		{"variants match", "splash inc", "splash"},
+		{"stacked suffixes", "Müller GmbH & Co. KG", "müller"},
+		{"dotted suffix", "Acme L.L.C.", "acme"},
+		{"suffix words inside the name are kept", "Co Labs Inc", "co labs"},
+		{"only legal words", "Company Inc", "company"},
+		{"apostrophes are dropped", "O'Reilly Media", "oreilly media"},
+		{"separators become spaces", "AT&T / Labs-X", "at t labs x"},
+		{"unicode letters kept", "Zürich Ventures", "zürich ventures"},
+		{"empty", " ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Normalize(tt.input))
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "splash", Slug("Splash, Inc."))
+	assert.Equal(t, "acme-robotics", Slug("Acme Robotics GmbH"))
+	assert.Equal(t, "company", Slug("..."))
+}
+
+func TestDomain(t *testing.T) {
+	tests := []struct {
+		website  string
+		expected string
+	}{
+		{"https://www.acme.io/about", "acme.io"},
+		{"acme.io", "acme.io"},
+		{"http://Careers.Acme.com:8080", "careers.acme.com"},
+		{"https://www.linkedin.com/company/acme", ""},
+		{"https://jobs.lever.co/acme", ""},
+		{"localhost", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.website, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Domain(tt.website))
+		})
+	}
+}
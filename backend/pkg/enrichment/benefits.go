@@ -0,0 +1,163 @@
+package enrichment
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Security clearance levels, matching the values stored in Job.SecurityClearance
+const (
+	ClearanceNone         = "none"
+	ClearanceConfidential = "confidential"
+	ClearanceSecret       = "secret"
+	ClearanceTopSecret    = "top_secret"
+)
+
+// BenefitsInference holds the perks and legal requirements found in a description.
+// Nil means the description doesn't say.
+type BenefitsInference struct {
+	Benefits          []string // Perk labels in a fixed order, e.g. "401(k)", "Unlimited PTO"
+	HealthInsurance   *bool
+	PaidTimeOff       *bool
+	FlexibleSchedule  *bool
+	EquityOffered     *bool
+	VisaSponsorship   *bool
+	SecurityClearance *string
+}
+
+// Found reports whether anything was detected
+func (b BenefitsInference) Found() bool {
+	return len(b.Benefits) > 0 || b.HealthInsurance != nil || b.PaidTimeOff != nil || b.FlexibleSchedule != nil ||
+		b.EquityOffered != nil || b.VisaSponsorship != nil || b.SecurityClearance != nil
+}
+
+// perk is a benefit label and the phrases that signal it
+type perk struct {
+	label   string
+	pattern *regexp.Regexp
+}
+
+var perks = []perk{
+	{"Health insurance", regexp.MustCompile(`(?i)\b(health|medical|healthcare|health care)\s+(insurance|coverage|plan|benefits?)\b|\bkrankenversicherung\b`)},
+	{"Dental", regexp.MustCompile(`(?i)\bdental\b`)},
+	{"Vision", regexp.MustCompile(`(?i)\bvision\s+(insurance|coverage|plan|care)\b|\bmedical,\s*dental,?\s*(and\s+)?vision\b`)},
+	{"401(k)", regexp.MustCompile(`(?i)\b401\s*\(?k\)?`)},
+	{"Pension", regexp.MustCompile(`(?i)\b(pension|retirement)\s+(plan|scheme|contributions?|savings?)\b|\bbetriebliche altersvorsorge\b`)},
+	{"Unlimited PTO", regexp.MustCompile(`(?i)\bunlimited\s+(pto|paid time off|vacation|holidays?|time off|leave)\b`)},
+	{"Paid time off", regexp.MustCompile(`(?i)\b(paid time off|pto|paid vacation|paid holidays|annual leave|\d{2}\s+(vacation|holiday|pto)\s+days|\d{2}\s+days\s+(of\s+)?(paid\s+)?(vacation|holidays?|annual leave|pto)|urlaubstage)\b`)},
+	{"Parental leave", regexp.MustCompile(`(?i)\b(parental|maternity|paternity)\s+leave\b`)},
+	{"Flexible hours", regexp.MustCompile(`(?i)\bflexible\s+(working\s+)?(hours|schedules?|working|work(ing)?\s+times?)\b|\bflexi?time\b|\bgleitzeit\b`)},
+	{"Equity", regexp.MustCompile(`(?i)\b(equity|stock options?|rsus?|esop|vsop|employee stock|ownership stake|share options?)\b`)},
+	{"Bonus", regexp.MustCompile(`(?i)\b(annual|performance|signing|sign-on)\s+bonus(es)?\b`)},
+	{"Learning budget", regexp.MustCompile(`(?i)\b(learning|education|training|conference|development)\s+(budget|stipend|allowance)\b`)},
+	{"Home office stipend", regexp.MustCompile(`(?i)\b(home\s*office|remote|wfh|equipment)\s+(stipend|budget|allowance)\b`)},
+	{"Gym", regexp.MustCompile(`(?i)\b(gym|fitness|wellness)\s+(membership|stipend|budget|allowance|subsidy)\b|\burban sports club\b|\bwellpass\b`)},
+	{"Meals", regexp.MustCompile(`(?i)\b(free|catered|daily)\s+(lunch|lunches|meals|breakfast|food)\b|\bmeal\s+(vouchers?|allowance)\b`)},
+	{"Relocation", regexp.MustCompile(`(?i)\brelocation\s+(support|assistance|package|bonus|budget)\b`)},
+	{"Visa sponsorship", regexp.MustCompile(`(?i)\bvisa\s+sponsorship\b`)},
+}
+
+var (
+	// "Diversity, equity and inclusion" and "private equity" are not an equity offer
+	notEquityPattern = regexp.MustCompile(`(?i)\b(diversity,?\s+equity|equity,?\s+(and|&)\s+inclusion|private equity|equity research|home equity|brand equity|pay equity)\b`)
+
+	noVisaPattern  = regexp.MustCompile(`(?i)\b(no|not|without)\s+(offer(ing)?\s+|provide\s+|provid(e|ing)\s+)?(visa\s+)?sponsorship\b|\b(unable|not able|cannot|can't|can not|won't|will not|do not|does not|don't)\s+(to\s+)?(offer\s+|provide\s+)?sponsor\w*\b|\bsponsorship\s+(is\s+)?not\s+(available|possible|offered)\b`)
+	visaPattern    = regexp.MustCompile(`(?i)\bvisa\s+sponsorship\s+(is\s+)?(available|offered|provided|possible)\b|\b(we|will|can|happy to|able to)\s+(also\s+)?sponsor\s+(work\s+)?(visas?|work permits?|h-?1b)\b|\b(offer|provide|including|includes|with)\s+(visa\s+sponsorship|sponsorship)\b|\bvisa\s+(support|assistance)\b|\bsponsorship available\b`)
+	noPTOPattern   = regexp.MustCompile(`(?i)\b(no|unpaid)\s+(paid time off|pto|vacation)\b`)
+	noEquityOffer  = regexp.MustCompile(`(?i)\bno\s+(equity|stock options)\b`)
+	tsSCIPattern   = regexp.MustCompile(`(?i)\b(ts\s*/\s*sci|top\s*secret|ts\s+clearance)\b`)
+	secretPattern  = regexp.MustCompile(`(?i)\bsecret\s+(security\s+)?clearance\b|\bclearance[^.]{0,20}\bsecret\b`)
+	confidPattern  = regexp.MustCompile(`(?i)\bconfidential\s+(security\s+)?clearance\b`)
+	noClearPattern = regexp.MustCompile(`(?i)\bno\s+(security\s+)?clearance\s+(is\s+)?(required|needed)\b`)
+)
+
+// InferBenefits detects perks, visa sponsorship and security clearance requirements in text
+func InferBenefits(text string) BenefitsInference {
+	var b BenefitsInference
+	if strings.TrimSpace(text) == "" {
+		return b
+	}
+
+	for _, p := range perks {
+		if !p.pattern.MatchString(text) {
+			continue
+		}
+		if p.label == "Equity" && !hasEquityOffer(text) {
+			continue
+		}
+		if p.label == "Visa sponsorship" && noVisaPattern.MatchString(text) {
+			continue
+		}
+		b.Benefits = append(b.Benefits, p.label)
+	}
+
+	has := func(labels ...string) bool {
+		for _, label := range labels {
+			for _, benefit := range b.Benefits {
+				if benefit == label {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if has("Health insurance", "Dental", "Vision") {
+		b.HealthInsurance = boolPtr(true)
+	}
+	if has("Paid time off", "Unlimited PTO") {
+		b.PaidTimeOff = boolPtr(true)
+	} else if noPTOPattern.MatchString(text) {
+		b.PaidTimeOff = boolPtr(false)
+	}
+	if has("Flexible hours") {
+		b.FlexibleSchedule = boolPtr(true)
+	}
+	if has("Equity") {
+		b.EquityOffered = boolPtr(true)
+	} else if noEquityOffer.MatchString(text) {
+		b.EquityOffered = boolPtr(false)
+	}
+
+	// A refusal wins over a generic mention such as "visa sponsorship: no"
+	switch {
+	case noVisaPattern.MatchString(text):
+		b.VisaSponsorship = boolPtr(false)
+	case visaPattern.MatchString(text) || has("Visa sponsorship"):
+		b.VisaSponsorship = boolPtr(true)
+	}
+
+	switch {
+	case tsSCIPattern.MatchString(text):
+		b.SecurityClearance = stringPtr(ClearanceTopSecret)
+	case secretPattern.MatchString(text):
+		b.SecurityClearance = stringPtr(ClearanceSecret)
+	case confidPattern.MatchString(text):
+		b.SecurityClearance = stringPtr(ClearanceConfidential)
+	case noClearPattern.MatchString(text):
+		b.SecurityClearance = stringPtr(ClearanceNone)
+	}
+	return b
+}
+
+// hasEquityOffer reports whether "equity" and friends appear outside of phrases like
+// "diversity, equity and inclusion"
+func hasEquityOffer(text string) bool {
+	return perks[equityPerkIndex].pattern.MatchString(notEquityPattern.ReplaceAllString(text, ""))
+}
+
+var equityPerkIndex = func() int {
+	for i, p := range perks {
+		if p.label == "Equity" {
+			return i
+		}
+	}
+	return -1
+}()
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
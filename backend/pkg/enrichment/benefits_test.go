@@ -0,0 +1,80 @@
+package enrichment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferBenefits(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantBenefits []string
+		health       *bool
+		pto          *bool
+		flexible     *bool
+		equity       *bool
+		visa         *bool
+		clearance    *string
+	}{
+		{
+			name:         "typical startup perks",
+			text:         "We offer competitive salary and equity, medical, dental and vision insurance, a 401(k) match and unlimited PTO. Flexible hours.",
+			wantBenefits: []string{"Dental", "Vision", "401(k)", "Unlimited PTO", "Flexible hours", "Equity"},
+			health:       boolPtr(true),
+			pto:          boolPtr(true),
+			flexible:     boolPtr(true),
+			equity:       boolPtr(true),
+		},
+		{
+			name:         "visa sponsorship available",
+			text:         "Visa sponsorship available for the right candidate. 30 days of paid vacation.",
+			wantBenefits: []string{"Paid time off", "Visa sponsorship"},
+			pto:          boolPtr(true),
+			visa:         boolPtr(true),
+		},
+		{
+			name: "no visa sponsorship",
+			text: "Please note we are unable to sponsor visas. No visa sponsorship.",
+			visa: boolPtr(false),
+		},
+		{
+			name: "diversity equity and inclusion is not equity",
+			text: "We are committed to diversity, equity and inclusion.",
+		},
+		{
+			name:      "top secret clearance",
+			text:      "Candidates must hold an active TS/SCI clearance.",
+			clearance: stringPtr(ClearanceTopSecret),
+		},
+		{
+			name:      "secret clearance",
+			text:      "An active Secret clearance is required.",
+			clearance: stringPtr(ClearanceSecret),
+		},
+		{
+			name: "nothing to find",
+			text: "Build APIs in Go.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := InferBenefits(tt.text)
+
+			for _, benefit := range tt.wantBenefits {
+				assert.Contains(t, got.Benefits, benefit)
+			}
+			if tt.wantBenefits == nil {
+				assert.NotContains(t, got.Benefits, "Equity")
+			}
+			assert.Equal(t, tt.health, got.HealthInsurance)
+			assert.Equal(t, tt.pto, got.PaidTimeOff)
+			assert.Equal(t, tt.flexible, got.FlexibleSchedule)
+			assert.Equal(t, tt.equity, got.EquityOffered)
+			assert.Equal(t, tt.visa, got.VisaSponsorship)
+			assert.Equal(t, tt.clearance, got.SecurityClearance)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package enrichment
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// categoryRule holds the phrases that put a job into a taxonomy category. The slugs
+// match the categories seeded by the 000018 migration.
+type categoryRule struct {
+	slug        string
+	parent      string
+	title       *regexp.Regexp
+	description *regexp.Regexp
+}
+
+// phrases builds a case-insensitive whole-word pattern from alternatives
+func phrases(alternatives string) *regexp.Regexp {
+	if alternatives == "" {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)\b(` + alternatives + `)\b`)
+}
+
+// categoryRules are listed parents first. A parent without title phrases is only
+// reached through its children.
+var categoryRules = []categoryRule{
+	{"engineering", "", phrases(`engineer|engineering|developer|programmer|software|swe|sde`), nil},
+	{"backend", "engineering", phrases(`backend|back-end|back end|server[- ]side`),
+		phrases(`microservices|rest apis?|grpc|postgres(?:ql)?|distributed systems|database design`)},
+	{"frontend", "engineering", phrases(`frontend|front-end|front end|react|angular|vue(?:\.js)?|web developer|ui engineer`),
+		phrases(`react|typescript|css|vue(?:\.js)?|angular|next\.js`)},
+	{"fullstack", "engineering", phrases(`full[- ]?stack`), phrases(`full[- ]?stack`)},
+	{"mobile", "engineering", phrases(`mobile|ios|android|react native|flutter`),
+		phrases(`ios|android|swiftui|react native|flutter|kotlin|app store`)},
+	{"devops", "engineering", phrases(`devops|sre|site reliability|infrastructure|platform engineer|cloud engineer|systems engineer`),
+		phrases(`kubernetes|terraform|ci/cd|observability|docker|ansible|helm`)},
+	{"data-engineering", "engineering", phrases(`data engineer|data engineering|etl|data platform|analytics engineer`),
+		phrases(`spark|airflow|dbt|data pipelines?|snowflake|kafka|bigquery`)},
+	{"machine-learning", "engineering", phrases(`machine learning|ml|ai|deep learning|nlp|computer vision|llms?|research scientist`),
+		phrases(`pytorch|tensorflow|llms?|model training|machine learning|deep learning`)},
+	{"security", "engineering", phrases(`security|appsec|infosec|cyber ?security|penetration tester`),
+		phrases(`threat modeling|soc ?2|vulnerabilit(?:y|ies)|penetration testing|siem|incident response`)},
+	{"qa", "engineering", phrases(`qa|quality assurance|quality engineer|tester|test engineer|sdet|test automation`),
+		phrases(`test automation|selenium|cypress|playwright|test plans?|regression testing`)},
+	{"embedded", "engineering", phrases(`embedded|firmware|hardware|electrical engineer|fpga|robotics`),
+		phrases(`rtos|firmware|pcb|microcontrollers?|embedded c`)},
+	{"engineering-management", "engineering", phrases(`engineering manager|head of engineering|vp,? engineering|vp of engineering|director of engineering|cto`), nil},
+
+	{"data", "", nil, nil},
+	{"data-science", "data", phrases(`data scientist|data science|statistician|quantitative researcher`),
+		phrases(`statistical model(?:l?ing)?|a/b testing|causal inference|experimentation|regression models?`)},
+	{"analytics", "data", phrases(`data analyst|business analyst|business intelligence|bi analyst|bi developer|analytics`),
+		phrases(`tableau|looker|power bi|dashboards|metabase`)},
+
+	{"product", "", nil, nil},
+	{"product-management", "product", phrases(`product manager|product owner|product lead|head of product|vp,? product|pm`),
+		phrases(`product roadmap|roadmap|product strategy|prioriti[sz]ation|product requirements|prds?`)},
+
+	{"design", "", phrases(`design|designer`), nil},
+	{"product-design", "design", phrases(`product designer|product design|ux|ui/ux|ux/ui|ui designer|user experience|interaction designer|user researcher`),
+		phrases(`figma|prototyp(?:e|es|ing)|wireframes?|user research|design systems?|usability testing`)},
+	{"graphic-design", "design", phrases(`graphic designer|graphic design|visual designer|brand designer|illustrator|motion designer`),
+		phrases(`adobe creative suite|photoshop|after effects|indesign|branding`)},
+
+	{"marketing", "", phrases(`marketing|marketer|brand manager`), nil},
+	{"growth-marketing", "marketing", phrases(`growth marketing|growth marketer|growth manager|head of growth|performance marketing|demand generation|seo|sem|paid acquisition|lifecycle marketing`),
+		phrases(`seo|paid acquisition|google ads|conversion rates?|funnels?|paid social`)},
+	{"content-marketing", "marketing", phrases(`content|copywriter|writer|editor|social media|communications`),
+		phrases(`copywriting|blog posts?|editorial|storytelling|content calendar`)},
+
+	{"sales", "", phrases(`sales|business development|partnerships|revenue`),
+		phrases(`quota|sales pipeline|salesforce|closing deals|prospecting`)},
+	{"sales-development", "sales", phrases(`sdr|bdr|sales development|business development representative`), nil},
+	{"account-management", "sales", phrases(`account manager|account executive|key account|account management`), nil},
+
+	{"customer-success", "", phrases(`customer success|customer support|customer service|customer experience|technical support|support specialist|support engineer|helpdesk|help desk|implementation`),
+		phrases(`zendesk|intercom|customer tickets|support tickets|customer satisfaction|onboarding customers`)},
+
+	{"operations", "", phrases(`operations|chief of staff|office manager|program manager|project manager|logistics|supply chain`),
+		phrases(`process improvement|vendor management|procurement|logistics`)},
+	{"people", "operations", phrases(`recruiter|recruiting|talent acquisition|talent partner|hr|human resources|people partner|people operations`),
+		phrases(`full[- ]cycle recruiting|talent acquisition|hris|onboarding new hires`)},
+	{"finance", "operations", phrases(`finance|financial|accountant|accounting|controller|fp&a|bookkeeper|tax|payroll|treasury`),
+		phrases(`gaap|ifrs|month[- ]end close|reconciliations?|financial reporting|fp&a`)},
+	{"legal", "operations", phrases(`legal|counsel|lawyer|attorney|paralegal|compliance`),
+		phrases(`contract negotiation|regulatory compliance|gdpr compliance|litigation`)},
+}
+
+const (
+	// maxCategories caps the categories assigned to one job
+	maxCategories = 3
+	// minDescriptionHits is the number of distinct description phrases needed when the
+	// title matched nothing
+	minDescriptionHits = 2
+)
+
+// ClassifyCategories assigns up to three taxonomy categories to a job, most specific
+// first. The title decides; the description is only used when the title matches no rule.
+// A parent is dropped when one of its children matched.
+func ClassifyCategories(title, description string) []string {
+	type match struct {
+		index int
+		slug  string
+		hits  int
+	}
+
+	var matches []match
+	for i, rule := range categoryRules {
+		if rule.title != nil && rule.title.MatchString(title) {
+			matches = append(matches, match{i, rule.slug, distinctHits(rule.title, title)})
+		}
+	}
+	if len(matches) == 0 {
+		best := match{index: -1}
+		for i, rule := range categoryRules {
+			if rule.description == nil {
+				continue
+			}
+			if hits := distinctHits(rule.description, description); hits >= minDescriptionHits && hits > best.hits {
+				best = match{i, rule.slug, hits}
+			}
+		}
+		if best.index < 0 {
+			return nil
+		}
+		matches = append(matches, best)
+	}
+
+	parents := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		parents[categoryRules[m.index].parent] = true
+	}
+	var specific []match
+	for _, m := range matches {
+		if !parents[m.slug] {
+			specific = append(specific, m)
+		}
+	}
+
+	sort.SliceStable(specific, func(i, j int) bool {
+		if specific[i].hits != specific[j].hits {
+			return specific[i].hits > specific[j].hits
+		}
+		// Children are more specific than top-level categories
+		return categoryRules[specific[i].index].parent != "" && categoryRules[specific[j].index].parent == ""
+	})
+	if len(specific) > maxCategories {
+		specific = specific[:maxCategories]
+	}
+
+	slugs := make([]string, len(specific))
+	for i, m := range specific {
+		slugs[i] = m.slug
+	}
+	return slugs
+}
+
+// distinctHits counts the different phrases of pattern found in text
+func distinctHits(pattern *regexp.Regexp, text string) int {
+	seen := make(map[string]bool)
+	for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+		seen[strings.ToLower(m[1])] = true
+	}
+	return len(seen)
+}
@@ -0,0 +1,47 @@
+package enrichment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyCategories(t *testing.T) {
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		want        []string
+	}{
+		{"child replaces parent", "Senior Software Engineer, Backend", "", []string{"backend"}},
+		{"generic engineer", "Software Engineer", "", []string{"engineering"}},
+		{"data engineer is engineering", "Data Engineer", "Spark and Airflow", []string{"data-engineering"}},
+		{"several specific categories", "Full-Stack Engineer (React / Node)", "", []string{"frontend", "fullstack"}},
+		{"design child", "Senior Product Designer", "", []string{"product-design"}},
+		{"parent without title phrases", "Product Manager, Payments", "", []string{"product-management"}},
+		{"management", "Engineering Manager", "", []string{"engineering-management"}},
+		{"sales child", "Account Executive, Mid-Market", "", []string{"account-management"}},
+		{"operations child", "Technical Recruiter", "", []string{"people"}},
+		{"title decides over description", "Machine Learning Engineer", "You'll work with Kubernetes, Terraform and Docker.", []string{"machine-learning"}},
+		{"description when the title says nothing", "Founding Member of Technical Staff", "Build our platform with Kubernetes, Terraform and CI/CD.", []string{"devops"}},
+		{"one description phrase is not enough", "Hacker in Residence", "We use Docker.", nil},
+		{"word boundaries", "Chief Happiness Officer", "Maintaining our inventory", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyCategories(tt.title, tt.description))
+		})
+	}
+}
+
+func TestCategoryRulesParentsExist(t *testing.T) {
+	slugs := make(map[string]bool)
+	for _, rule := range categoryRules {
+		if rule.parent != "" {
+			assert.True(t, slugs[rule.parent], "parent %s of %s must be listed before it", rule.parent, rule.slug)
+		}
+		assert.False(t, slugs[rule.slug], "duplicate slug %s", rule.slug)
+		slugs[rule.slug] = true
+	}
+}
@@ -0,0 +1,130 @@
+package enrichment
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bhati00/workova/backend/constant"
+)
+
+// ExperienceInference is the seniority derived from a job's title and description
+type ExperienceInference struct {
+	Level    *constant.ExperienceLevel
+	YearsMin *int
+	YearsMax *int
+	Source   string // Where the level came from: title or description
+	Cue      string // Text the level was derived from, e.g. "Senior" or "5+ years"
+}
+
+// titleCue maps a seniority keyword in a job title to a level
+type titleCue struct {
+	pattern *regexp.Regexp
+	level   constant.ExperienceLevel
+}
+
+// titleCues are checked in order, the first match wins. Internships and graduate
+// roles come first so "Senior Software Engineer Intern" stays an entry-level role.
+var titleCues = []titleCue{
+	{regexp.MustCompile(`(?i)\b(intern(ship)?|new grad(uate)?|graduate|trainee|apprentice(ship)?|working student|werkstudent)\b`), constant.ExperienceLevelEntry},
+	{regexp.MustCompile(`(?i)\b(chief \w+ officer|c[eotfip]o|vp|vice president|head of|director)\b`), constant.ExperienceLevelExecutive},
+	{regexp.MustCompile(`(?i)\b(staff|principal|distinguished|lead)\b`), constant.ExperienceLevelLead},
+	{regexp.MustCompile(`(?i)\b(senior|sr\.?|iii|iv)(\b|$)`), constant.ExperienceLevelSenior},
+	{regexp.MustCompile(`(?i)\b(mid[- ]?level|mid[- ]senior|intermediate|ii)(\b|$)`), constant.ExperienceLevelMid},
+	{regexp.MustCompile(`(?i)\b(junior|jr\.?|entry[- ]level|associate)(\b|$)`), constant.ExperienceLevelEntry},
+}
+
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7,
+	"eight": 8, "nine": 9, "ten": 10, "twelve": 12, "fifteen": 15,
+}
+
+const yearNumber = `(\d{1,2}|one|two|three|four|five|six|seven|eight|nine|ten|twelve|fifteen)`
+
+// yearsPattern matches "5+ years of experience", "3-5 yrs experience", "at least two years'
+// experience" and the like. Only years followed by "experience" count, so company ages don't.
+var yearsPattern = regexp.MustCompile(`(?i)(at least|minimum(?: of)?|min\.?|over|more than)?\s*\b` + yearNumber +
+	`\s*(\+|plus)?\s*(?:(?:-|–|to)\s*` + yearNumber + `\s*\+?)?\s*(?:years?|yrs?)['’]?\s*(?:of\s+)?(?:[\w-]+\s+){0,4}?(?:experience|exp\b)`)
+
+// maxYears drops obviously wrong matches such as "30 years of experience as a company"
+const maxYears = 25
+
+// InferExperience derives the experience level and the required years of experience.
+// Title cues win over years mentioned in the description.
+func InferExperience(title, description string) ExperienceInference {
+	var inference ExperienceInference
+
+	// Years can be in the title too ("Backend Engineer (5+ years)")
+	for _, text := range []string{title, description} {
+		if min, max, ok := parseYears(text); ok {
+			inference.YearsMin, inference.YearsMax = min, max
+			break
+		}
+	}
+
+	for _, cue := range titleCues {
+		if m := cue.pattern.FindString(title); m != "" {
+			level := cue.level
+			inference.Level = &level
+			inference.Source = SourceTitle
+			inference.Cue = strings.TrimSpace(m)
+			return inference
+		}
+	}
+
+	if inference.YearsMin != nil {
+		level := levelForYears(*inference.YearsMin)
+		inference.Level = &level
+		inference.Source = SourceDescription
+		inference.Cue = formatYears(inference.YearsMin, inference.YearsMax)
+	}
+	return inference
+}
+
+// parseYears returns the first years-of-experience range in text. Only ranges like "3-5 years"
+// have a max, "5+ years" and "3 years" are both read as a minimum.
+func parseYears(text string) (*int, *int, bool) {
+	for _, m := range yearsPattern.FindAllStringSubmatch(text, -1) {
+		min, ok := yearValue(m[2])
+		if !ok || min > maxYears {
+			continue
+		}
+		var max *int
+		if value, ok := yearValue(m[4]); ok && value >= min && value <= maxYears {
+			max = &value
+		}
+		return &min, max, true
+	}
+	return nil, nil, false
+}
+
+func yearValue(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+	n, ok := numberWords[strings.ToLower(s)]
+	return n, ok
+}
+
+// levelForYears maps a minimum number of years to a level
+func levelForYears(years int) constant.ExperienceLevel {
+	switch {
+	case years <= 1:
+		return constant.ExperienceLevelEntry
+	case years <= 4:
+		return constant.ExperienceLevelMid
+	case years <= 7:
+		return constant.ExperienceLevelSenior
+	default:
+		return constant.ExperienceLevelLead
+	}
+}
+
+func formatYears(min, max *int) string {
+	switch {
+	case max == nil:
+		return strconv.Itoa(*min) + "+ years"
+	default:
+		return strconv.Itoa(*min) + "-" + strconv.Itoa(*max) + " years"
+	}
+}
@@ -0,0 +1,54 @@
+package enrichment
+
+import (
+	"testing"
+
+	"github.com/bhati00/workova/backend/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferExperience(t *testing.T) {
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		wantLevel   constant.ExperienceLevel // 0 means no level
+		wantMin     *int
+		wantMax     *int
+		wantSource  string
+	}{
+		{"senior title", "Senior Backend Engineer", "", constant.ExperienceLevelSenior, nil, nil, SourceTitle},
+		{"abbreviated senior", "Sr. Data Scientist", "", constant.ExperienceLevelSenior, nil, nil, SourceTitle},
+		{"staff title", "Staff Software Engineer, Payments", "", constant.ExperienceLevelLead, nil, nil, SourceTitle},
+		{"principal title", "Principal Engineer", "", constant.ExperienceLevelLead, nil, nil, SourceTitle},
+		{"intern wins over senior", "Senior Software Engineer Intern", "", constant.ExperienceLevelEntry, nil, nil, SourceTitle},
+		{"new grad", "Software Engineer - New Grad 2025", "", constant.ExperienceLevelEntry, nil, nil, SourceTitle},
+		{"roman numeral", "Software Engineer II", "", constant.ExperienceLevelMid, nil, nil, SourceTitle},
+		{"executive", "VP of Engineering", "", constant.ExperienceLevelExecutive, nil, nil, SourceTitle},
+		{"open range from description", "Backend Engineer", "You have 5+ years of experience with Go.", constant.ExperienceLevelSenior, intPtr(5), nil, SourceDescription},
+		{"closed range", "Backend Engineer", "Requires 2-4 years of professional experience.", constant.ExperienceLevelMid, intPtr(2), intPtr(4), SourceDescription},
+		{"number words", "Designer", "At least two years' experience in product design.", constant.ExperienceLevelMid, intPtr(2), nil, SourceDescription},
+		{"title level keeps description years", "Senior Engineer", "3+ years experience", constant.ExperienceLevelSenior, intPtr(3), nil, SourceTitle},
+		{"company age is not experience", "Engineer", "We have been building software for 30 years.", 0, nil, nil, ""},
+		{"no cues", "Software Engineer", "Join our team.", 0, nil, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := InferExperience(tt.title, tt.description)
+
+			if tt.wantLevel == 0 {
+				assert.Nil(t, got.Level)
+			} else if assert.NotNil(t, got.Level) {
+				assert.Equal(t, tt.wantLevel, *got.Level)
+			}
+			assert.Equal(t, tt.wantMin, got.YearsMin)
+			assert.Equal(t, tt.wantMax, got.YearsMax)
+			assert.Equal(t, tt.wantSource, got.Source)
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,422 @@
+package enrichment
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bhati00/workova/backend/pkg/geo"
+)
+
+// Sources a parsed value can come from, in order of precedence
+const (
+	SourceAggregator  = "aggregator"
+	SourceTitle       = "title"
+	SourceLocation    = "location"
+	SourceDescription = "description"
+)
+
+// RemoteEligibility describes who can take a remote job
+type RemoteEligibility struct {
+	IsRemote  bool
+	Worldwide bool
+	Regions   []string // Region labels as written, normalized, e.g. "EMEA", "EU"
+	Countries []string // ISO2 codes allowed to work from, regions expanded
+
+	// Working timezone window as UTC offsets in hours, e.g. -5 to +1
+	TimezoneMinOffset *float64
+	TimezoneMaxOffset *float64
+
+	WorkAuthorizationRequired  bool
+	WorkAuthorizationCountries []string
+
+	Source string // Where the restriction was found: title, location or description
+}
+
+// IsRestricted reports whether anything narrows down who can apply
+func (r RemoteEligibility) IsRestricted() bool {
+	return len(r.Countries) > 0 || r.TimezoneMinOffset != nil || r.WorkAuthorizationRequired
+}
+
+// Summary renders the restriction for display, e.g. "EMEA" or "US, CA; UTC-05:00 to UTC+01:00"
+func (r RemoteEligibility) Summary() string {
+	var parts []string
+	switch {
+	case r.Worldwide && len(r.Countries) == 0:
+		parts = append(parts, "Worldwide")
+	case len(r.Regions) > 0:
+		parts = append(parts, strings.Join(r.Regions, ", "))
+	case len(r.Countries) > 0:
+		parts = append(parts, strings.Join(r.Countries, ", "))
+	}
+	if r.TimezoneMinOffset != nil && r.TimezoneMaxOffset != nil {
+		parts = append(parts, formatOffset(*r.TimezoneMinOffset)+" to "+formatOffset(*r.TimezoneMaxOffset))
+	}
+	if r.WorkAuthorizationRequired {
+		if len(r.WorkAuthorizationCountries) > 0 {
+			parts = append(parts, "work authorization in "+strings.Join(r.WorkAuthorizationCountries, ", "))
+		} else {
+			parts = append(parts, "work authorization required")
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+var (
+	remotePattern    = regexp.MustCompile(`(?i)\b(remote|work from home|wfh|telecommut\w*|fully distributed|distributed team)\b`)
+	notRemotePattern = regexp.MustCompile(`(?i)\b(not|no|non)[- ]remote\b|\bremote work is not\b`)
+	worldwidePattern = regexp.MustCompile(`(?i)\b(worldwide|world-wide|anywhere|globally|global remote|any location|any country)\b`)
+
+	// Description sentences only count when they talk about where people can work from
+	restrictionCuePattern = regexp.MustCompile(`(?i)\b(remote|located|based|reside|residing|live|living|time ?zones?|utc|gmt|anywhere|worldwide|authori[sz]ed to work|eligible to work|right to work|citizen\w*|work permit)\b`)
+
+	utcRangePattern    = regexp.MustCompile(`(?i)\b(?:UTC|GMT)\s*([+\-−–]\s*\d{1,2}(?::?[0-5]\d)?)\s*(?:to|-|–|—|and|through|until)\s*(?:UTC|GMT)?\s*([+\-−–]\s*\d{1,2}(?::?[0-5]\d)?)`)
+	hoursAroundPattern = regexp.MustCompile(`(?i)(?:within|\+/-|±|plus or minus)\s*(\d{1,2})\s*(?:hours?|hrs?|h)\s*(?:of|from|around)\s*(?:the\s+)?(UTC|GMT|CET|CEST|EET|BST|EST|EDT|ET|CST|CT|MST|MT|PST|PDT|PT|IST)\b`)
+	namedZonePattern   = regexp.MustCompile(`(?i)\b(CET|CEST|EET|BST|EST|EDT|ET|CST|CT|MST|MT|PST|PDT|PT|Pacific|Eastern|Central European|Central|Mountain)\s+(?:time\s*zones?|timezones?|hours|business hours|working hours)\b`)
+
+	workAuthCountryPattern = regexp.MustCompile(`(?i)(?:authori[sz]ed|eligible|legally (?:able|permitted)|right|permission)\s+to\s+work\s+(?:in|within|for)\s+(?:the\s+)?([^.;()]+)`)
+	citizenPattern         = regexp.MustCompile(`(?i)\b(U\.?S\.?|American|Canadian|UK|British|EU) citizen(?:s|ship)?\b`)
+	workAuthPattern        = regexp.MustCompile(`(?i)\b(work authori[sz]ation|work permit|right to work)\b[^.]*\b(required|must|need)\b|\b(must|need to) (?:have|hold|possess)\b[^.]*\b(work authori[sz]ation|work permit|right to work)\b|\bno visa sponsorship\b|\bunable to sponsor\b|\bcannot sponsor\b`)
+)
+
+// zoneOffsets are standard UTC offsets for common timezone abbreviations
+var zoneOffsets = map[string]float64{
+	"utc": 0, "gmt": 0, "bst": 1, "cet": 1, "cest": 2, "central european": 1, "eet": 2, "ist": 5.5,
+	"est": -5, "edt": -4, "et": -5, "eastern": -5,
+	"cst": -6, "ct": -6, "central": -6,
+	"mst": -7, "mt": -7, "mountain": -7,
+	"pst": -8, "pdt": -7, "pt": -8, "pacific": -8,
+}
+
+// ParseRemoteEligibility extracts remote eligibility from a job's title, free-text
+// locations and description. The first source that restricts eligibility wins.
+func ParseRemoteEligibility(title string, locations []string, description string) RemoteEligibility {
+	result := RemoteEligibility{}
+	texts := append([]string{title, description}, locations...)
+	for _, text := range texts {
+		if remotePattern.MatchString(text) && !notRemotePattern.MatchString(text) {
+			result.IsRemote = true
+			break
+		}
+	}
+
+	// Work authorization can be stated anywhere in the description
+	for _, sentence := range splitSentences(description) {
+		parseWorkAuthorization(sentence, &result)
+	}
+
+	if restriction, ok := parseRestriction(title); ok && remotePattern.MatchString(title) {
+		return mergeRestriction(result, restriction, SourceTitle)
+	}
+	for _, location := range locations {
+		if !remotePattern.MatchString(location) {
+			continue
+		}
+		if restriction, ok := parseRestriction(location); ok {
+			return mergeRestriction(result, restriction, SourceLocation)
+		}
+	}
+	for _, sentence := range splitSentences(description) {
+		if !restrictionCuePattern.MatchString(sentence) {
+			continue
+		}
+		if restriction, ok := parseRestriction(sentence); ok {
+			return mergeRestriction(result, restriction, SourceDescription)
+		}
+	}
+
+	// Work authorization alone still tells us where people can work from
+	if len(result.WorkAuthorizationCountries) > 0 {
+		result.Countries = append([]string(nil), result.WorkAuthorizationCountries...)
+		result.Source = SourceDescription
+	}
+	return result
+}
+
+func mergeRestriction(result, restriction RemoteEligibility, source string) RemoteEligibility {
+	result.Worldwide = restriction.Worldwide
+	result.Regions = restriction.Regions
+	result.Countries = restriction.Countries
+	result.TimezoneMinOffset = restriction.TimezoneMinOffset
+	result.TimezoneMaxOffset = restriction.TimezoneMaxOffset
+	result.Source = source
+	return result
+}
+
+// parseRestriction finds regions, countries and timezone windows in a piece of text
+func parseRestriction(text string) (RemoteEligibility, bool) {
+	var r RemoteEligibility
+	if text == "" {
+		return r, false
+	}
+
+	countries := make(map[string]bool)
+	for _, match := range placeMatcher().find(text) {
+		if match.region != "" {
+			r.Regions = appendUnique(r.Regions, match.region)
+		}
+		for _, iso := range match.countries {
+			countries[iso] = true
+		}
+	}
+	r.Countries = sortedKeys(countries)
+
+	if worldwidePattern.MatchString(text) && len(r.Countries) == 0 {
+		r.Worldwide = true
+	}
+
+	if min, max, ok := parseTimezoneWindow(text); ok {
+		r.TimezoneMinOffset, r.TimezoneMaxOffset = &min, &max
+	}
+
+	return r, r.Worldwide || len(r.Countries) > 0 || r.TimezoneMinOffset != nil
+}
+
+func parseTimezoneWindow(text string) (float64, float64, bool) {
+	if m := utcRangePattern.FindStringSubmatch(text); m != nil {
+		min, errMin := parseOffset(m[1])
+		max, errMax := parseOffset(m[2])
+		if errMin == nil && errMax == nil {
+			if min > max {
+				min, max = max, min
+			}
+			return min, max, true
+		}
+	}
+	if m := hoursAroundPattern.FindStringSubmatch(text); m != nil {
+		hours, _ := strconv.ParseFloat(m[1], 64)
+		centre := zoneOffsets[strings.ToLower(m[2])]
+		return centre - hours, centre + hours, true
+	}
+	if m := namedZonePattern.FindStringSubmatch(text); m != nil {
+		offset := zoneOffsets[strings.ToLower(m[1])]
+		return offset, offset, true
+	}
+	return 0, 0, false
+}
+
+func parseWorkAuthorization(sentence string, r *RemoteEligibility) {
+	if m := workAuthCountryPattern.FindStringSubmatch(sentence); m != nil {
+		r.WorkAuthorizationRequired = true
+		for _, match := range placeMatcher().find(m[1]) {
+			for _, iso := range match.countries {
+				r.WorkAuthorizationCountries = appendUnique(r.WorkAuthorizationCountries, iso)
+			}
+		}
+	}
+	if m := citizenPattern.FindStringSubmatch(sentence); m != nil {
+		r.WorkAuthorizationRequired = true
+		switch strings.ToLower(strings.ReplaceAll(m[1], ".", "")) {
+		case "us", "american":
+			r.WorkAuthorizationCountries = appendUnique(r.WorkAuthorizationCountries, "US")
+		case "canadian":
+			r.WorkAuthorizationCountries = appendUnique(r.WorkAuthorizationCountries, "CA")
+		case "uk", "british":
+			r.WorkAuthorizationCountries = appendUnique(r.WorkAuthorizationCountries, "GB")
+		}
+	}
+	if workAuthPattern.MatchString(sentence) {
+		r.WorkAuthorizationRequired = true
+	}
+}
+
+func parseOffset(value string) (float64, error) {
+	value = strings.NewReplacer(" ", "", "−", "-", "–", "-").Replace(value)
+	sign := 1.0
+	if strings.HasPrefix(value, "-") {
+		sign = -1
+	}
+	value = strings.TrimLeft(value, "+-")
+
+	hours, minutes := value, "0"
+	if i := strings.Index(value, ":"); i >= 0 {
+		hours, minutes = value[:i], value[i+1:]
+	} else if len(value) > 2 {
+		hours, minutes = value[:len(value)-2], value[len(value)-2:]
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil {
+		return 0, err
+	}
+	return sign * (float64(h) + float64(m)/60), nil
+}
+
+func formatOffset(offset float64) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	hours := int(offset)
+	minutes := int((offset - float64(hours)) * 60)
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, hours, minutes)
+}
+
+// placeMatch is a region or country found in text
+type placeMatch struct {
+	region    string
+	countries []string
+}
+
+type placeMatcherT struct {
+	names   *regexp.Regexp // case-insensitive names of regions and countries
+	codes   *regexp.Regexp // case-sensitive ISO codes, "in" or "it" must not match India or Italy
+	byName  map[string]placeMatch
+	byCode  map[string]placeMatch
+	builder sync.Once
+}
+
+var matcher placeMatcherT
+
+func placeMatcher() *placeMatcherT {
+	matcher.builder.Do(matcher.build)
+	return &matcher
+}
+
+func (m *placeMatcherT) build() {
+	gazetteer := geo.Default()
+	m.byName = make(map[string]placeMatch)
+	m.byCode = make(map[string]placeMatch)
+
+	byContinent := make(map[string][]string)
+	for _, c := range gazetteer.Countries() {
+		byContinent[c.Continent] = append(byContinent[c.Continent], c.ISO)
+	}
+	continents := func(codes ...string) []string {
+		var out []string
+		for _, code := range codes {
+			out = append(out, byContinent[code]...)
+		}
+		return out
+	}
+
+	eu := []string{"AT", "BE", "BG", "HR", "CY", "CZ", "DK", "EE", "FI", "FR", "DE", "GR", "HU", "IE", "IT", "LV", "LT", "LU", "MT", "NL", "PL", "PT", "RO", "SK", "SI", "ES", "SE"}
+	middleEast := []string{"AE", "IL", "SA", "TR", "CY"}
+	regions := map[string]struct {
+		label     string
+		countries []string
+	}{
+		"emea":           {"EMEA", append(continents("EU", "AF"), middleEast...)},
+		"europe":         {"Europe", continents("EU")},
+		"european":       {"Europe", continents("EU")},
+		"eu":             {"EU", eu},
+		"european union": {"EU", eu},
+		"eea":            {"EEA", append(append([]string{}, eu...), "IS", "NO")},
+		"apac":           {"APAC", continents("AS", "OC")},
+		"asia pacific":   {"APAC", continents("AS", "OC")},
+		"asia":           {"Asia", continents("AS")},
+		"latam":          {"LATAM", append(continents("SA"), "MX", "CR")},
+		"latin america":  {"LATAM", append(continents("SA"), "MX", "CR")},
+		"south america":  {"South America", continents("SA")},
+		"americas":       {"Americas", continents("NA", "SA")},
+		"north america":  {"North America", continents("NA")},
+		"africa":         {"Africa", continents("AF")},
+		"oceania":        {"Oceania", continents("OC")},
+		"middle east":    {"Middle East", middleEast},
+		"dach":           {"DACH", []string{"DE", "AT", "CH"}},
+		"nordics":        {"Nordics", []string{"DK", "FI", "IS", "NO", "SE"}},
+		"nordic":         {"Nordics", []string{"DK", "FI", "IS", "NO", "SE"}},
+		"scandinavia":    {"Nordics", []string{"DK", "NO", "SE"}},
+		"benelux":        {"Benelux", []string{"BE", "NL", "LU"}},
+	}
+	var names []string
+	for name, region := range regions {
+		countries := append([]string(nil), region.countries...)
+		sort.Strings(countries)
+		m.byName[name] = placeMatch{region: region.label, countries: countries}
+		names = append(names, name)
+	}
+
+	var codes []string
+	for _, c := range gazetteer.Countries() {
+		match := placeMatch{countries: []string{c.ISO}}
+		for _, name := range append([]string{c.Name}, c.Aliases...) {
+			key := strings.ToLower(name)
+			if _, exists := m.byName[key]; !exists {
+				m.byName[key] = match
+				names = append(names, key)
+			}
+		}
+		for _, code := range []string{c.ISO, c.ISO3} {
+			m.byCode[code] = match
+			codes = append(codes, code)
+		}
+	}
+	// Common non-ISO codes
+	m.byCode["UK"] = placeMatch{countries: []string{"GB"}}
+	codes = append(codes, "UK")
+
+	// Longest first so "United States of America" wins over "America"
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	m.names = regexp.MustCompile(`(?i)(?:^|[^\p{L}])(` + strings.Join(quoted, "|") + `)(?:$|[^\p{L}])`)
+	m.codes = regexp.MustCompile(`\b(` + strings.Join(codes, "|") + `)\b`)
+}
+
+func (m *placeMatcherT) find(text string) []placeMatch {
+	var matches []placeMatch
+	for _, group := range m.names.FindAllStringSubmatch(text, -1) {
+		if match, ok := m.byName[strings.ToLower(group[1])]; ok {
+			matches = append(matches, match)
+		}
+	}
+	for _, loc := range m.codes.FindAllStringSubmatchIndex(text, -1) {
+		if !codeContext(text[:loc[2]], text[loc[3]:]) {
+			continue
+		}
+		if match, ok := m.byCode[text[loc[2]:loc[3]]]; ok {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+var (
+	codePrefixPattern = regexp.MustCompile(`(?i)(?:^|[(,/|:;&\-–—]|\b(?:in|the|from|within|and|or))\s*$`)
+	codeSuffixPattern = regexp.MustCompile(`(?i)^\s*(?:$|[),/|;&]|only\b|-?based\b|residents?\b|citizens?\b)`)
+)
+
+// codeContext reports whether a bare country code sits where a place is expected,
+// so that "Remote (US only)" matches but "Remote IT Support" does not
+func codeContext(before, after string) bool {
+	return codePrefixPattern.MatchString(before) || codeSuffixPattern.MatchString(after)
+}
+
+var sentenceSplitter = regexp.MustCompile(`[.!?\n]+\s*|\s*[•·]\s*`)
+
+func splitSentences(text string) []string {
+	var sentences []string
+	for _, s := range sentenceSplitter.Split(text, -1) {
+		if s = strings.TrimSpace(s); s != "" {
+			sentences = append(sentences, s)
+		}
+	}
+	return sentences
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
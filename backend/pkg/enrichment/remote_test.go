@@ -0,0 +1,143 @@
+package enrichment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRemoteEligibility(t *testing.T) {
+	tests := []struct {
+		name        string
+		title       string
+		locations   []string
+		description string
+
+		wantRemote    bool
+		wantWorldwide bool
+		wantRegions   []string
+		wantCountries []string // subset that must be present
+		notCountries  []string
+		wantTZ        []float64
+		wantAuth      bool
+		wantSource    string
+	}{
+		{
+			name:          "country in title",
+			title:         "Senior Backend Engineer (Remote, US only)",
+			wantRemote:    true,
+			wantCountries: []string{"US"},
+			notCountries:  []string{"DE"},
+			wantSource:    SourceTitle,
+		},
+		{
+			name:          "region in title",
+			title:         "Product Designer - Remote – EMEA",
+			wantRemote:    true,
+			wantRegions:   []string{"EMEA"},
+			wantCountries: []string{"DE", "GB", "ZA", "AE"},
+			notCountries:  []string{"US", "IN"},
+			wantSource:    SourceTitle,
+		},
+		{
+			name:          "location string",
+			title:         "Data Engineer",
+			locations:     []string{"Remote - Germany / Austria"},
+			wantRemote:    true,
+			wantCountries: []string{"DE", "AT"},
+			wantSource:    SourceLocation,
+		},
+		{
+			name:          "worldwide",
+			title:         "Go Developer",
+			description:   "This is a fully remote role. You can work from anywhere in the world.",
+			wantRemote:    true,
+			wantWorldwide: true,
+			wantSource:    SourceDescription,
+		},
+		{
+			name:        "timezone window",
+			title:       "Support Engineer (Remote)",
+			description: "We are remote-first. You should be working between UTC-5 and UTC+1.",
+			wantRemote:  true,
+			wantTZ:      []float64{-5, 1},
+			wantSource:  SourceDescription,
+		},
+		{
+			name:        "hours around named zone",
+			title:       "Frontend Engineer",
+			description: "Remote position, within 3 hours of CET.",
+			wantRemote:  true,
+			wantTZ:      []float64{-2, 4},
+			wantSource:  SourceDescription,
+		},
+		{
+			name:          "work authorization",
+			title:         "Remote Security Engineer",
+			description:   "Candidates must be authorized to work in the United States. We are unable to sponsor visas.",
+			wantRemote:    true,
+			wantCountries: []string{"US"},
+			wantAuth:      true,
+			wantSource:    SourceDescription,
+		},
+		{
+			name:         "lowercase words are not country codes",
+			title:        "Remote IT Support in a growing team",
+			wantRemote:   true,
+			notCountries: []string{"IN", "IT"},
+		},
+		{
+			name:        "onsite job",
+			title:       "Backend Engineer",
+			locations:   []string{"Berlin, Germany"},
+			description: "This is not remote. Our office is in Berlin.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseRemoteEligibility(tt.title, tt.locations, tt.description)
+
+			assert.Equal(t, tt.wantRemote, got.IsRemote)
+			assert.Equal(t, tt.wantWorldwide, got.Worldwide)
+			assert.Equal(t, tt.wantAuth, got.WorkAuthorizationRequired)
+			assert.Equal(t, tt.wantSource, got.Source)
+			for _, region := range tt.wantRegions {
+				assert.Contains(t, got.Regions, region)
+			}
+			for _, iso := range tt.wantCountries {
+				assert.Contains(t, got.Countries, iso)
+			}
+			for _, iso := range tt.notCountries {
+				assert.NotContains(t, got.Countries, iso)
+			}
+			if tt.wantTZ != nil {
+				if assert.NotNil(t, got.TimezoneMinOffset) && assert.NotNil(t, got.TimezoneMaxOffset) {
+					assert.Equal(t, tt.wantTZ[0], *got.TimezoneMinOffset)
+					assert.Equal(t, tt.wantTZ[1], *got.TimezoneMaxOffset)
+				}
+			} else {
+				assert.Nil(t, got.TimezoneMinOffset)
+			}
+		})
+	}
+}
+
+func TestRemoteEligibilitySummary(t *testing.T) {
+	min, max := -5.0, 1.0
+	tests := []struct {
+		name     string
+		input    RemoteEligibility
+		expected string
+	}{
+		{"worldwide", RemoteEligibility{Worldwide: true}, "Worldwide"},
+		{"regions win over countries", RemoteEligibility{Regions: []string{"EMEA"}, Countries: []string{"DE", "GB"}}, "EMEA"},
+		{"countries and timezone", RemoteEligibility{Countries: []string{"US"}, TimezoneMinOffset: &min, TimezoneMaxOffset: &max}, "US; UTC-05:00 to UTC+01:00"},
+		{"work authorization", RemoteEligibility{WorkAuthorizationRequired: true}, "work authorization required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.input.Summary())
+		})
+	}
+}
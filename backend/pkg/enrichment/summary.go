@@ -0,0 +1,190 @@
+package enrichment
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultSummaryLength is the summary length in characters when none is configured
+const DefaultSummaryLength = 300
+
+var (
+	// Sentences describing the role, the work and the must-haves
+	roleCuePattern = regexp.MustCompile(`(?i)\b(you will|you'll|you would|as an? |we are looking for|we're looking for|we are hiring|we're hiring|join|responsib\w*|role|own|build|design|develop|lead|must|required|requirements?|experience (with|in)|proficien\w*|strong|ideal candidate)\b`)
+	// Boilerplate that rarely tells anything about the job itself
+	boilerplatePattern = regexp.MustCompile(`(?i)\b(equal opportunit\w*|equal employment|regardless of|discriminat\w*|accommodation|privacy|cookies?|click|apply (now|today|here)|how to apply|e-?verify|benefits include|we offer|perks)\b`)
+	wordPattern        = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{N}+#.-]*`)
+)
+
+var stopwords = toSet(strings.Fields(`a an and are as at be been but by can could do does for from has have how i if in
+	into is it its of on or our out so such than that the their them then there these they this to up us was we were
+	what when where which while who will with would you your yours all any also about more most other some very just
+	der die das und ist sind mit für auf ein eine einen wir sie ihr ihre du dein als bei zu von im den des dem oder`))
+
+// Summarize picks the most informative sentences of text in their original order, up to
+// maxLength characters. Texts that already fit are returned as they are, sentences are
+// cut at a word boundary only when not even one fits. Returns "" for empty text.
+func Summarize(title, text string, maxLength int) string {
+	if maxLength <= 0 {
+		maxLength = DefaultSummaryLength
+	}
+	flat := strings.Join(strings.Fields(text), " ")
+	if len(flat) <= maxLength {
+		return flat
+	}
+
+	// Lines are split separately so list items don't run into each other
+	var sentences []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimLeft(strings.TrimSpace(line), "-*•· ")
+		for _, s := range splitSummarySentences(strings.Join(strings.Fields(line), " ")) {
+			if !strings.ContainsRune(".!?:", rune(s[len(s)-1])) {
+				s += "."
+			}
+			sentences = append(sentences, s)
+		}
+	}
+	if len(sentences) == 0 {
+		return truncateWords(flat, maxLength)
+	}
+
+	frequencies := make(map[string]int)
+	for _, s := range sentences {
+		for _, w := range contentWords(s) {
+			frequencies[w]++
+		}
+	}
+	titleWords := toSet(contentWords(title))
+
+	type candidate struct {
+		index int
+		score float64
+	}
+	candidates := make([]candidate, len(sentences))
+	for i, s := range sentences {
+		candidates[i] = candidate{index: i, score: scoreSentence(s, i, len(sentences), frequencies, titleWords)}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	// Greedily take the best sentences that still fit
+	chosen := make(map[int]bool)
+	length := 0
+	for _, c := range candidates {
+		if c.score <= 0 {
+			break
+		}
+		l := len(sentences[c.index]) + 1
+		if length+l-1 > maxLength {
+			continue
+		}
+		chosen[c.index] = true
+		length += l
+	}
+	if len(chosen) == 0 {
+		return truncateWords(sentences[candidates[0].index], maxLength)
+	}
+
+	var parts []string
+	for i, s := range sentences {
+		if chosen[i] {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// scoreSentence rates a sentence by how many frequent and title words it has, with
+// bonuses for role cues and early position and penalties for boilerplate and odd lengths
+func scoreSentence(sentence string, index, total int, frequencies map[string]int, titleWords map[string]bool) float64 {
+	words := contentWords(sentence)
+	if len(words) < 4 {
+		return 0
+	}
+
+	score := 0.0
+	for _, w := range words {
+		score += float64(frequencies[w] - 1)
+		if titleWords[w] {
+			score += 2
+		}
+	}
+	// Normalize so long sentences don't win by size alone
+	score /= float64(len(words))
+
+	if roleCuePattern.MatchString(sentence) {
+		score += 1.5
+	}
+	if boilerplatePattern.MatchString(sentence) {
+		score -= 3
+	}
+	if len(words) > 45 {
+		score -= 1
+	}
+	// Descriptions usually open with the role
+	score += 1 - float64(index)/float64(total)
+	return score
+}
+
+// splitSummarySentences splits text after ".", "!" or "?" followed by a space and an
+// upper-case letter or digit, so "e.g. go" and "3.5 years" stay in one sentence
+func splitSummarySentences(text string) []string {
+	var sentences []string
+	runes := []rune(text)
+	start := 0
+	for i := 0; i < len(runes)-2; i++ {
+		if !strings.ContainsRune(".!?", runes[i]) || runes[i+1] != ' ' {
+			continue
+		}
+		next := runes[i+2]
+		if !unicode.IsUpper(next) && !unicode.IsDigit(next) && !strings.ContainsRune(`"'“(-•`, next) {
+			continue
+		}
+		if s := strings.TrimSpace(string(runes[start : i+1])); s != "" {
+			sentences = append(sentences, s)
+		}
+		start = i + 2
+	}
+	if s := strings.TrimSpace(string(runes[start:])); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
+
+func contentWords(text string) []string {
+	var words []string
+	for _, w := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+		w = strings.TrimRight(w, ".-")
+		if len(w) > 1 && !stopwords[w] {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// truncateWords cuts text at the last word boundary that fits maxLength and adds an ellipsis
+func truncateWords(text string, maxLength int) string {
+	if len(text) <= maxLength {
+		return text
+	}
+	cut := strings.LastIndex(text[:maxLength-len("…")+1], " ")
+	if cut <= 0 {
+		cut = maxLength - len("…")
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+	}
+	return strings.TrimRight(text[:cut], " ,;:-") + "…"
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,88 @@
+package enrichment
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleDescription = `Acme is a fast-growing fintech company headquartered in Berlin.
+We are looking for a Backend Engineer to build the payment APIs that power our checkout.
+As a Backend Engineer you will design, build and operate Go services handling millions of payments.
+Requirements:
+- 3+ years of experience building backend services in Go
+- Experience with PostgreSQL and Kubernetes
+We offer a great team, free snacks and a yearly offsite.
+Acme is an equal opportunity employer and does not discriminate regardless of background.`
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name      string
+		title     string
+		text      string
+		maxLength int
+		check     func(t *testing.T, summary string)
+	}{
+		{
+			name:      "picks role sentences and skips boilerplate",
+			title:     "Backend Engineer",
+			text:      sampleDescription,
+			maxLength: 200,
+			check: func(t *testing.T, summary string) {
+				assert.LessOrEqual(t, len(summary), 200)
+				assert.Contains(t, summary, "We are looking for a Backend Engineer")
+				assert.NotContains(t, summary, "equal opportunity")
+				assert.NotContains(t, summary, "snacks")
+			},
+		},
+		{
+			name:      "keeps original sentence order",
+			title:     "Backend Engineer",
+			text:      sampleDescription,
+			maxLength: 400,
+			check: func(t *testing.T, summary string) {
+				first := strings.Index(summary, "We are looking for")
+				second := strings.Index(summary, "As a Backend Engineer")
+				assert.True(t, first >= 0 && second > first, summary)
+			},
+		},
+		{
+			name:      "short text is returned as is",
+			title:     "Designer",
+			text:      "  Design   our app.\n",
+			maxLength: 300,
+			check: func(t *testing.T, summary string) {
+				assert.Equal(t, "Design our app.", summary)
+			},
+		},
+		{
+			name:      "single long sentence is cut at a word boundary",
+			title:     "Engineer",
+			text:      strings.Repeat("building reliable distributed systems ", 20),
+			maxLength: 60,
+			check: func(t *testing.T, summary string) {
+				assert.LessOrEqual(t, len(summary), 60)
+				assert.True(t, strings.HasSuffix(summary, "…"), summary)
+			},
+		},
+		{
+			name:      "empty text",
+			title:     "Engineer",
+			text:      "",
+			maxLength: 300,
+			check: func(t *testing.T, summary string) {
+				assert.Empty(t, summary)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := Summarize(tt.title, tt.text, tt.maxLength)
+			tt.check(t, summary)
+			// Deterministic
+			assert.Equal(t, summary, Summarize(tt.title, tt.text, tt.maxLength))
+		})
+	}
+}
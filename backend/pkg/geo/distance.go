@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"math"
+	"strings"
+	"time"
+	_ "time/tzdata" // resolve IANA names without relying on the host zoneinfo
+)
+
+const (
+	// EarthRadiusKm is the mean earth radius used for distance calculations
+	EarthRadiusKm = 6371.0
+
+	// WorkdayHours is the length of the working day used for timezone overlap
+	WorkdayHours = 8
+)
+
+// DistanceKm returns the great-circle (haversine) distance between two points
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// BoundingBox returns the latitude/longitude box that contains every point within radiusKm
+// of the centre. It is used to pre-filter rows with plain index-friendly comparisons.
+func BoundingBox(lat, lng, radiusKm float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := radiusKm / EarthRadiusKm * 180 / math.Pi
+	minLat, maxLat = math.Max(lat-dLat, -90), math.Min(lat+dLat, 90)
+
+	cosLat := math.Cos(toRadians(lat))
+	if cosLat < 0.01 || minLat == -90 || maxLat == 90 {
+		return minLat, maxLat, -180, 180
+	}
+	dLng := dLat / cosLat
+	return minLat, maxLat, math.Max(lng-dLng, -180), math.Min(lng+dLng, 180)
+}
+
+// LongitudeScale is the factor that turns longitude degrees into latitude-equivalent degrees
+// at the given latitude, for equirectangular distance approximations in SQL
+func LongitudeScale(lat float64) float64 {
+	c := math.Cos(toRadians(lat))
+	return c * c
+}
+
+// KmToDegrees converts a distance along a meridian into degrees of latitude
+func KmToDegrees(km float64) float64 {
+	return km / EarthRadiusKm * 180 / math.Pi
+}
+
+// ResolvePlace resolves free text like "Berlin", "Berlin, DE" or "Austin, United States"
+func (g *Gazetteer) ResolvePlace(text string) (*City, bool) {
+	name, country := text, ""
+	if i := strings.LastIndex(text, ","); i > 0 {
+		name, country = text[:i], strings.TrimSpace(text[i+1:])
+		if _, ok := g.LookupCountry(country); !ok {
+			// Not a country, e.g. "Austin, Texas" - fall back to the city alone
+			country = ""
+		}
+	}
+	return g.LookupCity(name, country)
+}
+
+// WorkdayOverlapHours returns how many hours of a 9-to-5 working day two timezones share at the given instant
+func WorkdayOverlapHours(a, b *time.Location, at time.Time) float64 {
+	_, offsetA := at.In(a).Zone()
+	_, offsetB := at.In(b).Zone()
+
+	diff := math.Abs(float64(offsetA-offsetB)) / 3600
+	if diff > 12 {
+		diff = 24 - diff
+	}
+	return math.Max(0, WorkdayHours-diff)
+}
+
+// TimezonesWithOverlap filters candidate IANA timezones down to those sharing at least
+// minHours of the working day with ref. Unknown timezone names are skipped.
+func TimezonesWithOverlap(ref *time.Location, minHours float64, candidates []string, at time.Time) []string {
+	var matches []string
+	for _, name := range candidates {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		if WorkdayOverlapHours(ref, loc, at) >= minHours {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,50 @@
+package geo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistanceKm(t *testing.T) {
+	berlin, _ := Default().LookupCity("Berlin", "DE")
+	potsdam, _ := Default().LookupCity("Potsdam", "DE")
+	munich, _ := Default().LookupCity("Munich", "DE")
+
+	assert.InDelta(t, 27, DistanceKm(berlin.Latitude, berlin.Longitude, potsdam.Latitude, potsdam.Longitude), 3)
+	assert.InDelta(t, 504, DistanceKm(berlin.Latitude, berlin.Longitude, munich.Latitude, munich.Longitude), 5)
+	assert.Zero(t, DistanceKm(berlin.Latitude, berlin.Longitude, berlin.Latitude, berlin.Longitude))
+}
+
+func TestBoundingBox(t *testing.T) {
+	minLat, maxLat, minLng, maxLng := BoundingBox(52.52, 13.41, 50)
+
+	assert.Less(t, minLat, 52.52)
+	assert.Greater(t, maxLat, 52.52)
+	assert.Less(t, minLng, 13.41)
+	assert.Greater(t, maxLng, 13.41)
+	// Longitude degrees are shorter than latitude degrees away from the equator
+	assert.Greater(t, maxLng-minLng, maxLat-minLat)
+}
+
+func TestResolvePlace(t *testing.T) {
+	city, ok := Default().ResolvePlace("Cambridge, US")
+	assert.True(t, ok)
+	assert.Equal(t, "Massachusetts", city.Region)
+
+	city, ok = Default().ResolvePlace("Austin, Texas")
+	assert.True(t, ok)
+	assert.Equal(t, "US", city.CountryISO)
+}
+
+func TestTimezonesWithOverlap(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	// Winter time: Berlin UTC+1, London UTC+0, New York UTC-5, Tokyo UTC+9
+	at := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+	candidates := []string{"Europe/London", "America/New_York", "Asia/Tokyo", "Not/AZone"}
+
+	assert.Equal(t, []string{"Europe/London"}, TimezonesWithOverlap(berlin, 4, candidates, at))
+	assert.Equal(t, []string{"Europe/London", "America/New_York"}, TimezonesWithOverlap(berlin, 2, candidates, at))
+	assert.Empty(t, TimezonesWithOverlap(berlin, 8, candidates, at))
+}
@@ -0,0 +1,215 @@
+package geo
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The datasets follow the GeoNames cities/countryInfo layout (trimmed down to
+// the columns we use) and are embedded so lookups never touch the network.
+//
+//go:embed data/cities.tsv
+var citiesTSV []byte
+
+//go:embed data/countries.tsv
+var countriesTSV []byte
+
+// Country is a single entry of the embedded country dataset
+type Country struct {
+	ISO       string   // ISO 3166-1 alpha-2, e.g. "DE"
+	ISO3      string   // ISO 3166-1 alpha-3, e.g. "DEU"
+	Name      string   // English short name, e.g. "Germany"
+	Continent string   // GeoNames continent code: AF, AS, EU, NA, OC, SA
+	Timezone  string   // IANA timezone of the capital
+	Aliases   []string // Alternative spellings, e.g. "Deutschland"
+}
+
+// City is a single entry of the embedded cities dataset
+type City struct {
+	Name           string
+	ASCIIName      string
+	AlternateNames []string
+	CountryISO     string
+	Region         string // First-level administrative division (state, province, Land)
+	Latitude       float64
+	Longitude      float64
+	Population     int
+	Timezone       string // IANA timezone, e.g. "Europe/Berlin"
+}
+
+// Gazetteer resolves free-text city and country names against the embedded datasets
+type Gazetteer struct {
+	countries     []Country
+	countryByKey  map[string]*Country
+	cities        []City
+	citiesByKey   map[string][]*City
+	citiesCountry map[string][]*City
+}
+
+var (
+	defaultGazetteer *Gazetteer
+	loadOnce         sync.Once
+)
+
+// Default returns the gazetteer backed by the embedded datasets, loading it on first use
+func Default() *Gazetteer {
+	loadOnce.Do(func() {
+		defaultGazetteer = newGazetteer(citiesTSV, countriesTSV)
+	})
+	return defaultGazetteer
+}
+
+func newGazetteer(citiesData, countriesData []byte) *Gazetteer {
+	g := &Gazetteer{
+		countryByKey:  make(map[string]*Country),
+		citiesByKey:   make(map[string][]*City),
+		citiesCountry: make(map[string][]*City),
+	}
+
+	for _, fields := range readTSV(countriesData) {
+		if len(fields) < 5 {
+			continue
+		}
+		country := Country{
+			ISO:       fields[0],
+			ISO3:      fields[1],
+			Name:      fields[2],
+			Continent: fields[3],
+			Timezone:  fields[4],
+		}
+		if len(fields) > 5 {
+			country.Aliases = splitList(fields[5])
+		}
+		g.countries = append(g.countries, country)
+	}
+	for i := range g.countries {
+		c := &g.countries[i]
+		for _, key := range append([]string{c.ISO, c.ISO3, c.Name}, c.Aliases...) {
+			g.countryByKey[normalizeKey(key)] = c
+		}
+	}
+
+	for _, fields := range readTSV(citiesData) {
+		if len(fields) < 9 {
+			continue
+		}
+		lat, errLat := strconv.ParseFloat(fields[5], 64)
+		lng, errLng := strconv.ParseFloat(fields[6], 64)
+		if errLat != nil || errLng != nil {
+			continue
+		}
+		population, _ := strconv.Atoi(fields[7])
+		g.cities = append(g.cities, City{
+			Name:           fields[0],
+			ASCIIName:      fields[1],
+			AlternateNames: splitList(fields[2]),
+			CountryISO:     fields[3],
+			Region:         fields[4],
+			Latitude:       lat,
+			Longitude:      lng,
+			Population:     population,
+			Timezone:       fields[8],
+		})
+	}
+	for i := range g.cities {
+		c := &g.cities[i]
+		seen := make(map[string]bool)
+		for _, name := range append([]string{c.Name, c.ASCIIName}, c.AlternateNames...) {
+			key := normalizeKey(name)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			g.citiesByKey[key] = append(g.citiesByKey[key], c)
+		}
+		g.citiesCountry[c.CountryISO] = append(g.citiesCountry[c.CountryISO], c)
+	}
+
+	return g
+}
+
+// LookupCountry resolves an ISO2/ISO3 code, English name or known alias to a country
+func (g *Gazetteer) LookupCountry(value string) (*Country, bool) {
+	c, ok := g.countryByKey[normalizeKey(value)]
+	return c, ok
+}
+
+// LookupCity resolves a city name, optionally restricted to a country (any form accepted
+// by LookupCountry). When several cities share a name the most populous one wins.
+func (g *Gazetteer) LookupCity(name, country string) (*City, bool) {
+	candidates := g.citiesByKey[normalizeKey(cleanCityName(name))]
+	if len(candidates) == 0 {
+		return nil, false
+	}
+
+	countryISO := ""
+	if country != "" {
+		if c, ok := g.LookupCountry(country); ok {
+			countryISO = c.ISO
+		}
+	}
+
+	var best *City
+	for _, c := range candidates {
+		if countryISO != "" && c.CountryISO != countryISO {
+			continue
+		}
+		if best == nil || c.Population > best.Population {
+			best = c
+		}
+	}
+	return best, best != nil
+}
+
+// Countries returns every country of the dataset
+func (g *Gazetteer) Countries() []Country {
+	return g.countries
+}
+
+// CitiesInCountry returns all known cities for an ISO2 country code
+func (g *Gazetteer) CitiesInCountry(iso string) []*City {
+	return g.citiesCountry[strings.ToUpper(iso)]
+}
+
+// cleanCityName strips common decorations found in job feeds, e.g. "Berlin, DE" or "Munich (Hybrid)"
+func cleanCityName(name string) string {
+	if i := strings.IndexAny(name, ",("); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func normalizeKey(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func readTSV(data []byte) [][]string {
+	var rows [][]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+	return rows
+}
@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGazetteer_LookupCountry(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		found    bool
+	}{
+		{name: "iso2", value: "DE", expected: "DE", found: true},
+		{name: "iso3", value: "usa", expected: "US", found: true},
+		{name: "english_name", value: "United Kingdom", expected: "GB", found: true},
+		{name: "alias", value: "Deutschland", expected: "DE", found: true},
+		{name: "unknown", value: "Atlantis", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			country, ok := Default().LookupCountry(tt.value)
+
+			assert.Equal(t, tt.found, ok)
+			if tt.found {
+				assert.Equal(t, tt.expected, country.ISO)
+			}
+		})
+	}
+}
+
+func TestGazetteer_LookupCity(t *testing.T) {
+	tests := []struct {
+		name     string
+		city     string
+		country  string
+		region   string
+		timezone string
+		found    bool
+	}{
+		{name: "exact_name", city: "Berlin", country: "DE", region: "Berlin", timezone: "Europe/Berlin", found: true},
+		{name: "alternate_name", city: "München", country: "DE", region: "Bavaria", timezone: "Europe/Berlin", found: true},
+		{name: "decorated_name", city: "Bangalore, India", country: "IN", region: "Karnataka", timezone: "Asia/Kolkata", found: true},
+		{name: "ambiguous_name_with_country", city: "Cambridge", country: "US", region: "Massachusetts", timezone: "America/New_York", found: true},
+		{name: "ambiguous_name_without_country", city: "Cambridge", country: "", region: "England", timezone: "Europe/London", found: true},
+		{name: "wrong_country", city: "Berlin", country: "US", found: false},
+		{name: "unknown_city", city: "Gotham", country: "US", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			city, ok := Default().LookupCity(tt.city, tt.country)
+
+			assert.Equal(t, tt.found, ok)
+			if tt.found {
+				assert.Equal(t, tt.region, city.Region)
+				assert.Equal(t, tt.timezone, city.Timezone)
+				assert.NotZero(t, city.Latitude)
+				assert.NotZero(t, city.Longitude)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Package highlight marks the words of a text that match a search query and cuts
+// excerpts around them. Words match the way the job text search matches them: by their
+// stemmed term, so "operators" is marked for the query "operator".
+package highlight
+
+import (
+	"html"
+	"strings"
+
+	"github.com/bhati00/workova/backend/pkg/language"
+	"github.com/bhati00/workova/backend/pkg/synonym"
+)
+
+// Defaults of Options
+const (
+	DefaultPreTag       = "<mark>"
+	DefaultPostTag      = "</mark>"
+	DefaultFragmentSize = 150
+	DefaultMaxFragments = 3
+)
+
+// Options controls how matches are marked
+type Options struct {
+	PreTag       string // Inserted before every matching word
+	PostTag      string // Inserted after every matching word
+	FragmentSize int    // Approximate length in bytes of an excerpt
+	MaxFragments int    // Maximum excerpts of one text
+	EscapeHTML   bool   // HTML-escape the text around the tags
+}
+
+// DefaultOptions marks matches with <mark> and escapes the text for HTML
+func DefaultOptions() Options {
+	return Options{
+		PreTag:       DefaultPreTag,
+		PostTag:      DefaultPostTag,
+		FragmentSize: DefaultFragmentSize,
+		MaxFragments: DefaultMaxFragments,
+		EscapeHTML:   true,
+	}
+}
+
+// Matcher finds the words of a text that match a query
+type Matcher struct {
+	terms map[string]bool
+}
+
+// NewMatcher matches the words of query and of their synonyms in the default dictionary,
+// stemmed for langs, every supported language when langs is empty. Stop words in the query
+// are ignored like in the search.
+func NewMatcher(query string, langs []string) *Matcher {
+	matcher := &Matcher{terms: make(map[string]bool)}
+	for _, phrases := range synonym.Default().Expand(query) {
+		for _, phrase := range phrases {
+			for _, forms := range language.QueryVariants(phrase, langs) {
+				for _, form := range forms {
+					matcher.terms[form] = true
+				}
+			}
+		}
+	}
+	return matcher
+}
+
+// Empty reports whether the query has no words to match
+func (m *Matcher) Empty() bool {
+	return len(m.terms) == 0
+}
+
+// matches returns the byte spans of the words of text whose term in lang matches
+func (m *Matcher) matches(text, lang string) [][]int {
+	var spans [][]int
+	for _, span := range language.WordSpans(text) {
+		if term := language.Term(text[span[0]:span[1]], lang); term != "" && m.terms[term] {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Highlight returns text, written in lang, with every matching word wrapped in the tags,
+// or "" when no word matches
+func (m *Matcher) Highlight(text, lang string, opts Options) string {
+	spans := m.matches(text, lang)
+	if len(spans) == 0 {
+		return ""
+	}
+	return mark(text, 0, len(text), spans, opts)
+}
+
+// Fragments returns up to opts.MaxFragments excerpts of text around its matching words,
+// in order. Excerpts end on word boundaries and "…" marks where text was cut.
+func (m *Matcher) Fragments(text, lang string, opts Options) []string {
+	spans := m.matches(text, lang)
+	if len(spans) == 0 {
+		return nil
+	}
+	words := language.WordSpans(text)
+
+	var fragments []string
+	end := 0
+	for _, span := range spans {
+		if span[0] < end {
+			continue // Already in the previous excerpt
+		}
+		if len(fragments) == opts.MaxFragments {
+			break
+		}
+
+		// Center the excerpt on the match without overlapping the previous one, then
+		// shrink it to whole words
+		previousEnd := end
+		start := max(0, span[0]-(opts.FragmentSize-(span[1]-span[0]))/2)
+		end = min(len(text), start+opts.FragmentSize)
+		start = max(previousEnd, min(start, end-opts.FragmentSize))
+		for _, word := range words {
+			if start > 0 && word[0] >= start {
+				start = min(word[0], span[0])
+				break
+			}
+		}
+		if end < len(text) {
+			last := span[1]
+			for _, word := range words {
+				if word[1] > end {
+					break
+				}
+				last = max(last, word[1])
+			}
+			end = last
+		}
+
+		fragment := mark(text, start, end, spans, opts)
+		if start > 0 {
+			fragment = "…" + fragment
+		}
+		if end < len(text) {
+			fragment += "…"
+		}
+		fragments = append(fragments, fragment)
+	}
+	return fragments
+}
+
+// mark writes text[start:end] with the spans inside it wrapped in the tags, whitespace runs
+// collapsed to single spaces
+func mark(text string, start, end int, spans [][]int, opts Options) string {
+	var b strings.Builder
+	plain := func(s string) {
+		if opts.EscapeHTML {
+			s = html.EscapeString(s)
+		}
+		b.WriteString(collapseSpaces(s))
+	}
+	pos := start
+	for _, span := range spans {
+		if span[0] < start || span[1] > end {
+			continue
+		}
+		plain(text[pos:span[0]])
+		b.WriteString(opts.PreTag)
+		plain(text[span[0]:span[1]])
+		b.WriteString(opts.PostTag)
+		pos = span[1]
+	}
+	plain(text[pos:end])
+	return strings.TrimSpace(b.String())
+}
+
+func collapseSpaces(s string) string {
+	if !strings.ContainsAny(s, "\n\r\t  ") {
+		return s
+	}
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\n' || r == '\r' || r == '\t' {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package highlight
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bhati00/workova/backend/pkg/synonym"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		langs []string
+		text  string
+		lang  string
+		opts  Options
+		want  string
+	}{
+		{"stemmed words", "kubernetes operator", []string{"en"}, "Senior Kubernetes Operators Engineer", "en", DefaultOptions(),
+			"Senior <mark>Kubernetes</mark> <mark>Operators</mark> Engineer"},
+		{"every language without a filter", "entwickler", nil, "Softwareentwickler / Entwicklerin (m/w/d)", "de", DefaultOptions(),
+			"Softwareentwickler / <mark>Entwicklerin</mark> (m/w/d)"},
+		{"stop words ignored", "the go team", []string{"en"}, "Join the Go platform team", "en", DefaultOptions(),
+			"Join the <mark>Go</mark> platform <mark>team</mark>"},
+		{"html escaped", "c++", []string{"en"}, "C++ & <Rust>", "en", DefaultOptions(),
+			"<mark>C++</mark> &amp; &lt;Rust&gt;"},
+		{"custom tags unescaped", "rust", []string{"en"}, "C++ & Rust", "en", Options{PreTag: "**", PostTag: "**"},
+			"C++ & **Rust**"},
+		{"no match", "java", []string{"en"}, "Go developer", "en", DefaultOptions(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewMatcher(tt.query, tt.langs).Highlight(tt.text, tt.lang, tt.opts))
+		})
+	}
+	assert.True(t, NewMatcher("the and", []string{"en"}).Empty())
+
+	t.Run("synonyms", func(t *testing.T) {
+		previous := synonym.Default()
+		defer synonym.SetDefault(previous)
+		synonym.SetDefault(synonym.NewDictionary([]synonym.Rule{{Terms: []string{"swe"}, Expansions: []string{"software engineer"}}}))
+
+		assert.Equal(t, "Senior <mark>Software</mark> <mark>Engineers</mark>", NewMatcher("SWE", []string{"en"}).Highlight("Senior Software Engineers", "en", DefaultOptions()))
+	})
+}
+
+func TestFragments(t *testing.T) {
+	filler := strings.Repeat("lorem ipsum dolor sit amet ", 10)
+	text := "We run Kubernetes.\n\n" + filler + "You will write an operator in Go. " + filler + "More operators later."
+	opts := DefaultOptions()
+	opts.FragmentSize = 60
+
+	fragments := NewMatcher("kubernetes operator", []string{"en"}).Fragments(text, "en", opts)
+
+	assert.Len(t, fragments, 3)
+	assert.True(t, strings.HasPrefix(fragments[0], "We run <mark>Kubernetes</mark>. lorem"), fragments[0])
+	assert.True(t, strings.HasSuffix(fragments[0], "…"))
+	assert.Contains(t, fragments[1], "write an <mark>operator</mark> in Go")
+	assert.True(t, strings.HasPrefix(fragments[1], "…") && strings.HasSuffix(fragments[1], "…"), fragments[1])
+	assert.True(t, strings.HasSuffix(fragments[2], "More <mark>operators</mark> later."), fragments[2])
+	for _, fragment := range fragments {
+		plain := strings.NewReplacer("<mark>", "", "</mark>", "", "…", "").Replace(fragment)
+		assert.LessOrEqual(t, len(plain), opts.FragmentSize, fragment)
+	}
+
+	// Nearby matches share one excerpt, MaxFragments caps the rest
+	opts.MaxFragments = 1
+	fragments = NewMatcher("kubernetes run", []string{"en"}).Fragments(text, "en", opts)
+	assert.Equal(t, []string{"We <mark>run</mark> <mark>Kubernetes</mark>. lorem ipsum dolor sit amet lorem ipsum…"}, fragments)
+
+	// Excerpts don't repeat text
+	fragments = NewMatcher("kubernetes operators", []string{"en"}).Fragments("We build on Kubernetes. You will write operators in Go.", "en", Options{FragmentSize: 30, MaxFragments: 3})
+	assert.Equal(t, []string{"…build on Kubernetes. You will…", "…write operators in Go."}, fragments)
+
+	assert.Nil(t, NewMatcher("java", []string{"en"}).Fragments(text, "en", opts))
+}
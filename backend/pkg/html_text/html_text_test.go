@@ -0,0 +1,117 @@
+package htmltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "drops scripts, styles and tracking pixels",
+			input:    `<style>p{color:red}</style><p style="color:red" onclick="x()">Hello <b>world</b></p><script>alert(1)</script><img src="https://t.example.com/px.gif" width="1">`,
+			expected: "<p>Hello <strong>world</strong></p>",
+		},
+		{
+			name:     "keeps safe links only",
+			input:    `<p><a href="https://acme.com/jobs" target="_blank">Apply</a> or <a href="javascript:alert(1)">not this</a></p>`,
+			expected: `<p><a href="https://acme.com/jobs" rel="nofollow noopener noreferrer">Apply</a> or not this</p>`,
+		},
+		{
+			name:     "unwraps unknown tags and turns inline divs into paragraphs",
+			input:    `<div class="x"><div>First</div><div><span>Second</span></div></div>`,
+			expected: "<p>First</p>\n<p>Second</p>",
+		},
+		{
+			name:     "downgrades top level headings",
+			input:    `<h1>About us</h1><ul><li>Go</li><li></li></ul>`,
+			expected: "<h3>About us</h3>\n<ul><li>Go</li></ul>",
+		},
+		{
+			name:     "plain text becomes paragraphs",
+			input:    "We build things.\n\nYou: love Go\n& coffee",
+			expected: "<p>We build things.</p>\n<p>You: love Go<br>&amp; coffee</p>",
+		},
+		{
+			name:     "empty",
+			input:    "  ",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Sanitize(tt.input))
+		})
+	}
+}
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "headings, emphasis and links",
+			input:    `<h2>Role</h2><p>Build <strong>fast</strong> APIs at <a href="https://acme.com">Acme</a>.</p>`,
+			expected: "### Role\n\nBuild **fast** APIs at [Acme](https://acme.com).",
+		},
+		{
+			name:     "nested lists",
+			input:    `<ul><li>Go<ul><li>gin</li></ul></li><li>SQL</li></ul><ol><li><p>First</p></li><li>Second</li></ol>`,
+			expected: "- Go\n  - gin\n- SQL\n\n1. First\n2. Second",
+		},
+		{
+			name:     "escapes markdown characters",
+			input:    `<p>Use *args and snake_case</p>`,
+			expected: `Use \*args and snake\_case`,
+		},
+		{
+			name:     "code blocks",
+			input:    "<pre><code>go test ./...</code></pre>",
+			expected: "```\ngo test ./...\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ToMarkdown(tt.input))
+		})
+	}
+}
+
+func TestToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "paragraphs and lists",
+			input:    `<p>About&nbsp;us</p><p>We   hire.</p><ul><li>Go</li><li>SQL</li></ul><script>x()</script>`,
+			expected: "About us\n\nWe hire.\n\n- Go\n- SQL",
+		},
+		{
+			name:     "line breaks",
+			input:    `Line one<br/>Line <em>two</em>`,
+			expected: "Line one\nLine two",
+		},
+		{
+			name:     "plain text is returned as is",
+			input:    "  Just text  ",
+			expected: "Just text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ToText(tt.input))
+		})
+	}
+}
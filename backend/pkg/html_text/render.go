@@ -0,0 +1,299 @@
+package htmltext
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	blankLines     = regexp.MustCompile(`\n{3,}`)
+	trailingSpaces = regexp.MustCompile(`[ \t]+\n`)
+	markdownEscape = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+)
+
+// ToMarkdown renders HTML as Markdown. It expects sanitized input but copes with any HTML,
+// tags without a Markdown equivalent are reduced to their text.
+func ToMarkdown(s string) string {
+	if !IsHTML(s) {
+		return strings.TrimSpace(s)
+	}
+	nodes, err := parseFragment(Sanitize(s))
+	if err != nil {
+		return ToText(s)
+	}
+	r := &renderer{markdown: true}
+	for _, n := range nodes {
+		r.node(n)
+	}
+	return r.String()
+}
+
+// ToText renders HTML as plain text, keeping paragraphs and list items on their own lines
+func ToText(s string) string {
+	if !IsHTML(s) {
+		return strings.TrimSpace(s)
+	}
+	nodes, err := parseFragment(s)
+	if err != nil {
+		return strings.TrimSpace(html.UnescapeString(s))
+	}
+	r := &renderer{}
+	for _, n := range nodes {
+		r.node(n)
+	}
+	return r.String()
+}
+
+// renderer walks an HTML tree and writes Markdown or plain text
+type renderer struct {
+	b        strings.Builder
+	markdown bool
+	lists    []listState // Open lists, innermost last
+	quote    int         // Blockquote depth
+	inPre    bool
+}
+
+type listState struct {
+	ordered bool
+	index   int
+}
+
+func (r *renderer) String() string {
+	out := trailingSpaces.ReplaceAllString(r.b.String(), "\n")
+	out = blankLines.ReplaceAllString(out, "\n\n")
+	return strings.TrimSpace(out)
+}
+
+func (r *renderer) write(s string) {
+	r.b.WriteString(s)
+}
+
+// newline starts a new line, prefixed with the blockquote markers in Markdown
+func (r *renderer) newline() {
+	r.write("\n")
+	if r.markdown && r.quote > 0 {
+		r.write(strings.Repeat("> ", r.quote))
+	}
+}
+
+func (r *renderer) block(n *html.Node) {
+	r.newline()
+	r.children(n)
+	r.newline()
+	r.newline()
+}
+
+func (r *renderer) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		r.node(c)
+	}
+}
+
+func (r *renderer) text(s string) {
+	if !r.inPre {
+		s = collapseSpace(s)
+		// Don't start lines with a space
+		if strings.HasSuffix(r.b.String(), "\n") || strings.HasSuffix(r.b.String(), "> ") || r.b.Len() == 0 {
+			s = strings.TrimLeft(s, " ")
+		}
+		if r.markdown {
+			s = markdownEscape.Replace(s)
+		}
+	}
+	r.write(s)
+}
+
+func (r *renderer) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		r.text(n.Data)
+		return
+	case html.DocumentNode:
+		r.children(n)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	if droppedTags[n.DataAtom] {
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		r.newline()
+	case atom.Hr:
+		r.newline()
+		if r.markdown {
+			r.write("---")
+		}
+		r.newline()
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Dl, atom.Dt, atom.Dd:
+		r.block(n)
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		r.newline()
+		if r.markdown {
+			level, _ := strconv.Atoi(n.Data[1:])
+			r.write(strings.Repeat("#", level) + " ")
+		}
+		r.children(n)
+		r.newline()
+		r.newline()
+	case atom.Strong, atom.B:
+		r.wrap(n, "**")
+	case atom.Em, atom.I:
+		r.wrap(n, "_")
+	case atom.S, atom.Del, atom.Strike:
+		r.wrap(n, "~~")
+	case atom.Code:
+		if r.inPre {
+			r.children(n)
+		} else if r.markdown {
+			r.write("`" + textContent(n) + "`")
+		} else {
+			r.children(n)
+		}
+	case atom.Pre:
+		r.newline()
+		if r.markdown {
+			r.write("```")
+			r.newline()
+		}
+		r.inPre = true
+		r.children(n)
+		r.inPre = false
+		if r.markdown {
+			r.newline()
+			r.write("```")
+		}
+		r.newline()
+		r.newline()
+	case atom.A:
+		href := attr(n, "href")
+		if r.markdown && href != "" {
+			r.write("[")
+			r.children(n)
+			r.write("](" + href + ")")
+		} else {
+			r.children(n)
+		}
+	case atom.Ul, atom.Ol:
+		r.lists = append(r.lists, listState{ordered: n.DataAtom == atom.Ol})
+		if len(r.lists) == 1 {
+			r.newline()
+		}
+		r.children(n)
+		r.lists = r.lists[:len(r.lists)-1]
+		if len(r.lists) == 0 {
+			r.newline()
+			r.newline()
+		}
+	case atom.Li:
+		r.listItem(n)
+	case atom.Blockquote:
+		r.quote++
+		r.block(n)
+		r.quote--
+		r.newline()
+	case atom.Table:
+		r.table(n)
+	default:
+		r.children(n)
+	}
+}
+
+func (r *renderer) wrap(n *html.Node, marker string) {
+	if !r.markdown {
+		r.children(n)
+		return
+	}
+	text := strings.TrimSpace(textContent(n))
+	if text == "" {
+		return
+	}
+	r.write(marker)
+	r.children(n)
+	r.write(marker)
+}
+
+func (r *renderer) listItem(n *html.Node) {
+	depth := len(r.lists)
+	marker := "- "
+	if depth > 0 {
+		list := &r.lists[depth-1]
+		list.index++
+		if list.ordered {
+			marker = strconv.Itoa(list.index) + ". "
+		}
+	} else {
+		depth = 1
+	}
+	r.newline()
+	r.write(strings.Repeat("  ", depth-1) + marker)
+
+	// Paragraphs inside list items would break the item apart
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == atom.P {
+			r.children(c)
+			continue
+		}
+		r.node(c)
+	}
+}
+
+func (r *renderer) table(n *html.Node) {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			if c.DataAtom == atom.Tr {
+				var cells []string
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+						cells = append(cells, strings.Join(strings.Fields(textContent(cell)), " "))
+					}
+				}
+				rows = append(rows, cells)
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(n)
+
+	r.newline()
+	for i, cells := range rows {
+		if r.markdown {
+			r.write("| " + strings.Join(cells, " | ") + " |")
+			if i == 0 {
+				r.newline()
+				r.write(strings.Repeat("| --- ", len(cells)) + "|")
+			}
+		} else {
+			r.write(strings.Join(cells, "\t"))
+		}
+		r.newline()
+	}
+	r.newline()
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && droppedTags[c.DataAtom] {
+			continue
+		}
+		b.WriteString(textContent(c))
+	}
+	return b.String()
+}
@@ -0,0 +1,223 @@
+// Package htmltext cleans up the HTML job sources deliver and renders it as Markdown or plain text
+package htmltext
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedTags are kept by Sanitize, every attribute except a[href] is dropped.
+// h1/h2 are downgraded to h3 so descriptions don't compete with the page title.
+var allowedTags = map[atom.Atom]atom.Atom{
+	atom.P: atom.P, atom.Br: atom.Br, atom.Hr: atom.Hr,
+	atom.Ul: atom.Ul, atom.Ol: atom.Ol, atom.Li: atom.Li,
+	atom.Strong: atom.Strong, atom.B: atom.Strong, atom.Em: atom.Em, atom.I: atom.Em,
+	atom.U: atom.U, atom.S: atom.S, atom.Del: atom.S, atom.Strike: atom.S,
+	atom.H1: atom.H3, atom.H2: atom.H3, atom.H3: atom.H3, atom.H4: atom.H4, atom.H5: atom.H5, atom.H6: atom.H6,
+	atom.A: atom.A, atom.Blockquote: atom.Blockquote, atom.Code: atom.Code, atom.Pre: atom.Pre,
+	atom.Table: atom.Table, atom.Thead: atom.Thead, atom.Tbody: atom.Tbody,
+	atom.Tr: atom.Tr, atom.Th: atom.Th, atom.Td: atom.Td,
+}
+
+// droppedTags are removed together with their content
+var droppedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Iframe: true, atom.Object: true, atom.Embed: true, atom.Svg: true, atom.Math: true,
+	atom.Img: true, atom.Picture: true, atom.Video: true, atom.Audio: true,
+	atom.Form: true, atom.Input: true, atom.Button: true, atom.Select: true, atom.Textarea: true,
+	atom.Head: true, atom.Title: true, atom.Meta: true, atom.Link: true,
+}
+
+// blockTags start a new block; containers holding none of them are rendered as paragraphs
+var blockTags = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Header: true, atom.Footer: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Table: true, atom.Pre: true, atom.Blockquote: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true, atom.Hr: true,
+	atom.Dl: true, atom.Dt: true, atom.Dd: true,
+}
+
+var voidTags = map[atom.Atom]bool{atom.Br: true, atom.Hr: true}
+
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// IsHTML reports whether s contains markup rather than plain text
+func IsHTML(s string) bool {
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return false
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			return true
+		}
+	}
+}
+
+// Sanitize returns raw reduced to the allowlisted tags. Scripts, styles, images (tracking
+// pixels), forms and embeds are removed with their content, other tags are unwrapped and
+// every attribute except safe link targets is dropped. Plain text is turned into paragraphs.
+func Sanitize(raw string) string {
+	if strings.TrimSpace(raw) == "" {
+		return ""
+	}
+	if !IsHTML(raw) {
+		return fromPlainText(raw)
+	}
+
+	nodes, err := parseFragment(raw)
+	if err != nil {
+		return fromPlainText(ToText(raw))
+	}
+	var b strings.Builder
+	for _, n := range nodes {
+		sanitizeNode(&b, n, false)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func parseFragment(s string) ([]*html.Node, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	return html.ParseFragment(strings.NewReader(s), body)
+}
+
+func sanitizeNode(b *strings.Builder, n *html.Node, inPre bool) {
+	switch n.Type {
+	case html.TextNode:
+		text := n.Data
+		if !inPre {
+			text = collapseSpace(text)
+		}
+		b.WriteString(html.EscapeString(text))
+		return
+	case html.ElementNode:
+	default:
+		// Documents, doctypes and comments only contribute their children
+		if n.Type == html.DocumentNode {
+			sanitizeChildren(b, n, inPre)
+		}
+		return
+	}
+
+	if droppedTags[n.DataAtom] {
+		return
+	}
+	tag, ok := allowedTags[n.DataAtom]
+	if !ok {
+		// Unknown containers with only inline content become paragraphs, the rest is unwrapped
+		if blockTags[n.DataAtom] && !hasBlockChild(n) {
+			tag = atom.P
+		} else {
+			sanitizeChildren(b, n, inPre)
+			if blockTags[n.DataAtom] {
+				b.WriteString("\n")
+			}
+			return
+		}
+	}
+
+	if voidTags[tag] {
+		b.WriteString("<" + tag.String() + ">")
+		if tag == atom.Hr {
+			b.WriteString("\n")
+		}
+		return
+	}
+
+	var inner strings.Builder
+	sanitizeChildren(&inner, n, inPre || tag == atom.Pre)
+	content := inner.String()
+	if strings.TrimSpace(content) == "" {
+		return
+	}
+
+	if tag == atom.A {
+		href, ok := safeHref(attr(n, "href"))
+		if !ok {
+			b.WriteString(content)
+			return
+		}
+		b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">` + content + "</a>")
+		return
+	}
+
+	if blockTags[tag] {
+		content = strings.TrimSpace(content)
+	}
+	b.WriteString("<" + tag.String() + ">" + content + "</" + tag.String() + ">")
+	if blockTags[tag] {
+		b.WriteString("\n")
+	}
+}
+
+func sanitizeChildren(b *strings.Builder, n *html.Node, inPre bool) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sanitizeNode(b, c, inPre)
+	}
+}
+
+func hasBlockChild(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && (blockTags[c.DataAtom] || hasBlockChild(c)) {
+			return true
+		}
+	}
+	return false
+}
+
+func safeHref(href string) (string, bool) {
+	href = strings.TrimSpace(href)
+	u, err := url.Parse(href)
+	if err != nil || !allowedSchemes[strings.ToLower(u.Scheme)] {
+		return "", false
+	}
+	return u.String(), true
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// fromPlainText turns blank-line separated text into paragraphs and line breaks into <br>
+func fromPlainText(text string) string {
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	var paragraphs []string
+	for _, block := range strings.Split(text, "\n\n") {
+		var lines []string
+		for _, line := range strings.Split(block, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, html.EscapeString(line))
+			}
+		}
+		if len(lines) > 0 {
+			paragraphs = append(paragraphs, "<p>"+strings.Join(lines, "<br>")+"</p>")
+		}
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+func collapseSpace(s string) string {
+	if s == "" {
+		return s
+	}
+	fields := strings.Fields(s)
+	out := strings.Join(fields, " ")
+	if isSpace(s[0]) && out != "" {
+		out = " " + out
+	}
+	if isSpace(s[len(s)-1]) {
+		out += " "
+	}
+	return out
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t' || c == '\r' || c == '\f'
+}
@@ -201,16 +201,7 @@ func (y *YCombinatorAggregator) RawJobtoDto(rawJob []any) (dtos.JobRequest, erro
 	}
 
 	// Extract location info
-	var city *string
-	countryIso := "US" // Default for YC jobs
-	if len(ycJob.LocationsRaw) > 0 {
-		if ycJob.LocationsRaw[0].Address.AddressLocality != "" {
-			city = &ycJob.LocationsRaw[0].Address.AddressLocality
-		}
-		if ycJob.LocationsRaw[0].Address.AddressCountry != "" {
-			countryIso = ycJob.LocationsRaw[0].Address.AddressCountry
-		}
-	}
+	locations := extractLocations(ycJob)
 
 	// Extract salary info
 	var salaryMin, salaryMax *int
@@ -231,9 +222,9 @@ func (y *YCombinatorAggregator) RawJobtoDto(rawJob []any) (dtos.JobRequest, erro
 	}
 
 	// Work mode
-	workMode := constant.WorkMode(1) // Default onsite
+	workMode := constant.WorkModeOnsite
 	if ycJob.RemoteDerived {
-		workMode = constant.WorkMode(2) // Remote
+		workMode = constant.WorkModeRemote
 	}
 
 	// Create slug from title and ID
@@ -245,15 +236,17 @@ func (y *YCombinatorAggregator) RawJobtoDto(rawJob []any) (dtos.JobRequest, erro
 		Title:           ycJob.Title,
 		Description:     nil, // Not provided in YC API
 		CompanyName:     ycJob.Organization,
-		CountryIso:      countryIso,
-		City:            city,
+		CompanyWebsite:  utils.String(ycJob.OrganizationURL),
+		CompanyLogoURL:  utils.String(ycJob.OrganizationLogo),
+		Locations:       locations,
+		LocationText:    ycJob.LocationsDerived,
 		JobType:         jobType,
 		SalaryMin:       salaryMin,
 		SalaryMax:       salaryMax,
 		SalaryCurrency:  salaryCurrency,
 		IsRemote:        &ycJob.RemoteDerived,
-		Skills:          []string{},   // Not provided in YC API
-		Category:        "Technology", // Default for YC jobs
+		Skills:          []string{}, // Not provided in YC API
+		Category:        "",         // Classified from the title and description at ingestion
 		PostedDate:      utils.ParseToRFC3339(ycJob.DatePosted),
 		ApplicationURL:  &ycJob.URL,
 		Source:          "Y Combinator",
@@ -266,3 +259,56 @@ func (y *YCombinatorAggregator) RawJobtoDto(rawJob []any) (dtos.JobRequest, erro
 		WorkMode:        workMode,
 	}, nil
 }
+
+// extractLocations maps every entry of locations_raw to a location request. The *_derived
+// arrays are only used when they line up one-to-one with locations_raw.
+func extractLocations(ycJob YCombinatorJobResponse) []dtos.LocationRequest {
+	workMode := constant.WorkModeOnsite
+	if ycJob.RemoteDerived {
+		workMode = constant.WorkModeRemote
+	}
+
+	var locations []dtos.LocationRequest
+	count := len(ycJob.LocationsRaw)
+	for i, raw := range ycJob.LocationsRaw {
+		location := dtos.LocationRequest{
+			CountryIso: raw.Address.AddressCountry,
+			City:       utils.String(raw.Address.AddressLocality),
+			Region:     utils.String(raw.Address.AddressRegion),
+			WorkMode:   workMode,
+		}
+		if location.CountryIso == "" {
+			location.CountryIso = "US" // Default for YC jobs
+		}
+		if location.City == nil && len(ycJob.CitiesDerived) == count {
+			location.City = utils.String(ycJob.CitiesDerived[i])
+		}
+		if location.Region == nil && len(ycJob.RegionsDerived) == count {
+			location.Region = utils.String(ycJob.RegionsDerived[i])
+		}
+		if len(ycJob.LatsDerived) == count && len(ycJob.LngsDerived) == count {
+			location.Latitude = &ycJob.LatsDerived[i]
+			location.Longitude = &ycJob.LngsDerived[i]
+		}
+		if len(ycJob.TimezonesDerived) == count {
+			location.Timezone = utils.String(ycJob.TimezonesDerived[i])
+		}
+		locations = append(locations, location)
+	}
+
+	// Postings without an address keep the countries they hire in. Onsite ones default to
+	// the US, remote ones are left open rather than restricted to it.
+	if len(locations) == 0 {
+		countries := ycJob.CountriesDerived
+		if len(countries) == 0 && !ycJob.RemoteDerived {
+			countries = []string{"US"} // Default for YC jobs
+		}
+		for _, country := range countries {
+			locations = append(locations, dtos.LocationRequest{
+				CountryIso: country,
+				WorkMode:   workMode,
+			})
+		}
+	}
+	return locations
+}
@@ -152,9 +152,12 @@ func TestYCombinatorAggregator_RawJobtoDto(t *testing.T) {
 		// Verify essential fields are populated
 		assert.Equal(t, "Robotics Software Engineer", jobRequest.Title)
 		assert.Equal(t, "Splash Inc.", jobRequest.CompanyName)
-		assert.Equal(t, "US", jobRequest.CountryIso)
-		assert.NotNil(t, jobRequest.City)
-		assert.Equal(t, "El Segundo", *jobRequest.City)
+		assert.Len(t, jobRequest.Locations, 1)
+		assert.Equal(t, "US", jobRequest.Locations[0].CountryIso)
+		assert.NotNil(t, jobRequest.Locations[0].City)
+		assert.Equal(t, "El Segundo", *jobRequest.Locations[0].City)
+		assert.NotNil(t, jobRequest.Locations[0].Region)
+		assert.Equal(t, "California", *jobRequest.Locations[0].Region)
 		assert.NotNil(t, jobRequest.ExternalJobID)
 		assert.Equal(t, "1871443718", *jobRequest.ExternalJobID)
 		assert.Equal(t, constant.JobType(1), jobRequest.JobType) // Full-time
@@ -189,10 +192,88 @@ func TestYCombinatorAggregator_RawJobtoDto(t *testing.T) {
 		// Assert
 		assert.NoError(t, err)
 		assert.Equal(t, "Frontend Developer", jobRequest.Title)
-		assert.Equal(t, constant.WorkMode(2), jobRequest.WorkMode) // Remote
+		assert.Equal(t, constant.WorkModeRemote, jobRequest.WorkMode)
 		assert.NotNil(t, jobRequest.IsRemote)
 		assert.True(t, *jobRequest.IsRemote)
 	})
+
+	t.Run("Keeps every location of a multi-location job", func(t *testing.T) {
+		// Arrange
+		rawJob := []any{map[string]any{
+			"id":           "42",
+			"title":        "Platform Engineer",
+			"organization": "Multi Office Inc",
+			"locations_raw": []map[string]any{
+				{"address": map[string]any{"addressLocality": "New York", "addressRegion": "New York", "addressCountry": "US"}},
+				{"address": map[string]any{"addressLocality": "London", "addressCountry": "GB"}},
+			},
+			"lats_derived":      []float64{40.71, 51.51},
+			"lngs_derived":      []float64{-74.01, -0.13},
+			"timezones_derived": []string{"America/New_York", "Europe/London"},
+		}}
+
+		// Act
+		jobRequest, err := aggregator.RawJobtoDto(rawJob)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, jobRequest.Locations, 2)
+		assert.Equal(t, "GB", jobRequest.Locations[1].CountryIso)
+		assert.Equal(t, "London", *jobRequest.Locations[1].City)
+		assert.Nil(t, jobRequest.Locations[1].Region)
+		assert.Equal(t, 51.51, *jobRequest.Locations[1].Latitude)
+		assert.Equal(t, "Europe/London", *jobRequest.Locations[1].Timezone)
+		assert.Equal(t, constant.WorkModeOnsite, jobRequest.Locations[1].WorkMode)
+	})
+
+	t.Run("Falls back to derived countries for remote jobs without an address", func(t *testing.T) {
+		// Arrange
+		rawJob := []any{map[string]any{
+			"id":                "43",
+			"title":             "Remote Engineer",
+			"organization":      "Distributed Co",
+			"remote_derived":    true,
+			"countries_derived": []string{"United States", "Canada"},
+		}}
+
+		// Act
+		jobRequest, err := aggregator.RawJobtoDto(rawJob)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, jobRequest.Locations, 2)
+		assert.Equal(t, "Canada", jobRequest.Locations[1].CountryIso)
+		assert.Equal(t, constant.WorkModeRemote, jobRequest.Locations[1].WorkMode)
+	})
+
+	t.Run("Falls back to derived countries, then US, for onsite jobs without an address", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			countries []string
+			want      string
+		}{
+			{"derived country", []string{"Canada"}, "Canada"},
+			{"no derived country", nil, "US"},
+		}
+		for _, tt := range tests {
+			// Arrange
+			rawJob := []any{map[string]any{
+				"id":                "44",
+				"title":             "Hardware Engineer",
+				"organization":      "Factory Co",
+				"countries_derived": tt.countries,
+			}}
+
+			// Act
+			jobRequest, err := aggregator.RawJobtoDto(rawJob)
+
+			// Assert
+			assert.NoError(t, err, tt.name)
+			assert.Len(t, jobRequest.Locations, 1, tt.name)
+			assert.Equal(t, tt.want, jobRequest.Locations[0].CountryIso, tt.name)
+			assert.Equal(t, constant.WorkModeOnsite, jobRequest.Locations[0].WorkMode, tt.name)
+		}
+	})
 }
 
 func TestYCombinatorAggregator_FetchJobs_WithMockServer(t *testing.T) {
@@ -0,0 +1,258 @@
+package language
+
+import (
+	"strings"
+)
+
+// accentFolder maps accented Latin letters to their base letter so "Développeur" and
+// "Developpeur" produce the same term. German umlauts fold the same way.
+var accentFolder = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "ą", "a",
+	"ç", "c", "ć", "c",
+	"è", "e", "é", "e", "ê", "e", "ë", "e", "ę", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i",
+	"ł", "l", "ñ", "n", "ń", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u",
+	"ś", "s", "ß", "ss", "ź", "z", "ż", "z", "œ", "oe", "æ", "ae",
+)
+
+// Terms turns text into search terms for the given language: lower-cased words without
+// stop words, accent-folded and stemmed. An empty or unsupported language only folds.
+func Terms(text, lang string) []string {
+	var terms []string
+	for _, w := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+		if term := Term(w, lang); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// Term is the search term of a single word as Terms produces it, "" for a stop word
+func Term(word, lang string) string {
+	w := strings.ToLower(word)
+	if stopwords[lang][w] {
+		return ""
+	}
+	return Stem(accentFolder.Replace(w), lang)
+}
+
+// WordSpans returns the start and end byte offsets of the words Terms splits text into
+func WordSpans(text string) [][]int {
+	return wordPattern.FindAllStringIndex(text, -1)
+}
+
+// Words splits text into lower-case, accent-folded words, keeping stop words unstemmed
+func Words(text string) []string {
+	return wordPattern.FindAllString(accentFolder.Replace(strings.ToLower(text)), -1)
+}
+
+// UniqueTerms is Terms without duplicates, in order of first occurrence
+func UniqueTerms(text, lang string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range Terms(text, lang) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// QueryVariants returns, for every word of a search query, the distinct terms it can
+// stem to across the given languages (all supported ones when langs is empty). A job
+// matches the query when, for every word, its terms contain one of the variants.
+func QueryVariants(query string, langs []string) [][]string {
+	if len(langs) == 0 {
+		langs = Supported
+	}
+	var variants [][]string
+	for _, w := range wordPattern.FindAllString(strings.ToLower(query), -1) {
+		// Stop words aren't indexed, so requiring them would only lose matches
+		if isStopword(w, langs) {
+			continue
+		}
+		folded := accentFolder.Replace(w)
+		seen := make(map[string]bool)
+		var forms []string
+		for _, lang := range langs {
+			if term := Stem(folded, lang); term != "" && !seen[term] {
+				seen[term] = true
+				forms = append(forms, term)
+			}
+		}
+		if len(forms) > 0 {
+			variants = append(variants, forms)
+		}
+	}
+	return variants
+}
+
+func isStopword(w string, langs []string) bool {
+	for _, lang := range langs {
+		if stopwords[lang][w] {
+			return true
+		}
+	}
+	return false
+}
+
+// Stem reduces an accent-folded, lower-case word to its stem with a light, rule-based
+// stemmer for the language. Words with digits or symbols (c++, s3) are left alone.
+func Stem(word, lang string) string {
+	for _, r := range word {
+		if (r < 'a' || r > 'z') && r < 0x80 {
+			return word
+		}
+	}
+	switch lang {
+	case English:
+		return stemEnglish(word)
+	case German:
+		return stemGerman(word)
+	case French:
+		return stemFrench(word)
+	case Spanish, Portuguese:
+		return stemIberian(word)
+	case Italian:
+		return stemItalian(word)
+	case Dutch:
+		return stemDutch(word)
+	case Swedish:
+		return stemSwedish(word)
+	}
+	return word
+}
+
+func stemEnglish(w string) string {
+	if len(w) <= 3 {
+		return w
+	}
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		w = w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "sses"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is"):
+		w = w[:len(w)-1]
+	}
+	for _, suffix := range []string{"ing", "ed"} {
+		if strings.HasSuffix(w, suffix) && len(w)-len(suffix) >= 3 && hasVowel(w[:len(w)-len(suffix)]) {
+			w = undouble(w[:len(w)-len(suffix)])
+			break
+		}
+	}
+	return w
+}
+
+// stemGerman follows the light stemmer of Savoy, with the feminine forms
+// "-in"/"-innen" folded into the masculine so "Entwicklerin" matches "Entwickler"
+func stemGerman(w string) string {
+	switch {
+	case len(w) > 8 && strings.HasSuffix(w, "innen"):
+		w = w[:len(w)-5]
+	case len(w) > 6 && strings.HasSuffix(w, "erin"):
+		w = w[:len(w)-2]
+	}
+
+	// Step 1
+	switch {
+	case len(w) > 5 && strings.HasSuffix(w, "ern"):
+		w = w[:len(w)-3]
+	case len(w) > 4 && hasAnySuffix(w, "em", "en", "er", "es"):
+		w = w[:len(w)-2]
+	case len(w) > 3 && strings.HasSuffix(w, "e"):
+		w = w[:len(w)-1]
+	case len(w) > 3 && strings.HasSuffix(w, "s") && strings.ContainsRune("bdfghklmnt", rune(w[len(w)-2])):
+		w = w[:len(w)-1]
+	}
+
+	// Step 2
+	switch {
+	case len(w) > 5 && strings.HasSuffix(w, "est"):
+		w = w[:len(w)-3]
+	case len(w) > 4 && hasAnySuffix(w, "er", "en"):
+		w = w[:len(w)-2]
+	case len(w) > 4 && strings.HasSuffix(w, "st") && strings.ContainsRune("bdfghklmnt", rune(w[len(w)-3])):
+		w = w[:len(w)-2]
+	}
+	return w
+}
+
+func stemFrench(w string) string {
+	if len(w) > 4 && hasAnySuffix(w, "s", "x") {
+		w = w[:len(w)-1]
+	}
+	for _, suffix := range []string{"ement", "ment", "euse", "eur", "rice", "ive", "if", "ee", "e"} {
+		if strings.HasSuffix(w, suffix) && len(w)-len(suffix) >= 4 {
+			return w[:len(w)-len(suffix)]
+		}
+	}
+	return w
+}
+
+// stemIberian handles Spanish and Portuguese plurals and gendered endings
+func stemIberian(w string) string {
+	switch {
+	case len(w) > 5 && strings.HasSuffix(w, "es"):
+		w = w[:len(w)-2]
+	case len(w) > 4 && strings.HasSuffix(w, "s"):
+		w = w[:len(w)-1]
+	}
+	if len(w) > 4 && hasAnySuffix(w, "a", "o", "e") {
+		w = w[:len(w)-1]
+	}
+	return w
+}
+
+func stemItalian(w string) string {
+	if len(w) > 4 && hasAnySuffix(w, "a", "e", "i", "o") {
+		w = w[:len(w)-1]
+	}
+	return w
+}
+
+func stemDutch(w string) string {
+	switch {
+	case len(w) > 5 && strings.HasSuffix(w, "en"):
+		w = w[:len(w)-2]
+	case len(w) > 4 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		w = w[:len(w)-1]
+	case len(w) > 4 && strings.HasSuffix(w, "e"):
+		w = w[:len(w)-1]
+	}
+	return undouble(w)
+}
+
+func stemSwedish(w string) string {
+	for _, suffix := range []string{"arna", "erna", "orna", "ande", "ende", "are", "ar", "er", "or", "en", "et", "a", "e"} {
+		if strings.HasSuffix(w, suffix) && len(w)-len(suffix) >= 3 {
+			return w[:len(w)-len(suffix)]
+		}
+	}
+	return w
+}
+
+func hasAnySuffix(w string, suffixes ...string) bool {
+	for _, s := range suffixes {
+		if strings.HasSuffix(w, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVowel(w string) bool {
+	return strings.ContainsAny(w, "aeiouy")
+}
+
+// undouble turns a trailing double consonant into a single one ("runn" -> "run")
+func undouble(w string) string {
+	n := len(w)
+	if n >= 3 && w[n-1] == w[n-2] && !strings.ContainsRune("aeiouls", rune(w[n-1])) {
+		return w[:n-1]
+	}
+	return w
+}
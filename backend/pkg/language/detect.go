@@ -0,0 +1,105 @@
+// Package language detects the language of job postings and turns text into
+// language-aware search terms (lower-cased, accent-folded, stop words removed, stemmed)
+package language
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ISO 639-1 codes of the supported languages
+const (
+	English    = "en"
+	German     = "de"
+	French     = "fr"
+	Spanish    = "es"
+	Italian    = "it"
+	Dutch      = "nl"
+	Portuguese = "pt"
+	Swedish    = "sv"
+	Polish     = "pl"
+)
+
+// Supported lists every language Detect can return, in tie-break order
+var Supported = []string{English, German, French, Spanish, Italian, Dutch, Portuguese, Swedish, Polish}
+
+// stopwordLists are the most frequent function words of each language. They drive
+// detection and are dropped from search terms.
+var stopwordLists = map[string]string{
+	English:    "the and to of a in for is you are we with on as our be will this that your or an at by from have it can all who about not us has more",
+	German:     "der die das und in zu den von mit sich des auf für ist im dem nicht ein eine als auch es an werden aus er hat dass sie nach wird bei einer um am sind noch wie einem über einen so zum war haben nur oder aber vor zur bis mehr durch man sein wir du ihr ihre dich deine unser unsere uns kannst bist",
+	French:     "le la les de des du et en un une est pour que qui dans sur au aux avec par pas plus nous vous votre vos notre nos ce cette sont être ou son sa ses il elle",
+	Spanish:    "el la los las de del y en un una es para que por con no se al lo como más su sus nuestro nuestra tu tus somos eres buscamos estar ser o",
+	Italian:    "il lo la i gli le di del della dei delle e in un una è per che con non si al alla come più suo sua nostro nostra tuo tua siamo sei cerchiamo essere o",
+	Dutch:      "de het een en van in is op te dat die voor met zijn niet aan er om ook als bij of wij we je jij jouw onze ons naar wordt heb hebt",
+	Portuguese: "o a os as de do da dos das e em um uma é para que com não se ao no na nos nas como mais seu sua nosso nossa você somos ser ou",
+	Swedish:    "och i att det som en på är av för med till den har de inte om ett vi du kan eller vår våra dig din ditt sig ska",
+	Polish:     "i w na z do się nie że jest to jak o od po dla przez oraz lub czy są być który która które jesteś nasz nasza twoje",
+}
+
+var stopwords = func() map[string]map[string]bool {
+	sets := make(map[string]map[string]bool, len(stopwordLists))
+	for lang, list := range stopwordLists {
+		set := make(map[string]bool)
+		for _, w := range strings.Fields(list) {
+			set[w] = true
+		}
+		sets[lang] = set
+	}
+	return sets
+}()
+
+// letterHints are characters that (almost) only occur in one of the supported languages
+var letterHints = map[string]string{
+	German:     "ßäöü",
+	French:     "çèêëîœ",
+	Spanish:    "ñ¿¡",
+	Portuguese: "ãõ",
+	Swedish:    "å",
+	Polish:     "ąćęłńśźż",
+}
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{N}+#]*`)
+
+// minDetectionHits is the number of stop words needed before Detect commits to a language
+const minDetectionHits = 3
+
+// Detect returns the ISO 639-1 code of the dominant language of text, or "" when the
+// text is too short or doesn't look like any supported language
+func Detect(text string) string {
+	scores := make(map[string]float64)
+	for _, w := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+		for lang, set := range stopwords {
+			if set[w] {
+				scores[lang]++
+			}
+		}
+	}
+	lower := strings.ToLower(text)
+	for lang, letters := range letterHints {
+		for _, r := range letters {
+			scores[lang] += 0.5 * float64(strings.Count(lower, string(r)))
+		}
+	}
+
+	best, bestScore := "", 0.0
+	for _, lang := range Supported {
+		if scores[lang] > bestScore {
+			best, bestScore = lang, scores[lang]
+		}
+	}
+	if bestScore < minDetectionHits {
+		return ""
+	}
+	return best
+}
+
+// IsSupported reports whether lang is one of the supported ISO 639-1 codes
+func IsSupported(lang string) bool {
+	for _, l := range Supported {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
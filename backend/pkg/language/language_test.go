@@ -0,0 +1,117 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "english",
+			text:     "We are looking for a Backend Engineer to join our team and build the payment APIs with Go.",
+			expected: English,
+		},
+		{
+			name:     "german",
+			text:     "Wir suchen eine Softwareentwicklerin (m/w/d) für unser Team in Berlin. Du hast Erfahrung mit Go und bist neugierig.",
+			expected: German,
+		},
+		{
+			name:     "french",
+			text:     "Nous recherchons un développeur backend pour rejoindre notre équipe. Vous travaillerez avec des technologies modernes.",
+			expected: French,
+		},
+		{
+			name:     "spanish",
+			text:     "Buscamos un desarrollador para nuestro equipo de producto. Es un puesto remoto con horario flexible para la empresa.",
+			expected: Spanish,
+		},
+		{
+			name:     "dutch",
+			text:     "Wij zoeken een ontwikkelaar voor ons team in Amsterdam. Je werkt aan de backend van het platform en bent niet bang om te leren.",
+			expected: Dutch,
+		},
+		{
+			name:     "too short to tell",
+			text:     "Senior Go Engineer",
+			expected: "",
+		},
+		{
+			name:     "empty",
+			text:     "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Detect(tt.text))
+		})
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		lang  string
+		words []string // All words must stem to the same term
+	}{
+		{English, []string{"engineer", "engineers"}},
+		{English, []string{"develop", "developing", "developed"}},
+		{English, []string{"company", "companies"}},
+		{German, []string{"entwickler", "entwicklerin", "entwicklerinnen", "entwicklern"}},
+		{German, []string{"erfahrung", "erfahrungen"}},
+		{German, []string{"kunde", "kunden"}},
+		{French, []string{"developpeur", "developpeurs", "developpeuse"}},
+		{Spanish, []string{"desarrollador", "desarrolladora", "desarrolladores"}},
+		{Italian, []string{"sviluppatore", "sviluppatori"}},
+		{Dutch, []string{"ontwikkelaar", "ontwikkelaars"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang+"/"+tt.words[0], func(t *testing.T) {
+			want := Stem(tt.words[0], tt.lang)
+			for _, w := range tt.words[1:] {
+				assert.Equal(t, want, Stem(w, tt.lang), w)
+			}
+		})
+	}
+
+	t.Run("symbols are left alone", func(t *testing.T) {
+		assert.Equal(t, "c++", Stem("c++", English))
+		assert.Equal(t, "s3", Stem("s3", German))
+	})
+}
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"softwareentwickl", "munch"}, Terms("Softwareentwicklerin für München", German))
+	assert.Equal(t, []string{"senior", "engineer", "go"}, Terms("The Senior Engineers for Go", English))
+	assert.Equal(t, []string{"dev", "dev"}, Terms("dev dev", ""))
+	assert.Equal(t, []string{"dev"}, UniqueTerms("dev dev", ""))
+	assert.Equal(t, "engineer", Term("Engineers", English))
+	assert.Equal(t, "", Term("The", English))
+	assert.Equal(t, [][]int{{0, 3}, {5, 13}}, WordSpans("C++, München!"))
+	assert.Equal(t, []string{"the", "front", "end", "munchen"}, Words("The Front-End, München"))
+}
+
+func TestQueryVariants(t *testing.T) {
+	t.Run("stems with the filtered language", func(t *testing.T) {
+		assert.Equal(t, [][]string{{"entwickl"}}, QueryVariants("Entwicklerin", []string{German}))
+	})
+
+	t.Run("stems with every language without a filter", func(t *testing.T) {
+		variants := QueryVariants("Entwicklerin", nil)
+		assert.Len(t, variants, 1)
+		assert.Contains(t, variants[0], "entwickl")
+		assert.Contains(t, variants[0], "entwicklerin")
+	})
+
+	t.Run("drops stop words", func(t *testing.T) {
+		assert.Equal(t, [][]string{{"engineer"}}, QueryVariants("the engineers", []string{English}))
+	})
+}
@@ -0,0 +1,144 @@
+// Package notify delivers plain text messages to people, by mail through an SMTP server
+// or, for development and tests, by writing them to a log or file.
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidMessage is returned for messages that can't be delivered as they are
+var ErrInvalidMessage = errors.New("invalid message")
+
+// Message is a plain text message to one recipient
+type Message struct {
+	To      string // Email address
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages
+type Notifier interface {
+	Notify(message Message) error
+}
+
+// validate rejects messages without a recipient and headers that would smuggle in others
+func (m Message) validate() error {
+	if _, err := mail.ParseAddress(m.To); err != nil {
+		return fmt.Errorf("%w: recipient %q: %v", ErrInvalidMessage, m.To, err)
+	}
+	if strings.ContainsAny(m.To+m.Subject, "\r\n") {
+		return fmt.Errorf("%w: line break in a header", ErrInvalidMessage)
+	}
+	return nil
+}
+
+// SMTPConfig is where and as whom an SMTPNotifier sends mail
+type SMTPConfig struct {
+	Addr     string // host:port
+	From     string // Sender address
+	Username string // Empty for servers without authentication
+	Password string
+}
+
+// SMTPNotifier mails messages through an SMTP server. Connections are upgraded with
+// STARTTLS when the server offers it, and credentials are only sent over TLS or to
+// localhost.
+type SMTPNotifier struct {
+	config SMTPConfig
+	now    func() time.Time
+}
+
+// NewSMTPNotifier creates a notifier mailing through the server of config
+func NewSMTPNotifier(config SMTPConfig) (*SMTPNotifier, error) {
+	if _, _, err := net.SplitHostPort(config.Addr); err != nil {
+		return nil, fmt.Errorf("invalid smtp address %q: %w", config.Addr, err)
+	}
+	if _, err := mail.ParseAddress(config.From); err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", config.From, err)
+	}
+	return &SMTPNotifier{config: config, now: time.Now}, nil
+}
+
+func (n *SMTPNotifier) Notify(message Message) error {
+	if err := message.validate(); err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		host, _, _ := net.SplitHostPort(n.config.Addr)
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, host)
+	}
+	from, _ := mail.ParseAddress(n.config.From)
+	to, _ := mail.ParseAddress(message.To)
+	if err := smtp.SendMail(n.config.Addr, auth, from.Address, []string{to.Address}, n.format(message)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
+
+// format builds the RFC 5322 message, with CRLF line endings
+func (n *SMTPNotifier) format(message Message) []byte {
+	var b bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, value)
+	}
+	header("From", n.config.From)
+	header("To", message.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", message.Subject))
+	header("Date", n.now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/plain; charset="utf-8"`)
+	header("Content-Transfer-Encoding", "8bit")
+	b.WriteString("\r\n")
+
+	body := strings.ReplaceAll(message.Body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	if !strings.HasSuffix(body, "\n") {
+		b.WriteString("\r\n")
+	}
+	return b.Bytes()
+}
+
+// WriterNotifier writes messages to a writer, one after the other, separated by a line
+// of dashes
+type WriterNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterNotifier creates a notifier writing messages to w, e.g. os.Stdout
+func NewWriterNotifier(w io.Writer) *WriterNotifier {
+	return &WriterNotifier{w: w}
+}
+
+// NewFileNotifier creates a notifier appending messages to the file at path, created
+// when missing. Close the file when done.
+func NewFileNotifier(path string) (*WriterNotifier, *os.File, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return NewWriterNotifier(file), file, nil
+}
+
+func (n *WriterNotifier) Notify(message Message) error {
+	if err := message.validate(); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, err := fmt.Fprintf(n.w, "To: %s\nSubject: %s\n\n%s\n%s\n",
+		message.To, message.Subject, strings.TrimRight(message.Body, "\n"), strings.Repeat("-", 72))
+	return err
+}
@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/textproto"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts mail on a local port and records it, without extensions
+type fakeSMTPServer struct {
+	listener net.Listener
+	mails    chan fakeMail
+}
+
+type fakeMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTPServer{listener: listener, mails: make(chan fakeMail, 10)}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	var mail fakeMail
+	text.PrintfLine("220 localhost fake SMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO", "HELO":
+			text.PrintfLine("250 localhost")
+		case "MAIL":
+			mail = fakeMail{From: strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")}
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			mail.To = append(mail.To, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			mail.Data = string(data)
+			s.mails <- mail
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	notifier, err := NewSMTPNotifier(SMTPConfig{Addr: server.Addr(), From: "Workova <alerts@workova.dev>"})
+	require.NoError(t, err)
+	notifier.now = func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	err = notifier.Notify(Message{
+		To:      "Ada <ada@example.com>",
+		Subject: "2 new jobs for “Go”",
+		Body:    "- Go Developer\n.\n- Go Engineer",
+	})
+	require.NoError(t, err)
+
+	select {
+	case mail := <-server.mails:
+		assert.Equal(t, "alerts@workova.dev", mail.From)
+		assert.Equal(t, []string{"ada@example.com"}, mail.To)
+		message, err := textproto.NewReader(bufio.NewReader(strings.NewReader(mail.Data))).ReadMIMEHeader()
+		require.NoError(t, err)
+		assert.Equal(t, "Ada <ada@example.com>", message.Get("To"))
+		assert.Equal(t, "=?utf-8?q?2_new_jobs_for_=E2=80=9CGo=E2=80=9D?=", message.Get("Subject"))
+		assert.Equal(t, "Sat, 01 Mar 2025 12:00:00 +0000", message.Get("Date"))
+		assert.True(t, strings.HasSuffix(mail.Data, "\n\n- Go Developer\n.\n- Go Engineer\n"), mail.Data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no mail received")
+	}
+}
+
+func TestSMTPNotifier_Errors(t *testing.T) {
+	_, err := NewSMTPNotifier(SMTPConfig{Addr: "localhost", From: "alerts@workova.dev"})
+	assert.Error(t, err)
+	_, err = NewSMTPNotifier(SMTPConfig{Addr: "localhost:25", From: "workova"})
+	assert.Error(t, err)
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{Addr: newFakeSMTPServer(t).Addr(), From: "alerts@workova.dev"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, notifier.Notify(Message{To: "not an address", Subject: "Hi"}), ErrInvalidMessage)
+	assert.ErrorIs(t, notifier.Notify(Message{To: "ada@example.com", Subject: "Hi\r\nBcc: eve@example.com"}), ErrInvalidMessage)
+
+	// Nothing listens on the port of a closed listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	listener.Close()
+	notifier, err = NewSMTPNotifier(SMTPConfig{Addr: listener.Addr().String(), From: "alerts@workova.dev"})
+	require.NoError(t, err)
+	assert.ErrorContains(t, notifier.Notify(Message{To: "ada@example.com", Subject: "Hi"}), "failed to send mail")
+}
+
+func TestWriterNotifier(t *testing.T) {
+	var b bytes.Buffer
+	notifier := NewWriterNotifier(&b)
+
+	require.NoError(t, notifier.Notify(Message{To: "ada@example.com", Subject: "1 new job", Body: "- Go Developer\n"}))
+	require.NoError(t, notifier.Notify(Message{To: "bob@example.com", Subject: "2 new jobs", Body: "- Go Engineer"}))
+	assert.ErrorIs(t, notifier.Notify(Message{To: "", Subject: "0 new jobs"}), ErrInvalidMessage)
+
+	separator := strings.Repeat("-", 72)
+	assert.Equal(t, "To: ada@example.com\nSubject: 1 new job\n\n- Go Developer\n"+separator+"\n"+
+		"To: bob@example.com\nSubject: 2 new jobs\n\n- Go Engineer\n"+separator+"\n", b.String())
+}
+
+func TestFileNotifier(t *testing.T) {
+	path := t.TempDir() + "/alerts.log"
+	for _, to := range []string{"ada@example.com", "bob@example.com"} {
+		notifier, file, err := NewFileNotifier(path)
+		require.NoError(t, err)
+		require.NoError(t, notifier.Notify(Message{To: to, Subject: "1 new job"}))
+		require.NoError(t, file.Close())
+	}
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), "Subject: 1 new job"))
+	assert.Contains(t, string(content), "To: bob@example.com")
+
+	_, _, err = NewFileNotifier(t.TempDir() + "/missing/alerts.log")
+	assert.Error(t, err)
+}
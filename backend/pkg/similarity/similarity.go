@@ -0,0 +1,100 @@
+// Package similarity compares job postings: sparse TF-IDF vectors over their search terms
+// and set overlap for skills, categories and locations.
+package similarity
+
+import (
+	"math"
+	"sort"
+)
+
+// VectorSize is the number of terms kept per vector. The highest weighted terms carry most
+// of a posting's cosine similarity, and short vectors keep the term index small.
+const VectorSize = 32
+
+// Vector maps terms to weights, normalized to unit length
+type Vector map[string]float64
+
+// TermFrequencies counts every term
+func TermFrequencies(terms []string) map[string]int {
+	frequencies := make(map[string]int, len(terms))
+	for _, term := range terms {
+		frequencies[term]++
+	}
+	return frequencies
+}
+
+// IDF is the smoothed inverse document frequency of a term found in documentFrequency of
+// documents postings
+func IDF(documentFrequency, documents int64) float64 {
+	return math.Log(float64(1+documents)/float64(1+documentFrequency)) + 1
+}
+
+// NewVector weighs terms by sublinear term frequency times IDF, keeps the size highest
+// weighted and normalizes them. Terms missing from documentFrequencies count as unseen.
+func NewVector(frequencies map[string]int, documentFrequencies map[string]int64, documents int64, size int) Vector {
+	type weighted struct {
+		term   string
+		weight float64
+	}
+	weights := make([]weighted, 0, len(frequencies))
+	for term, frequency := range frequencies {
+		weight := (1 + math.Log(float64(frequency))) * IDF(documentFrequencies[term], documents)
+		weights = append(weights, weighted{term, weight})
+	}
+	sort.Slice(weights, func(i, j int) bool {
+		if weights[i].weight != weights[j].weight {
+			return weights[i].weight > weights[j].weight
+		}
+		return weights[i].term < weights[j].term
+	})
+	if len(weights) > size {
+		weights = weights[:size]
+	}
+
+	var norm float64
+	for _, w := range weights {
+		norm += w.weight * w.weight
+	}
+	vector := make(Vector, len(weights))
+	for _, w := range weights {
+		vector[w.term] = w.weight / math.Sqrt(norm)
+	}
+	return vector
+}
+
+// Cosine returns the cosine similarity of two normalized vectors
+func Cosine(a, b Vector) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, weight := range a {
+		dot += weight * b[term]
+	}
+	return dot
+}
+
+// Jaccard returns the share of distinct values found in both a and b, 0 when both are empty
+func Jaccard[T comparable](a, b []T) float64 {
+	set := make(map[T]bool, len(a))
+	for _, value := range a {
+		set[value] = true
+	}
+	union, shared := len(set), 0
+	seen := make(map[T]bool, len(b))
+	for _, value := range b {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		if set[value] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
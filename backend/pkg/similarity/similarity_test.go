@@ -0,0 +1,47 @@
+package similarity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVector(t *testing.T) {
+	documentFrequencies := map[string]int64{"engin": 90, "go": 10, "kubernet": 5}
+	vector := NewVector(TermFrequencies([]string{"go", "go", "engin", "kubernet", "payment"}), documentFrequencies, 100, 3)
+
+	assert.Len(t, vector, 3)
+	assert.NotContains(t, vector, "engin", "common terms are dropped first")
+	assert.Greater(t, vector["payment"], vector["kubernet"], "unseen terms weigh most")
+	assert.Greater(t, vector["go"], vector["kubernet"], "repeated terms weigh more")
+
+	var norm float64
+	for _, weight := range vector {
+		norm += weight * weight
+	}
+	assert.InDelta(t, 1, norm, 1e-9)
+	assert.Empty(t, NewVector(nil, nil, 0, VectorSize))
+}
+
+func TestCosine(t *testing.T) {
+	documentFrequencies := map[string]int64{"go": 10, "backend": 20, "engin": 50, "design": 10, "figma": 3}
+	vectorOf := func(terms ...string) Vector {
+		return NewVector(TermFrequencies(terms), documentFrequencies, 100, VectorSize)
+	}
+	goBackend := vectorOf("go", "backend", "engin")
+
+	assert.InDelta(t, 1, Cosine(goBackend, goBackend), 1e-9)
+	assert.Equal(t, 0.0, Cosine(goBackend, vectorOf("design", "figma")))
+	partial := Cosine(goBackend, vectorOf("go", "engin", "design"))
+	assert.True(t, partial > 0 && partial < 1)
+	assert.Equal(t, partial, Cosine(vectorOf("go", "engin", "design"), goBackend))
+	assert.False(t, math.IsNaN(Cosine(Vector{}, goBackend)))
+}
+
+func TestJaccard(t *testing.T) {
+	assert.Equal(t, 0.5, Jaccard([]string{"go", "sql"}, []string{"go", "sql", "docker", "go", "k8s"}))
+	assert.Equal(t, 1.0, Jaccard([]uint{1, 2}, []uint{2, 1, 1}))
+	assert.Equal(t, 0.0, Jaccard([]string{}, []string{}))
+	assert.Equal(t, 0.0, Jaccard([]string{"go"}, nil))
+}
@@ -0,0 +1,162 @@
+// Package spell corrects misspelled search words against a vocabulary of indexed words.
+// A word is replaced by the closest known word, by Damerau-Levenshtein distance so swapped
+// letters ("pyhton") count as one edit, and among equally close words by the most frequent.
+package spell
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bhati00/workova/backend/pkg/language"
+)
+
+const (
+	// MinWordLength is the length in letters below which words are never corrected, short
+	// words are too close to too many others
+	MinWordLength = 3
+	// shortWordLength is the longest word corrected with a single edit, longer ones take two
+	shortWordLength = 4
+)
+
+// Vocabulary is a set of known words with their frequency
+type Vocabulary struct {
+	counts   map[string]int64
+	byLength map[int][]string
+}
+
+// NewVocabulary returns an empty vocabulary
+func NewVocabulary() *Vocabulary {
+	return &Vocabulary{counts: make(map[string]int64), byLength: make(map[int][]string)}
+}
+
+// Add adds the words of text, each counting weight more occurrences
+func (v *Vocabulary) Add(text string, weight int64) {
+	for _, word := range language.Words(text) {
+		if _, ok := v.counts[word]; !ok {
+			length := utf8.RuneCountInString(word)
+			v.byLength[length] = append(v.byLength[length], word)
+		}
+		v.counts[word] += weight
+	}
+}
+
+// Len returns the number of known words
+func (v *Vocabulary) Len() int {
+	return len(v.counts)
+}
+
+// Known reports whether word, lower-cased and accent-folded, is in the vocabulary
+func (v *Vocabulary) Known(word string) bool {
+	_, ok := v.counts[word]
+	return ok
+}
+
+// Correct returns the known word closest to word, or "" when word is known, too short,
+// has digits or symbols, or nothing known is within reach
+func (v *Vocabulary) Correct(word string) string {
+	length := utf8.RuneCountInString(word)
+	if length < MinWordLength || v.Known(word) || !isLetters(word) {
+		return ""
+	}
+	maxDistance := 2
+	if length <= shortWordLength {
+		maxDistance = 1
+	}
+
+	source := []rune(word)
+	best, bestDistance, bestCount := "", maxDistance+1, int64(0)
+	for l := length - maxDistance; l <= length+maxDistance; l++ {
+		for _, candidate := range v.byLength[l] {
+			d := distance(source, []rune(candidate), maxDistance)
+			if d > maxDistance {
+				continue
+			}
+			count := v.counts[candidate]
+			if d < bestDistance || d == bestDistance && (count > bestCount || count == bestCount && candidate < best) {
+				best, bestDistance, bestCount = candidate, d, count
+			}
+		}
+	}
+	return best
+}
+
+// CorrectQuery returns query with its unknown words corrected, or "" when no word is.
+// Stop words and words for which keep returns true are left alone. Corrected words are
+// lower-case, the rest of the query is kept as is.
+func (v *Vocabulary) CorrectQuery(query string, keep func(word string) bool) string {
+	var b strings.Builder
+	corrected := false
+	pos := 0
+	for _, span := range language.WordSpans(query) {
+		original := query[span[0]:span[1]]
+		word := strings.Join(language.Words(original), "")
+		if (keep != nil && keep(word)) || len(language.QueryVariants(word, nil)) == 0 {
+			continue
+		}
+		if correction := v.Correct(word); correction != "" {
+			b.WriteString(query[pos:span[0]])
+			b.WriteString(correction)
+			pos = span[1]
+			corrected = true
+		}
+	}
+	if !corrected {
+		return ""
+	}
+	b.WriteString(query[pos:])
+	return b.String()
+}
+
+func isLetters(word string) bool {
+	for _, r := range word {
+		if r < 'a' || r > 'z' {
+			if r < utf8.RuneSelf {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// distance is the optimal string alignment distance of a and b: insertions, deletions,
+// substitutions and transpositions of adjacent letters. It returns limit+1 as soon as the
+// distance is known to exceed limit.
+func distance(a, b []rune, limit int) int {
+	if abs(len(a)-len(b)) > limit {
+		return limit + 1
+	}
+	// Three rows of the dynamic programming matrix: two rows back, previous and current
+	prevPrev := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,70 @@
+package spell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testVocabulary() *Vocabulary {
+	v := NewVocabulary()
+	v.Add("Kubernetes Engineer", 40)
+	v.Add("Python", 120)
+	v.Add("Senior Python Developer", 30)
+	v.Add("Pytorch", 5)
+	v.Add("Java", 80)
+	v.Add("Jira", 3)
+	v.Add("München", 2)
+	return v
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"python", "python", 0},
+		{"pyhton", "python", 1},
+		{"kubernets", "kubernetes", 1},
+		{"jvaa", "java", 1},
+		{"engneer", "engineer", 1},
+		{"enginere", "engineer", 1},
+		{"kitten", "sitting", 3},
+		{"", "go", 2},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, distance([]rune(tt.a), []rune(tt.b), 5), "%s/%s", tt.a, tt.b)
+	}
+	assert.Equal(t, 3, distance([]rune("kitten"), []rune("sitting"), 2), "stops past the limit")
+}
+
+func TestCorrect(t *testing.T) {
+	v := testVocabulary()
+
+	assert.Equal(t, 9, v.Len())
+	assert.Equal(t, "kubernetes", v.Correct("kubernets"))
+	assert.Equal(t, "python", v.Correct("pyhton"))
+	assert.Equal(t, "python", v.Correct("pythn"))
+	assert.Equal(t, "engineer", v.Correct("enginer"))
+	assert.Equal(t, "munchen", v.Correct("munchn"))
+	// Equally close, the more frequent word wins
+	assert.Equal(t, "java", v.Correct("jiva"))
+
+	assert.Equal(t, "", v.Correct("python"), "known words")
+	assert.Equal(t, "", v.Correct("jv"), "short words")
+	assert.Equal(t, "", v.Correct("jvaa1"), "words with digits")
+	assert.Equal(t, "", v.Correct("accountant"), "nothing close")
+	assert.Equal(t, "", v.Correct("pyt"), "short words take a single edit")
+}
+
+func TestCorrectQuery(t *testing.T) {
+	v := testVocabulary()
+
+	assert.Equal(t, "senior kubernetes Engineer", v.CorrectQuery("senoir Kubernets Engineer", nil))
+	assert.Equal(t, "python, remote", v.CorrectQuery("pyhton, remote", nil))
+	assert.Equal(t, "", v.CorrectQuery("python developer", nil))
+	assert.Equal(t, "", v.CorrectQuery("the", nil), "stop words")
+	assert.Equal(t, "", v.CorrectQuery("", nil))
+	keep := func(word string) bool { return word == "pytorc" }
+	assert.Equal(t, "", v.CorrectQuery("pytorc", keep))
+}
@@ -0,0 +1,157 @@
+// Package suggest is an in-memory prefix index for typeahead suggestions. Lookups are a
+// binary search over sorted keys, so they stay well under a millisecond for the few
+// hundred thousand names a job board has.
+package suggest
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Suggestion types
+const (
+	TypeSkill    = "skill"
+	TypeCompany  = "company"
+	TypeTitle    = "title"
+	TypeLocation = "location"
+	TypeCategory = "category"
+)
+
+// Types lists every suggestion type
+var Types = []string{TypeSkill, TypeCompany, TypeTitle, TypeLocation, TypeCategory}
+
+// How an entry matched the query
+const (
+	MatchPrefix = "prefix" // The label starts with the query
+	MatchWord   = "word"   // A later word of the label starts with the query
+)
+
+// Entry is one suggestible value
+type Entry struct {
+	Type  string
+	Label string
+	ID    uint   // Row ID, 0 when the value has no row (titles, cities)
+	Slug  string // Value for the matching search filter
+	Count int64  // Jobs with this value, the ranking
+}
+
+// Match is an entry found by Search
+type Match struct {
+	Entry
+	Match string
+}
+
+type indexKey struct {
+	key   string
+	entry int
+	word  bool
+}
+
+// Index finds entries by the prefix of their label or of any word in it
+type Index struct {
+	entries []Entry
+	keys    []indexKey
+}
+
+// NewIndex indexes entries by their normalized label and every word suffix of it, so
+// "eng" finds "Backend Engineer"
+func NewIndex(entries []Entry) *Index {
+	index := &Index{entries: entries}
+	for i, entry := range entries {
+		words := strings.Fields(Normalize(entry.Label))
+		for w := range words {
+			index.keys = append(index.keys, indexKey{key: strings.Join(words[w:], " "), entry: i, word: w > 0})
+		}
+	}
+	sort.Slice(index.keys, func(i, j int) bool { return index.keys[i].key < index.keys[j].key })
+	return index
+}
+
+// Len returns the number of indexed entries
+func (index *Index) Len() int {
+	return len(index.entries)
+}
+
+// Search returns up to limit entries of the given types (all when empty) matching query,
+// most jobs first. Label prefix matches rank above word matches with the same count.
+func (index *Index) Search(query string, types []string, limit int) []Match {
+	query = Normalize(query)
+	if query == "" || limit < 1 {
+		return []Match{}
+	}
+	wanted := make(map[string]bool, len(types))
+	for _, t := range types {
+		wanted[t] = true
+	}
+
+	// Broad queries match most of the index, so dedupe with a flat slice and keep only
+	// the best limit matches instead of sorting all of them
+	matched := make([]uint8, len(index.entries)) // 0 unseen, 1 word, 2 prefix
+	var candidates []int
+	start := sort.Search(len(index.keys), func(i int) bool { return index.keys[i].key >= query })
+	for _, key := range index.keys[start:] {
+		if !strings.HasPrefix(key.key, query) {
+			break
+		}
+		if len(wanted) > 0 && !wanted[index.entries[key.entry].Type] {
+			continue
+		}
+		if matched[key.entry] == 0 {
+			candidates = append(candidates, key.entry)
+		}
+		if !key.word {
+			matched[key.entry] = 2
+		} else if matched[key.entry] == 0 {
+			matched[key.entry] = 1
+		}
+	}
+
+	matches := make([]Match, 0, limit+1)
+	for _, i := range candidates {
+		match := Match{Entry: index.entries[i], Match: MatchPrefix}
+		if matched[i] == 1 {
+			match.Match = MatchWord
+		}
+		if len(matches) == limit && !ranksBefore(match, matches[limit-1]) {
+			continue
+		}
+		pos := sort.Search(len(matches), func(j int) bool { return ranksBefore(match, matches[j]) })
+		matches = append(matches, Match{})
+		copy(matches[pos+1:], matches[pos:])
+		matches[pos] = match
+		if len(matches) > limit {
+			matches = matches[:limit]
+		}
+	}
+	return matches
+}
+
+// ranksBefore orders matches by job count, then prefix before word matches, then
+// shorter labels
+func ranksBefore(a, b Match) bool {
+	if a.Count != b.Count {
+		return a.Count > b.Count
+	}
+	if a.Match != b.Match {
+		return a.Match == MatchPrefix
+	}
+	if len(a.Label) != len(b.Label) {
+		return len(a.Label) < len(b.Label)
+	}
+	return a.Label < b.Label
+}
+
+// Normalize lower-cases s and turns punctuation into spaces, keeping the characters
+// that matter in skill names such as "C++", "C#" and "Node.js"
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("+#.", r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
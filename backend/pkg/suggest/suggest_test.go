@@ -0,0 +1,68 @@
+package suggest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func labels(matches []Match) []string {
+	result := make([]string, len(matches))
+	for i, match := range matches {
+		result[i] = match.Type + ":" + match.Label + ":" + match.Match
+	}
+	return result
+}
+
+func TestIndexSearch(t *testing.T) {
+	index := NewIndex([]Entry{
+		{Type: TypeSkill, Label: "Go", Slug: "go", ID: 1, Count: 40},
+		{Type: TypeSkill, Label: "GraphQL", Slug: "graphql", ID: 2, Count: 12},
+		{Type: TypeSkill, Label: "C++", Slug: "c++", ID: 3, Count: 8},
+		{Type: TypeSkill, Label: "Node.js", Slug: "node.js", ID: 4, Count: 30},
+		{Type: TypeCompany, Label: "Google", Slug: "google", ID: 7, Count: 12},
+		{Type: TypeTitle, Label: "Senior Go Engineer", Slug: "Senior Go Engineer", Count: 5},
+		{Type: TypeTitle, Label: "Backend Engineer", Slug: "Backend Engineer", Count: 9},
+		{Type: TypeLocation, Label: "Germany", Slug: "DE", ID: 3, Count: 20},
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		types []string
+		limit int
+		want  []string
+	}{
+		{"ranked by job count", "g", nil, 10, []string{"skill:Go:prefix", "location:Germany:prefix", "company:Google:prefix", "skill:GraphQL:prefix", "title:Senior Go Engineer:word"}},
+		{"prefix beats word on equal count, shorter first", "go", nil, 10, []string{"skill:Go:prefix", "company:Google:prefix", "title:Senior Go Engineer:word"}},
+		{"filtered by type", "g", []string{TypeCompany, TypeLocation}, 10, []string{"location:Germany:prefix", "company:Google:prefix"}},
+		{"limit", "g", nil, 2, []string{"skill:Go:prefix", "location:Germany:prefix"}},
+		{"multi-word prefix", "backend eng", nil, 10, []string{"title:Backend Engineer:prefix"}},
+		{"word prefix", "engineer", nil, 10, []string{"title:Backend Engineer:word", "title:Senior Go Engineer:word"}},
+		{"case and punctuation", "  NODE.J ", nil, 10, []string{"skill:Node.js:prefix"}},
+		{"symbols kept", "c+", nil, 10, []string{"skill:C++:prefix"}},
+		{"no match", "rust", nil, 10, []string{}},
+		{"empty query", " - ", nil, 10, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, labels(index.Search(tt.query, tt.types, tt.limit)))
+		})
+	}
+}
+
+func TestIndexSearchSpeed(t *testing.T) {
+	entries := make([]Entry, 0, 200000)
+	for i := 0; i < 200000; i++ {
+		entries = append(entries, Entry{Type: TypeTitle, Label: fmt.Sprintf("Senior Software Engineer %d", i), Count: int64(i % 100)})
+	}
+	index := NewIndex(entries)
+
+	start := time.Now()
+	matches := index.Search("senior software engineer 1", nil, 10)
+	assert.Len(t, matches, 10)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
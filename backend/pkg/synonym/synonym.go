@@ -0,0 +1,224 @@
+// Package synonym expands search queries with a dictionary of synonyms, so "SWE" also
+// finds "Software Engineer". Rules are written in the synonyms.txt format of Solr:
+//
+//	# Equivalent phrases, each one matches all the others
+//	frontend, front-end, front end
+//	# One-way: the phrases left of => also match the ones on the right, not the reverse
+//	swe, sde => software engineer
+//
+// Unlike Solr, a one-way rule keeps the phrases it applies to and only adds alternatives.
+package synonym
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"github.com/bhati00/workova/backend/pkg/language"
+)
+
+// MaxPhraseWords is the number of words a phrase of a rule can have
+const MaxPhraseWords = 5
+
+// ErrInvalidRule is returned for rules that can't be written to or read from a synonyms file
+var ErrInvalidRule = errors.New("invalid synonym rule")
+
+// Rule is one line of a synonyms file
+type Rule struct {
+	Terms      []string // Phrases the rule applies to
+	Expansions []string // Phrases a one-way rule adds to Terms, empty when Terms are equivalent
+}
+
+// Bidirectional reports whether every term of the rule matches all the others
+func (r Rule) Bidirectional() bool {
+	return len(r.Expansions) == 0
+}
+
+// String formats the rule as a line of a synonyms file
+func (r Rule) String() string {
+	line := strings.Join(r.Terms, ", ")
+	if !r.Bidirectional() {
+		line += " => " + strings.Join(r.Expansions, ", ")
+	}
+	return line
+}
+
+// Validate checks that the rule has something to expand and that its phrases survive a
+// round trip through a synonyms file
+func (r Rule) Validate() error {
+	if r.Bidirectional() && len(r.Terms) < 2 {
+		return fmt.Errorf("%w: equivalent terms need at least two phrases", ErrInvalidRule)
+	}
+	if len(r.Terms) == 0 {
+		return fmt.Errorf("%w: no terms to expand", ErrInvalidRule)
+	}
+	for _, phrase := range append(append([]string{}, r.Terms...), r.Expansions...) {
+		if strings.ContainsAny(phrase, ",\n\r") || strings.Contains(phrase, "=>") || strings.HasPrefix(strings.TrimSpace(phrase), "#") {
+			return fmt.Errorf("%w: %q can't contain commas, line breaks or => or start with #", ErrInvalidRule, phrase)
+		}
+		words := language.Words(phrase)
+		if len(words) == 0 {
+			return fmt.Errorf("%w: %q has no words", ErrInvalidRule, phrase)
+		}
+		if len(words) > MaxPhraseWords {
+			return fmt.Errorf("%w: %q has more than %d words", ErrInvalidRule, phrase, MaxPhraseWords)
+		}
+	}
+	return nil
+}
+
+// ParseLine parses a single rule
+func ParseLine(line string) (Rule, error) {
+	sides := strings.Split(line, "=>")
+	if len(sides) > 2 {
+		return Rule{}, fmt.Errorf("%w: more than one =>", ErrInvalidRule)
+	}
+	rule := Rule{Terms: splitPhrases(sides[0])}
+	if len(sides) == 2 {
+		if rule.Expansions = splitPhrases(sides[1]); len(rule.Expansions) == 0 {
+			return Rule{}, fmt.Errorf("%w: nothing right of =>", ErrInvalidRule)
+		}
+	}
+	return rule, rule.Validate()
+}
+
+func splitPhrases(s string) []string {
+	var phrases []string
+	for _, phrase := range strings.Split(s, ",") {
+		if phrase = strings.Join(strings.Fields(phrase), " "); phrase != "" {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases
+}
+
+// Parse reads the rules of a synonyms file. Blank lines and lines starting with # are skipped.
+func Parse(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule, err := ParseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, scanner.Err()
+}
+
+// Write writes rules in the format Parse reads, one per line
+func Write(w io.Writer, rules []Rule) error {
+	for _, rule := range rules {
+		if _, err := fmt.Fprintln(w, rule.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dictionary looks up the synonyms of the phrases of a query
+type Dictionary struct {
+	expansions map[string][]string // Normalized phrase to the normalized phrases it also matches
+	maxWords   int
+}
+
+// NewDictionary builds the dictionary of rules. A phrase in several rules expands to the
+// synonyms of all of them.
+func NewDictionary(rules []Rule) *Dictionary {
+	d := &Dictionary{expansions: make(map[string][]string)}
+	add := func(phrase string, synonyms []string) {
+		words := strings.Count(phrase, " ") + 1
+		d.maxWords = max(d.maxWords, words)
+		for _, synonym := range synonyms {
+			if synonym != phrase && !contains(d.expansions[phrase], synonym) {
+				d.expansions[phrase] = append(d.expansions[phrase], synonym)
+			}
+		}
+	}
+	for _, rule := range rules {
+		terms := normalizeAll(rule.Terms)
+		if rule.Bidirectional() {
+			for _, term := range terms {
+				add(term, terms)
+			}
+			continue
+		}
+		expansions := normalizeAll(rule.Expansions)
+		for _, term := range terms {
+			add(term, expansions)
+		}
+	}
+	return d
+}
+
+// Expand splits query into groups of alternative phrases, one group per phrase of the
+// query. The first alternative of a group is the phrase as it appears in the query, the
+// others are its synonyms, lower-cased. The longest phrase with synonyms wins, words
+// without synonyms make a group of their own.
+func (d *Dictionary) Expand(query string) [][]string {
+	spans := language.WordSpans(query)
+	keys := make([]string, len(spans))
+	for i, span := range spans {
+		keys[i] = strings.Join(language.Words(query[span[0]:span[1]]), "")
+	}
+
+	var groups [][]string
+	for i := 0; i < len(spans); {
+		size := 1
+		var synonyms []string
+		if d != nil {
+			for n := min(d.maxWords, len(spans)-i); n > 0; n-- {
+				if expansions, ok := d.expansions[strings.Join(keys[i:i+n], " ")]; ok {
+					size, synonyms = n, expansions
+					break
+				}
+			}
+		}
+		groups = append(groups, append([]string{query[spans[i][0]:spans[i+size-1][1]]}, synonyms...))
+		i += size
+	}
+	return groups
+}
+
+// normalize turns a phrase into the form Expand looks it up by
+func normalize(phrase string) string {
+	return strings.Join(language.Words(phrase), " ")
+}
+
+func normalizeAll(phrases []string) []string {
+	normalized := make([]string, 0, len(phrases))
+	for _, phrase := range phrases {
+		if n := normalize(phrase); n != "" && !contains(normalized, n) {
+			normalized = append(normalized, n)
+		}
+	}
+	return normalized
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+var defaultDictionary atomic.Pointer[Dictionary]
+
+// Default returns the dictionary search queries are expanded with, empty until SetDefault
+func Default() *Dictionary {
+	return defaultDictionary.Load()
+}
+
+// SetDefault replaces the dictionary search queries are expanded with
+func SetDefault(d *Dictionary) {
+	defaultDictionary.Store(d)
+}
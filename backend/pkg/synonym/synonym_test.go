@@ -0,0 +1,91 @@
+package synonym
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRules = `# Job titles
+swe, sde => software engineer, software developer
+frontend, front-end, front end
+
+ML => Machine Learning
+frontend => ui engineer
+`
+
+func TestParse(t *testing.T) {
+	rules, err := Parse(strings.NewReader(testRules))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Rule{
+		{Terms: []string{"swe", "sde"}, Expansions: []string{"software engineer", "software developer"}},
+		{Terms: []string{"frontend", "front-end", "front end"}},
+		{Terms: []string{"ML"}, Expansions: []string{"Machine Learning"}},
+		{Terms: []string{"frontend"}, Expansions: []string{"ui engineer"}},
+	}, rules)
+	assert.False(t, rules[0].Bidirectional())
+	assert.True(t, rules[1].Bidirectional())
+
+	var written bytes.Buffer
+	require.NoError(t, Write(&written, rules))
+	reparsed, err := Parse(&written)
+	require.NoError(t, err)
+	assert.Equal(t, rules, reparsed)
+}
+
+func TestParseLineErrors(t *testing.T) {
+	for _, line := range []string{
+		"swe",
+		"swe =>",
+		"=> software engineer",
+		"a => b => c",
+		"a, !!!",
+		"one two three four five six, six",
+	} {
+		_, err := ParseLine(line)
+		assert.True(t, errors.Is(err, ErrInvalidRule), line)
+	}
+
+	_, err := Parse(strings.NewReader("swe => software engineer\nswe\n"))
+	assert.ErrorContains(t, err, "line 2")
+	assert.ErrorIs(t, Rule{Terms: []string{"a,b", "c"}}.Validate(), ErrInvalidRule)
+}
+
+func TestExpand(t *testing.T) {
+	rules, err := Parse(strings.NewReader(testRules))
+	require.NoError(t, err)
+	d := NewDictionary(rules)
+
+	tests := []struct {
+		query string
+		want  [][]string
+	}{
+		{"SWE Berlin", [][]string{{"SWE", "software engineer", "software developer"}, {"Berlin"}}},
+		{"frontend", [][]string{{"frontend", "front end", "ui engineer"}}},
+		{"Front-End jobs", [][]string{{"Front-End", "frontend"}, {"jobs"}}},
+		{"ml", [][]string{{"ml", "machine learning"}}},
+		// One-way rules don't expand back
+		{"machine learning", [][]string{{"machine"}, {"learning"}}},
+		{"software engineer", [][]string{{"software"}, {"engineer"}}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, d.Expand(tt.query), tt.query)
+	}
+
+	var empty *Dictionary
+	assert.Equal(t, [][]string{{"SWE"}, {"C++"}}, empty.Expand("SWE, C++"))
+}
+
+func TestDefault(t *testing.T) {
+	previous := Default()
+	defer SetDefault(previous)
+
+	SetDefault(NewDictionary([]Rule{{Terms: []string{"k8s", "kubernetes"}}}))
+	assert.Equal(t, [][]string{{"k8s", "kubernetes"}}, Default().Expand("k8s"))
+}
@@ -15,3 +15,10 @@ func Int(i int) *int {
 func Bool(b bool) *bool {
 	return &b
 }
+
+func StringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
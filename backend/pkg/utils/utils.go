@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -37,3 +38,24 @@ func ParseToRFC3339(dateStr string) *string {
 	fmt.Printf("failed to parse date %q, falling back to current UTC time\n", dateStr)
 	return String(time.Now().UTC().Format(time.RFC3339))
 }
+
+// ParseDateOrAge reads an RFC 3339 time, a YYYY-MM-DD date or an age such as 7d, 12h
+// or 2w, which is counted back from now
+func ParseDateOrAge(value string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true
+	}
+	if len(value) < 2 {
+		return time.Time{}, false
+	}
+	units := map[byte]time.Duration{'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	unit, ok := units[value[len(value)-1]]
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if !ok || err != nil || n < 0 {
+		return time.Time{}, false
+	}
+	return time.Now().Add(-time.Duration(n) * unit), true
+}